@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/tsutsumi389/real-time-auction/internal/handler"
 	"github.com/tsutsumi389/real-time-auction/internal/middleware"
 	"github.com/tsutsumi389/real-time-auction/internal/repository"
@@ -46,6 +49,8 @@ func main() {
 	itemRepo := repository.NewItemRepository(db)
 	mediaRepo := repository.NewItemMediaRepository(db)
 	dashboardRepo := repository.NewDashboardRepository(db)
+	messageRepo := repository.NewAuctionMessageRepository(db)
+	pointGrantBatchRepo := repository.NewPointGrantBatchRepository(db)
 
 	// ストレージサービス初期化
 	storageService, err := storage.NewStorageService()
@@ -69,6 +74,16 @@ func main() {
 	bidService := service.NewBidService(db, redisClient, bidRepo, pointRepo, auctionRepo)
 	itemService := service.NewItemService(itemRepo)
 	dashboardService := service.NewDashboardService(dashboardRepo)
+	wsTicketService := service.NewWSTicketService(redisClient)
+	chatService := service.NewChatService(messageRepo, auctionRepo, bidderRepo, redisClient)
+	reconciliationService := service.NewPointReconciliationService(db, pointRepo)
+	pointExpiryService := service.NewPointExpiryService(db, pointRepo)
+	pointGrantBatchService := service.NewPointGrantBatchService(db, pointGrantBatchRepo, pointRepo)
+	// 閾値を超えるポイント付与は別のシステム管理者の承認待ちになる（一括付与では受け付けない。0以下で無効）
+	grantApprovalThreshold := getEnvAsInt64("POINT_GRANT_APPROVAL_THRESHOLD", 100000)
+	bidderService.SetGrantApprovalThreshold(grantApprovalThreshold)
+	pointGrantBatchService.SetGrantApprovalThreshold(grantApprovalThreshold)
+	pointGrantRequestService := service.NewPointGrantRequestService(bidderRepo, redisClient)
 
 	// ハンドラ初期化
 	authHandler := handler.NewAuthHandler(authService)
@@ -78,6 +93,11 @@ func main() {
 	bidHandler := handler.NewBidHandler(pointService, bidService)
 	itemHandler := handler.NewItemHandler(itemService)
 	dashboardHandler := handler.NewDashboardHandler(dashboardService)
+	wsTicketHandler := handler.NewWSTicketHandler(wsTicketService)
+	chatHandler := handler.NewChatHandler(chatService)
+	reconciliationHandler := handler.NewPointReconciliationHandler(reconciliationService)
+	pointGrantBatchHandler := handler.NewPointGrantBatchHandler(pointGrantBatchService)
+	pointGrantRequestHandler := handler.NewPointGrantRequestHandler(pointGrantRequestService)
 	storageTestHandler := handler.NewStorageTestHandler(storageService)
 
 	// メディアハンドラ初期化
@@ -93,6 +113,9 @@ func main() {
 		MaxVideosPerItem: 3,
 	})
 
+	// 冪等性キーのストア（入札・ポイント付与の再送による二重処理を防ぐ）
+	idempotency := middleware.Idempotency(middleware.NewRedisIdempotencyStore(redisClient), middleware.LoadIdempotencyTTL())
+
 	// Ginルーター初期化
 	router := gin.Default()
 
@@ -130,6 +153,10 @@ func main() {
 		api.GET("/auctions", auctionHandler.GetBidderAuctionList)
 		// オークション詳細取得（すべてのユーザーがアクセス可能）
 		api.GET("/auctions/:id", auctionHandler.GetAuctionDetail)
+		// オークションのライブ状態取得（すべてのユーザーがアクセス可能）
+		api.GET("/auctions/:id/live", auctionHandler.GetLiveState)
+		// オークションのアナウンス一覧取得（すべてのユーザーがアクセス可能）
+		api.GET("/auctions/:id/messages", chatHandler.GetAnnouncements)
 		// 商品メディア一覧取得（すべてのユーザーがアクセス可能）
 		api.GET("/items/:id/media", mediaHandler.GetMediaList)
 
@@ -146,13 +173,18 @@ func main() {
 			// 現在のユーザー情報取得
 			protected.GET("/admin/me", adminHandler.GetCurrentAdmin)
 
+			// WebSocket接続用の使い捨てチケット発行（管理者・入札者共通）
+			protected.POST("/ws/ticket", wsTicketHandler.IssueTicket)
+
 			// 入札者専用エンドポイント
 			bidder := protected.Group("/bidder")
 			bidder.Use(middleware.RequireBidder())
 			{
 				bidder.GET("/points", bidHandler.GetPoints)
-				bidder.POST("/items/:id/bid", bidHandler.PlaceBid)
+				bidder.POST("/items/:id/bid", idempotency, bidHandler.PlaceBid)
 				bidder.GET("/items/:id/bids", bidHandler.GetBidHistory)
+				// アナウンスと自分の質問の一覧取得
+				bidder.GET("/auctions/:id/messages", chatHandler.GetBidderMessages)
 			}
 
 			// システム管理者専用エンドポイント
@@ -179,12 +211,31 @@ func main() {
 				// 入札者更新
 				systemAdmin.PUT("/admin/bidders/:id", bidderHandler.UpdateBidder)
 				// 入札者へのポイント付与
-				systemAdmin.POST("/admin/bidders/:id/points", bidderHandler.GrantPoints)
+				systemAdmin.POST("/admin/bidders/:id/points", idempotency, bidderHandler.GrantPoints)
+				// ポイント調整（加算・減算、理由必須）
+				systemAdmin.POST("/admin/bidders/:id/points/adjustments", idempotency, bidderHandler.AdjustPoints)
 				// 入札者のポイント履歴取得
 				systemAdmin.GET("/admin/bidders/:id/points/history", bidderHandler.GetPointHistory)
 				// 入札者状態変更
 				systemAdmin.PATCH("/admin/bidders/:id/status", bidderHandler.UpdateBidderStatus)
 
+				// ポイント台帳の照合（不整合の報告）
+				systemAdmin.GET("/admin/points/reconciliation", reconciliationHandler.GetReconciliation)
+				// ポイント台帳の照合（adjust履歴による補正を含む）
+				systemAdmin.POST("/admin/points/reconciliation", reconciliationHandler.Reconcile)
+				// ポイント一括付与（CSV/JSON、デフォルトはdry run。dry_run=falseで一括適用）
+				systemAdmin.POST("/admin/points/grant-batches", idempotency, pointGrantBatchHandler.CreateBatch)
+				// ポイント一括付与の詳細（バッチで作成したポイント履歴を含む）
+				systemAdmin.GET("/admin/points/grant-batches/:id", pointGrantBatchHandler.GetBatch)
+				// ポイント一括付与の取り消し
+				systemAdmin.POST("/admin/points/grant-batches/:id/reverse", idempotency, pointGrantBatchHandler.ReverseBatch)
+				// 承認待ちポイント付与の一覧取得（status=pending|approved|rejected）
+				systemAdmin.GET("/admin/points/grant-requests", pointGrantRequestHandler.ListRequests)
+				// 承認待ちポイント付与の承認（申請者以外のシステム管理者のみ）
+				systemAdmin.POST("/admin/points/grant-requests/:id/approve", idempotency, pointGrantRequestHandler.ApproveRequest)
+				// 承認待ちポイント付与の却下
+				systemAdmin.POST("/admin/points/grant-requests/:id/reject", idempotency, pointGrantRequestHandler.RejectRequest)
+
 				// オークション中止（system_adminのみ）
 				systemAdmin.POST("/admin/auctions/:id/cancel", auctionHandler.CancelAuctionWithReason)
 			}
@@ -212,6 +263,21 @@ func main() {
 				adminOrAuctioneer.POST("/admin/auctions/:id/end", auctionHandler.EndAuction)
 				// オークション参加者一覧取得
 				adminOrAuctioneer.GET("/admin/auctions/:id/participants", auctionHandler.GetParticipants)
+				// 入札の遅延分布取得（先着紛争の検証用）
+				adminOrAuctioneer.GET("/admin/auctions/:id/bid-latency", auctionHandler.GetBidLatency)
+
+				// アナウンス・チャットのモデレーション
+				// オークションのメッセージ記録取得（削除済みを含む）
+				adminOrAuctioneer.GET("/admin/auctions/:id/messages", chatHandler.GetAdminMessages)
+				// アナウンス送信
+				adminOrAuctioneer.POST("/admin/auctions/:id/announcements", chatHandler.Announce)
+				// 入札者からの質問の受付設定
+				adminOrAuctioneer.PUT("/admin/auctions/:id/chat", chatHandler.UpdateChatSettings)
+				// メッセージ削除
+				adminOrAuctioneer.DELETE("/admin/auctions/:id/messages/:messageId", chatHandler.DeleteMessage)
+				// 入札者のミュート・ミュート解除
+				adminOrAuctioneer.PUT("/admin/auctions/:id/mutes/:bidderId", chatHandler.MuteBidder)
+				adminOrAuctioneer.DELETE("/admin/auctions/:id/mutes/:bidderId", chatHandler.UnmuteBidder)
 
 				// オークション商品紐づけ
 				adminOrAuctioneer.POST("/admin/auctions/:id/items/assign", itemHandler.AssignItems)
@@ -249,10 +315,18 @@ func main() {
 				adminOrAuctioneer.GET("/admin/items/:id/bids", auctionHandler.GetBidHistory)
 				// 価格開示履歴取得
 				adminOrAuctioneer.GET("/admin/items/:id/price-history", auctionHandler.GetPriceHistory)
+				// 入札キューの監査記録（到着順・処理結果）
+				adminOrAuctioneer.GET("/admin/items/:id/bid-queue", auctionHandler.GetBidQueue)
 			}
 		}
 	}
 
+	// ポイント台帳の定期照合（POINT_RECONCILE_INTERVAL_MINUTES=0で無効）
+	startPointReconciliationJob(reconciliationService, time.Duration(getEnvAsInt("POINT_RECONCILE_INTERVAL_MINUTES", 60))*time.Minute)
+
+	// 有効期限切れポイントの定期失効（POINT_EXPIRY_INTERVAL_MINUTES=0で無効）
+	startPointExpiryJob(pointExpiryService, redisClient, time.Duration(getEnvAsInt("POINT_EXPIRY_INTERVAL_MINUTES", 60))*time.Minute)
+
 	// サーバー起動
 	log.Printf("Starting REST API server on port %s (env: %s)", port, env)
 	if err := router.Run(":" + port); err != nil {
@@ -260,6 +334,73 @@ func main() {
 	}
 }
 
+// startPointReconciliationJob はポイント台帳を定期的に照合し、不整合をログに出力する
+// 補正は行わない（管理者が照合エンドポイントから実行する）
+func startPointReconciliationJob(reconciliationService *service.PointReconciliationService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			report, err := reconciliationService.Reconcile(false, nil)
+			if err != nil {
+				log.Printf("[PointReconciliation] Failed to reconcile points: %v", err)
+				continue
+			}
+			if len(report.Discrepancies) == 0 {
+				continue
+			}
+
+			log.Printf("[PointReconciliation] %d discrepancies found in %d bidders", len(report.Discrepancies), report.BiddersChecked)
+			for _, d := range report.Discrepancies {
+				log.Printf("[PointReconciliation] bidder=%s type=%s field=%s expected=%d actual=%d", d.BidderID, d.Type, d.Field, d.Expected, d.Actual)
+			}
+		}
+	}()
+}
+
+// pointExpiryLockKey は失効ジョブを1つのAPIインスタンスだけで実行するためのロックキー
+const pointExpiryLockKey = "lock:point_expiry"
+
+// startPointExpiryJob は有効期限を過ぎたポイントロットの未使用分を定期的に失効させる
+// 複数のAPIインスタンスが起動していても、各周期でRedisのロックを取得した1インスタンスだけが実行する
+func startPointExpiryJob(pointExpiryService *service.PointExpiryService, redisClient *redis.Client, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	// ロックは解放せず、周期より少し短いTTLで失効させる（同じ周期に他のインスタンスが再実行しないようにする）
+	lockTTL := interval - interval/10
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			acquired, err := redisClient.SetNX(context.Background(), pointExpiryLockKey, time.Now().Unix(), lockTTL).Result()
+			if err != nil {
+				log.Printf("[PointExpiry] Failed to acquire lock: %v", err)
+				continue
+			}
+			if !acquired {
+				continue
+			}
+
+			histories, err := pointExpiryService.ExpirePoints(time.Now())
+			if err != nil {
+				log.Printf("[PointExpiry] Failed to expire points: %v", err)
+			}
+			for _, h := range histories {
+				log.Printf("[PointExpiry] bidder=%s expired=%d", h.BidderID, -h.Amount)
+			}
+		}
+	}()
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -0,0 +1,34 @@
+// eventschema はリアルタイムイベントのJSON Schemaを出力する
+// フロントエンド・iOSクライアントはこのスキーマからイベントの型を生成する
+//
+// 使用方法: go run ./cmd/eventschema -o ../docs/events.schema.json
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/tsutsumi389/real-time-auction/internal/events"
+)
+
+func main() {
+	output := flag.String("o", "", "出力先ファイル（省略時は標準出力）")
+	flag.Parse()
+
+	schema, err := events.SchemaJSON()
+	if err != nil {
+		log.Fatalf("Failed to generate event schema: %v", err)
+	}
+
+	if *output == "" {
+		if _, err := os.Stdout.Write(schema); err != nil {
+			log.Fatalf("Failed to write event schema: %v", err)
+		}
+		return
+	}
+
+	if err := os.WriteFile(*output, schema, 0o644); err != nil {
+		log.Fatalf("Failed to write event schema: %v", err)
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
 	"github.com/tsutsumi389/real-time-auction/internal/ws"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -26,6 +29,14 @@ func main() {
 	dbUser := getEnv("POSTGRES_USER", "auction_user")
 	dbPassword := getEnv("POSTGRES_PASSWORD", "auction_pass_dev_only")
 	dbName := getEnv("POSTGRES_DB", "auction_db")
+	maxSpectators := getEnv("WS_MAX_SPECTATORS", "1000")
+
+	// JWT_SECRETが未設定の場合はデフォルト値で動作させず起動を中止する
+	// （REST APIと異なる鍵でトークンを検証してしまうのを防ぐ）
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		log.Fatal("JWT_SECRET must be set")
+	}
 
 	// Ginモード設定
 	if env == "production" {
@@ -57,14 +68,57 @@ func main() {
 
 	// Repository初期化
 	auctionRepo := repository.NewAuctionRepository(db)
+	bidRepo := repository.NewBidRepository(db)
+	pointRepo := repository.NewPointRepository(db)
+	bidderRepo := repository.NewBidderRepository(db)
+	messageRepo := repository.NewAuctionMessageRepository(db)
+
+	// Service初期化（WebSocket経由の入札・主催者操作はREST APIと同じロジックを使用）
+	bidService := service.NewBidService(db, redisClient, bidRepo, pointRepo, auctionRepo)
+	auctionService := service.NewAuctionService(db, auctionRepo, bidRepo, pointRepo, redisClient)
+	chatService := service.NewChatService(messageRepo, auctionRepo, bidderRepo, redisClient)
+
+	// 認証（REST APIで発行した使い捨てチケット、またはAuthorizationヘッダーのJWT）
+	authenticator := ws.NewAuthenticator(service.NewJWTService(jwtSecret), service.NewWSTicketService(redisClient))
 
 	// Hubを初期化
-	hub := ws.NewHub(redisClient, auctionRepo)
+	hub := ws.NewHub(redisClient, auctionRepo, bidService, auctionService)
+
+	// ユーザー・IPアドレスごとの接続数上限を設定
+	limits := ws.DefaultConnectionLimits()
+	limits.MaxPerUser = getEnvAsInt("WS_MAX_CONNECTIONS_PER_USER", limits.MaxPerUser)
+	limits.MaxPerBidder = getEnvAsInt("WS_MAX_CONNECTIONS_PER_BIDDER", limits.MaxPerBidder)
+	limits.MaxPerIP = getEnvAsInt("WS_MAX_CONNECTIONS_PER_IP", limits.MaxPerIP)
+	switch policy := ws.BidderSessionPolicy(getEnv("WS_BIDDER_SESSION_POLICY", string(limits.BidderSession))); policy {
+	case ws.BidderSessionKickOldest, ws.BidderSessionReject:
+		limits.BidderSession = policy
+	default:
+		log.Printf("Invalid WS_BIDDER_SESSION_POLICY=%q, using %s", policy, limits.BidderSession)
+	}
+	hub.SetConnectionLimits(limits)
+
+	// アナウンス・チャット（永続化とモデレーション）
+	hub.SetChatService(chatService)
+
+	// 観覧者（未認証接続）の接続数上限を設定
+	if n, err := strconv.ParseInt(maxSpectators, 10, 64); err == nil && n >= 0 {
+		hub.SetMaxSpectators(n)
+	} else {
+		log.Printf("Invalid WS_MAX_SPECTATORS=%q, using default", maxSpectators)
+	}
+
 	go hub.Run()
 
 	// Ginルーター初期化
 	router := gin.Default()
 
+	// IPアドレスごとの接続数上限はc.ClientIP()で数えるため、X-Forwarded-ForとX-Real-IPは
+	// TRUSTED_PROXIES（リバースプロキシのアドレス、カンマ区切り）からの接続の場合のみ信頼する
+	// 未設定の場合はどのプロキシも信頼せず、接続元のアドレスを使用する
+	if err := router.SetTrustedProxies(getEnvAsList("TRUSTED_PROXIES")); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
+
 	// ヘルスチェックエンドポイント
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -76,7 +130,12 @@ func main() {
 
 	// WebSocketエンドポイント
 	router.GET("/ws", func(c *gin.Context) {
-		ws.ServeWs(hub, c)
+		ws.ServeWs(hub, authenticator, c)
+	})
+
+	// Server-Sent Eventsエンドポイント（WebSocketが使えない環境向けのフォールバック）
+	router.GET("/sse/auctions/:id", func(c *gin.Context) {
+		ws.ServeSSE(hub, c)
 	})
 
 	// サーバー起動
@@ -92,3 +151,26 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvAsInt(key string, defaultValue int) int {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		log.Printf("Warning: Invalid integer value for %s: %s, using default: %d", key, valueStr, defaultValue)
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvAsList(key string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
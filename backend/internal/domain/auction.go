@@ -23,6 +23,7 @@ type Auction struct {
 	Description string        `gorm:"type:text" json:"description"`
 	Status      AuctionStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
 	StartedAt   *time.Time    `gorm:"type:timestamptz" json:"started_at"`
+	ChatEnabled bool          `gorm:"not null;default:false" json:"chat_enabled"` // Whether bidders may send questions to the auctioneer
 	CreatedAt   time.Time     `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
 }
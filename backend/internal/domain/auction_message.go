@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuctionMessageKind represents the kind of an auction message
+type AuctionMessageKind string
+
+const (
+	AuctionMessageKindAnnouncement AuctionMessageKind = "announcement" // Sent by an auctioneer to everyone in the room
+	AuctionMessageKindQuestion     AuctionMessageKind = "question"     // Sent by a bidder to the auctioneers
+)
+
+// AuctionMessage represents an announcement or bidder question recorded for an auction
+type AuctionMessage struct {
+	ID         int64              `gorm:"primaryKey;autoIncrement" json:"id"`
+	AuctionID  uuid.UUID          `gorm:"type:uuid;not null;index:idx_auction_messages_auction" json:"auction_id"`
+	Kind       AuctionMessageKind `gorm:"type:varchar(20);not null" json:"kind"`
+	AdminID    *int64             `json:"admin_id,omitempty"`
+	BidderID   *uuid.UUID         `gorm:"type:uuid" json:"bidder_id,omitempty"`
+	SenderName string             `gorm:"type:varchar(100);not null" json:"sender_name"`
+	Body       string             `gorm:"type:text;not null" json:"body"`
+	DeletedAt  *time.Time         `gorm:"type:timestamptz" json:"deleted_at,omitempty"`
+	DeletedBy  *int64             `json:"deleted_by,omitempty"`
+	CreatedAt  time.Time          `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for AuctionMessage model
+func (AuctionMessage) TableName() string {
+	return "auction_messages"
+}
+
+// IsDeleted reports whether the message was removed by a moderator
+func (m *AuctionMessage) IsDeleted() bool {
+	return m.DeletedAt != nil
+}
+
+// ForBidder hides moderation details from a bidder's view
+func (m AuctionMessage) ForBidder() AuctionMessage {
+	m.AdminID = nil
+	m.DeletedBy = nil
+	return m
+}
+
+// AuctionChatMute represents a bidder muted from sending messages in an auction
+type AuctionChatMute struct {
+	AuctionID uuid.UUID `gorm:"type:uuid;primaryKey" json:"auction_id"`
+	BidderID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"bidder_id"`
+	MutedBy   int64     `gorm:"not null" json:"muted_by"`
+	Reason    *string   `gorm:"type:varchar(200)" json:"reason,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for AuctionChatMute model
+func (AuctionChatMute) TableName() string {
+	return "auction_chat_mutes"
+}
+
+// AuctionMessageListResponse represents the messages recorded for an auction
+type AuctionMessageListResponse struct {
+	AuctionID   uuid.UUID        `json:"auction_id"`
+	ChatEnabled bool             `json:"chat_enabled"`
+	Messages    []AuctionMessage `json:"messages"`
+}
+
+// UpdateChatSettingsRequest represents the request to enable or disable bidder messages
+type UpdateChatSettingsRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// MuteBidderRequest represents the request to mute a bidder in an auction
+type MuteBidderRequest struct {
+	Reason string `json:"reason" binding:"max=200"`
+}
+
+// AnnounceRequest represents the request to post an announcement to an auction room
+type AnnounceRequest struct {
+	Message string `json:"message" binding:"required"`
+}
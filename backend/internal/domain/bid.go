@@ -14,6 +14,11 @@ type Bid struct {
 	Price     int64      `gorm:"type:bigint;not null" json:"price"`
 	IsWinning bool       `gorm:"default:false;not null" json:"is_winning"`
 	BidAt     time.Time  `gorm:"type:timestamptz;not null;default:now()" json:"bid_at"`
+
+	// Timing evidence for first-come disputes
+	ReceivedAt    *time.Time `gorm:"type:timestamptz" json:"received_at,omitempty"`           // Server time the bid request was received
+	ClientRTTMs   *int64     `gorm:"column:client_rtt_ms" json:"client_rtt_ms,omitempty"`     // Connection round-trip time at the time of the bid
+	ClockOffsetMs *int64     `gorm:"column:clock_offset_ms" json:"clock_offset_ms,omitempty"` // Client clock minus server clock, as measured by the client
 }
 
 // TableName specifies the table name for Bid model
@@ -37,3 +42,72 @@ type BidHistoryResponse struct {
 	Total int64               `json:"total"`
 	Bids  []BidWithBidderInfo `json:"bids"`
 }
+
+// BidLatencyDistribution summarizes the connection round-trip times recorded with bids, in milliseconds.
+// Samples counts the bids with a recorded round-trip time (bids placed over the REST API have none).
+type BidLatencyDistribution struct {
+	BidCount int64   `gorm:"column:bid_count" json:"bid_count"`
+	Samples  int64   `gorm:"column:samples" json:"samples"`
+	P50      float64 `gorm:"column:p50" json:"p50"`
+	P90      float64 `gorm:"column:p90" json:"p90"`
+	P99      float64 `gorm:"column:p99" json:"p99"`
+	Max      float64 `gorm:"column:max" json:"max"`
+}
+
+// BidderBidLatency is the latency distribution of one bidder's bids in an auction
+type BidderBidLatency struct {
+	BidderID   uuid.UUID `gorm:"column:bidder_id" json:"bidder_id"`
+	BidderName string    `gorm:"column:bidder_name" json:"bidder_name"`
+	BidLatencyDistribution
+	MeanClockOffsetMs *float64 `gorm:"column:mean_clock_offset_ms" json:"mean_clock_offset_ms"`
+}
+
+// AuctionBidLatencyResponse represents the latency distribution of the bids in an auction
+type AuctionBidLatencyResponse struct {
+	AuctionID uuid.UUID              `json:"auction_id"`
+	Overall   BidLatencyDistribution `json:"overall"`
+	Bidders   []BidderBidLatency     `json:"bidders"`
+}
+
+// BidQueueOutcome represents how a queued bid request was resolved
+type BidQueueOutcome string
+
+const (
+	BidQueueOutcomeAccepted      BidQueueOutcome = "accepted"        // The bid was accepted
+	BidQueueOutcomeOutbidAtPrice BidQueueOutcome = "outbid_at_price" // An earlier bid at the same price was accepted
+	BidQueueOutcomeRejected      BidQueueOutcome = "rejected"        // The bid failed validation
+)
+
+// BidQueueEntry is the audit record of a bid request passing through an item's bid queue
+type BidQueueEntry struct {
+	ID          int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ItemID      uuid.UUID       `gorm:"type:uuid;not null" json:"item_id"`
+	BidderID    uuid.UUID       `gorm:"type:uuid;not null" json:"bidder_id"`
+	Price       int64           `gorm:"type:bigint;not null" json:"price"`
+	Position    *int64          `json:"position,omitempty"` // Arrival order in the item's queue; requests are processed in this order
+	Outcome     BidQueueOutcome `gorm:"type:varchar(20);not null" json:"outcome"`
+	BidID       *int64          `json:"bid_id,omitempty"`                         // Accepted bid, or the earlier bid that took the price
+	Reason      *string         `gorm:"type:varchar(50)" json:"reason,omitempty"` // Rejection reason
+	ReceivedAt  time.Time       `gorm:"type:timestamptz;not null" json:"received_at"`
+	ProcessedAt time.Time       `gorm:"type:timestamptz;not null;default:now()" json:"processed_at"`
+}
+
+// TableName specifies the table name for BidQueueEntry model
+func (BidQueueEntry) TableName() string {
+	return "bid_queue_entries"
+}
+
+// BidQueueResponse represents the bid queue audit records of an item
+type BidQueueResponse struct {
+	ItemID  uuid.UUID       `json:"item_id"`
+	Entries []BidQueueEntry `json:"entries"`
+}
+
+// OutbidAtPriceResult names the bid accepted first at the price a later bid request asked for
+type OutbidAtPriceResult struct {
+	Result        BidQueueOutcome `json:"result"`
+	AcceptedBidID int64           `json:"accepted_bid_id"`
+	Price         int64           `json:"price"`
+	AcceptedAt    time.Time       `json:"accepted_at"`
+	Position      *int64          `json:"position,omitempty"` // Arrival order of the later request
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // BidderStatus represents the status of a bidder account
@@ -67,6 +69,8 @@ const (
 	PointHistoryTypeRelease PointHistoryType = "release"
 	PointHistoryTypeConsume PointHistoryType = "consume"
 	PointHistoryTypeRefund  PointHistoryType = "refund"
+	PointHistoryTypeAdjust  PointHistoryType = "adjust" // Manual adjustment or correction of a balance discrepancy
+	PointHistoryTypeExpire  PointHistoryType = "expire" // Unused points of expired grant lots
 )
 
 // PointHistory represents a record of point transactions
@@ -76,9 +80,11 @@ type PointHistory struct {
 	Amount           int64            `gorm:"not null" json:"amount"`
 	Type             PointHistoryType `gorm:"type:varchar(50);not null" json:"type"`
 	Reason           *string          `gorm:"type:text" json:"reason"`
-	RelatedAuctionID *int64           `gorm:"type:bigint" json:"related_auction_id"`
+	RelatedAuctionID *uuid.UUID       `gorm:"type:uuid" json:"related_auction_id"`
+	RelatedItemID    *uuid.UUID       `gorm:"type:uuid" json:"related_item_id"`
 	RelatedBidID     *int64           `gorm:"type:bigint" json:"related_bid_id"`
 	AdminID          *int64           `gorm:"type:bigint" json:"admin_id"`
+	BatchID          *string          `gorm:"type:uuid" json:"batch_id"`
 	BalanceBefore    int64            `gorm:"not null" json:"balance_before"`
 	BalanceAfter     int64            `gorm:"not null" json:"balance_after"`
 	ReservedBefore   int64            `gorm:"not null" json:"reserved_before"`
@@ -116,19 +122,43 @@ type BidderListResponse struct {
 
 // GrantPointsRequest represents the request body for granting points to a bidder
 type GrantPointsRequest struct {
-	Points int64 `json:"points" binding:"required,min=1"`
+	Points    int64      `json:"points" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"` // Optional expiry of the granted points
 }
 
-// GrantPointsResponse represents the response for grant points endpoint
+// GrantPointsResponse represents the response for grant points endpoint.
+// Grants above the approval threshold are not applied: only PendingRequest is set.
 type GrantPointsResponse struct {
+	Bidder         *BidderWithPoints  `json:"bidder,omitempty"`
+	History        *PointHistory      `json:"history,omitempty"`
+	PendingRequest *PointGrantRequest `json:"pending_request,omitempty"`
+}
+
+// AdjustPointsRequest represents the request body for a manual point adjustment.
+// Amount is signed: positive adds points, negative deducts them.
+type AdjustPointsRequest struct {
+	Amount int64  `json:"amount" binding:"required"`
+	Reason string `json:"reason" binding:"required"`
+}
+
+// AdjustPointsResponse represents the response for adjust points endpoint
+type AdjustPointsResponse struct {
 	Bidder  BidderWithPoints `json:"bidder"`
 	History PointHistory     `json:"history"`
 }
 
-// PointHistoryWithAuction represents a point history entry with auction information
+// PointHistoryWithAuction represents a point history entry with auction and item information
 type PointHistoryWithAuction struct {
 	PointHistory
 	AuctionTitle *string `json:"auction_title"`
+	ItemName     *string `json:"item_name"`
+}
+
+// PointHistoryFilter represents the optional filters of the point history endpoint
+type PointHistoryFilter struct {
+	AuctionID string           `form:"auction_id"`
+	ItemID    string           `form:"item_id"`
+	Type      PointHistoryType `form:"type"`
 }
 
 // PointHistoryListResponse represents the response for point history endpoint
@@ -176,6 +206,8 @@ type GetPointsResponse struct {
 	AvailablePoints int64     `json:"available_points"`
 	ReservedPoints  int64     `json:"reserved_points"`
 	UpdatedAt       time.Time `json:"updated_at"`
+
+	UpcomingExpiries []PointExpiry `json:"upcoming_expiries"` // Unused granted points that will expire, soonest first
 }
 
 // BidderUpdateRequest represents the request body for updating a bidder
@@ -8,6 +8,9 @@ type DashboardStats struct {
 	TodayBids      int64 `json:"today_bids"`      // Number of bids today
 	TotalBidders   int64 `json:"total_bidders"`   // Total number of active bidders
 	TotalPoints    int64 `json:"total_points"`    // Total points in circulation
+
+	TotalGrantedPoints  int64 `json:"total_granted_points"`  // Total points granted by admins
+	TotalAdjustedPoints int64 `json:"total_adjusted_points"` // Net points of manual adjustments (negative when deductions exceed additions)
 }
 
 // RecentBid represents a recent bid with related information
@@ -42,11 +45,24 @@ type EndedAuction struct {
 	EndedAt     *time.Time `json:"ended_at"`
 }
 
+// PendingPointGrant represents a point grant awaiting approval by a second system admin
+type PendingPointGrant struct {
+	ID              int64     `json:"id"`
+	BidderID        string    `json:"bidder_id"`
+	BidderEmail     string    `json:"bidder_email"`
+	BidderName      *string   `json:"bidder_name"`
+	Points          int64     `json:"points"`
+	RequestedBy     int64     `json:"requested_by"`
+	RequestedByName string    `json:"requested_by_name"`
+	RequestedAt     time.Time `json:"requested_at"`
+}
+
 // DashboardActivities represents recent activities on the dashboard
 type DashboardActivities struct {
-	RecentBids      []RecentBid    `json:"recent_bids"`
-	NewBidders      []NewBidder    `json:"new_bidders,omitempty"` // Only for system_admin
-	EndedAuctions   []EndedAuction `json:"ended_auctions"`
+	RecentBids         []RecentBid         `json:"recent_bids"`
+	NewBidders         []NewBidder         `json:"new_bidders,omitempty"` // Only for system_admin
+	EndedAuctions      []EndedAuction      `json:"ended_auctions"`
+	PendingPointGrants []PendingPointGrant `json:"pending_point_grants,omitempty"` // Only for system_admin
 }
 
 // DashboardStatsResponse represents the response for dashboard stats endpoint
@@ -20,6 +20,10 @@ type Item struct {
 	LotNumber     int        `gorm:"not null" json:"lot_number"`
 	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// FenceToken is the token of the last operation that wrote to the item. It is read-only for
+	// GORM and only advanced with AuctionRepository.AdvanceFenceToken.
+	FenceToken int64 `gorm:"->" json:"-"`
 }
 
 // TableName specifies the table name for Item model
@@ -105,6 +109,9 @@ type CancelAuctionResponse struct {
 	RefundedBidders     int64     `json:"refunded_bidders"`
 	TotalRefundedPoints int64     `json:"total_refunded_points"`
 	CancelledAt         time.Time `json:"cancelled_at"`
+
+	// RefundedBidderIDs lists the bidders whose points were refunded (used for notifications)
+	RefundedBidderIDs []uuid.UUID `json:"-"`
 }
 
 // UpdateItemRequest represents the request to update an item
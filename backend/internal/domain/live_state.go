@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuctionLiveState represents a consolidated snapshot of an auction in progress
+// (current item, price, winning bid and lot progress)
+type AuctionLiveState struct {
+	AuctionID   uuid.UUID       `json:"auction_id"`
+	Title       string          `json:"title"`
+	Status      AuctionStatus   `json:"status"`
+	CurrentItem *LiveItemState  `json:"current_item"`
+	WinningBid  *LiveWinningBid `json:"winning_bid"`
+	Progress    LotProgress     `json:"progress"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// LiveItemState represents the item currently on the block (or the next item when none is active)
+type LiveItemState struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	LotNumber     int        `json:"lot_number"`
+	Status        ItemStatus `json:"status"`
+	StartingPrice *int64     `json:"starting_price"`
+	CurrentPrice  *int64     `json:"current_price"`
+	StartedAt     *time.Time `json:"started_at"`
+}
+
+// LiveWinningBid represents the current winning bid on the active item
+type LiveWinningBid struct {
+	BidderID       *uuid.UUID `json:"bidder_id,omitempty"`
+	PaddleNumber   int64      `json:"paddle_number,omitempty"`
+	Price          int64      `json:"price"`
+	BidAt          time.Time  `json:"bid_at"`
+	PointsReserved bool       `json:"points_reserved"` // Whether the winner's points are held until the item ends
+	IsMine         bool       `json:"is_mine"`
+}
+
+// LotProgress represents how far the auction has progressed through its lots
+type LotProgress struct {
+	TotalLots     int `json:"total_lots"`
+	CompletedLots int `json:"completed_lots"`
+	RemainingLots int `json:"remaining_lots"`
+	CurrentLot    int `json:"current_lot,omitempty"`
+}
+
+// ForViewer returns a copy of the state for a viewer. Bidder identities are only kept for admins;
+// bidders instead see whether the winning bid is their own. Paddle numbers are shown to bidders
+// but not to spectators and unauthenticated viewers (bidderID == nil).
+func (s *AuctionLiveState) ForViewer(bidderID *uuid.UUID, isAdmin bool) *AuctionLiveState {
+	state := *s
+	if s.WinningBid == nil || isAdmin {
+		return &state
+	}
+
+	winningBid := *s.WinningBid
+	winningBid.IsMine = bidderID != nil && winningBid.BidderID != nil && *winningBid.BidderID == *bidderID
+	winningBid.BidderID = nil
+	if bidderID == nil {
+		winningBid.PaddleNumber = 0
+	}
+	state.WinningBid = &winningBid
+	return &state
+}
@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+// PointGrantBatchStatus represents the status of a bulk point grant
+type PointGrantBatchStatus string
+
+const (
+	PointGrantBatchStatusApplied  PointGrantBatchStatus = "applied"
+	PointGrantBatchStatusReversed PointGrantBatchStatus = "reversed"
+)
+
+// PointGrantBatch represents a bulk point grant applied in one transaction
+type PointGrantBatch struct {
+	ID          string                `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	AdminID     *int64                `gorm:"type:bigint" json:"admin_id"`
+	RowCount    int                   `gorm:"not null" json:"row_count"`
+	TotalPoints int64                 `gorm:"not null" json:"total_points"`
+	Status      PointGrantBatchStatus `gorm:"type:varchar(20);not null;default:'applied'" json:"status"`
+	ReversedBy  *int64                `gorm:"type:bigint" json:"reversed_by"`
+	ReversedAt  *time.Time            `json:"reversed_at"`
+	CreatedAt   time.Time             `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for PointGrantBatch model
+func (PointGrantBatch) TableName() string {
+	return "point_grant_batches"
+}
+
+// PointGrantRow represents one row of a bulk point grant.
+// Bidder is either the bidder's ID or email address.
+type PointGrantRow struct {
+	Bidder    string     `json:"bidder"`
+	Points    int64      `json:"points"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"` // Optional expiry of the granted points
+}
+
+// PointGrantBatchRequest represents the JSON request body for a bulk point grant
+type PointGrantBatchRequest struct {
+	Rows []PointGrantRow `json:"rows" binding:"required"`
+}
+
+// PointGrantRowResult represents the validation result of one row of a bulk point grant
+type PointGrantRowResult struct {
+	Row       int        `json:"row"` // 1-based row number, excluding the CSV header
+	Bidder    string     `json:"bidder"`
+	BidderID  *string    `json:"bidder_id"` // Resolved bidder ID, nil when the bidder was not found
+	Points    int64      `json:"points"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Errors    []string   `json:"errors"`
+}
+
+// PointGrantBatchResult represents the result of a bulk point grant dry run or apply
+type PointGrantBatchResult struct {
+	DryRun      bool                  `json:"dry_run"`
+	Valid       bool                  `json:"valid"`
+	RowCount    int                   `json:"row_count"`
+	TotalPoints int64                 `json:"total_points"`
+	Rows        []PointGrantRowResult `json:"rows"`
+	Batch       *PointGrantBatch      `json:"batch,omitempty"` // Set when the batch was applied
+}
+
+// PointGrantBatchDetail represents a bulk point grant with the point history entries it created
+type PointGrantBatchDetail struct {
+	Batch     PointGrantBatch `json:"batch"`
+	Histories []PointHistory  `json:"histories"`
+}
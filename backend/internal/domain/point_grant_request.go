@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// PointGrantRequestStatus represents the status of a point grant awaiting approval
+type PointGrantRequestStatus string
+
+const (
+	PointGrantRequestStatusPending  PointGrantRequestStatus = "pending"
+	PointGrantRequestStatusApproved PointGrantRequestStatus = "approved"
+	PointGrantRequestStatusRejected PointGrantRequestStatus = "rejected"
+)
+
+// PointGrantRequest represents a point grant above the approval threshold. It is applied only
+// once a system admin other than the requester approves it.
+type PointGrantRequest struct {
+	ID           int64                   `gorm:"primaryKey;autoIncrement" json:"id"`
+	BidderID     string                  `gorm:"type:uuid;not null" json:"bidder_id"`
+	Points       int64                   `gorm:"not null" json:"points"`
+	ExpiresAt    *time.Time              `json:"expires_at"`
+	Status       PointGrantRequestStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	RequestedBy  int64                   `gorm:"not null" json:"requested_by"`
+	DecidedBy    *int64                  `json:"decided_by"`
+	DecisionNote *string                 `gorm:"type:text" json:"decision_note"`
+	HistoryID    *int64                  `json:"history_id"` // Grant history entry created on approval
+	CreatedAt    time.Time               `gorm:"autoCreateTime" json:"created_at"`
+	DecidedAt    *time.Time              `json:"decided_at"`
+}
+
+// TableName specifies the table name for PointGrantRequest model
+func (PointGrantRequest) TableName() string {
+	return "point_grant_requests"
+}
+
+// PointGrantRequestListResponse represents the response for the point grant request list endpoint
+type PointGrantRequestListResponse struct {
+	Requests []PointGrantRequest `json:"requests"`
+}
+
+// RejectPointGrantRequest represents the request body for rejecting a point grant request
+type RejectPointGrantRequest struct {
+	Note string `json:"note"`
+}
+
+// PointGrantDecision represents the result of approving or rejecting a point grant request
+type PointGrantDecision struct {
+	Request PointGrantRequest `json:"request"`
+	History *PointHistory     `json:"history,omitempty"` // Set when the request was approved
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// PointLot represents the points of one grant. Consumed points and negative manual adjustments are
+// taken from the oldest open lots first; credits that create no lot (refunds, positive
+// adjustments) never count as unused points of a lot.
+type PointLot struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	BidderID      string     `gorm:"type:uuid;not null" json:"bidder_id"`
+	HistoryID     *int64     `gorm:"type:bigint" json:"history_id"` // Grant history entry that created the lot
+	Amount        int64      `gorm:"not null" json:"amount"`
+	ExpiresAt     *time.Time `json:"expires_at"` // nil for points that never expire
+	SpentAmount   int64      `gorm:"not null;default:0" json:"spent_amount"`
+	ExpiredAmount int64      `gorm:"not null;default:0" json:"expired_amount"`
+	ClosedAt      *time.Time `json:"closed_at"` // Set when the lot expired or its grant was reversed
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for PointLot model
+func (PointLot) TableName() string {
+	return "point_lots"
+}
+
+// PointExpiry represents unused points that expire at the same time
+type PointExpiry struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Points    int64     `json:"points"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// PointDiscrepancyType identifies a broken point ledger invariant
+type PointDiscrepancyType string
+
+const (
+	// PointDiscrepancyBalance: total_points differs from available_points + reserved_points
+	PointDiscrepancyBalance PointDiscrepancyType = "balance_mismatch"
+	// PointDiscrepancyReserved: reserved_points differs from the sum of the bidder's winning bids on open items
+	PointDiscrepancyReserved PointDiscrepancyType = "reserved_mismatch"
+	// PointDiscrepancyHistoryGap: a history entry does not start from the balances the previous entry ended with
+	PointDiscrepancyHistoryGap PointDiscrepancyType = "history_gap"
+	// PointDiscrepancyHistoryTail: the bidder's latest history entry does not end with the current balances
+	PointDiscrepancyHistoryTail PointDiscrepancyType = "history_tail_mismatch"
+)
+
+// PointDiscrepancy describes one broken invariant of a bidder's points
+type PointDiscrepancy struct {
+	BidderID  string               `json:"bidder_id"`
+	Type      PointDiscrepancyType `json:"type"`
+	Field     string               `json:"field"`                // Balance the discrepancy is about: total, available or reserved
+	Expected  int64                `json:"expected"`             // Value implied by the invariant
+	Actual    int64                `json:"actual"`               // Recorded value
+	HistoryID *int64               `json:"history_id,omitempty"` // History entry involved, for history discrepancies
+}
+
+// PointHistoryLink pairs a history entry with the balances its predecessor ended with
+type PointHistoryLink struct {
+	ID                   int64  `gorm:"column:id"`
+	BidderID             string `gorm:"column:bidder_id"`
+	BalanceBefore        int64  `gorm:"column:balance_before"`
+	ReservedBefore       int64  `gorm:"column:reserved_before"`
+	TotalBefore          int64  `gorm:"column:total_before"`
+	PreviousBalanceAfter int64  `gorm:"column:previous_balance_after"`
+	PreviousReserved     int64  `gorm:"column:previous_reserved_after"`
+	PreviousTotalAfter   int64  `gorm:"column:previous_total_after"`
+}
+
+// PointReconciliationRequest represents the request body for the reconciliation endpoint
+type PointReconciliationRequest struct {
+	ApplyCorrections bool `json:"apply_corrections"` // Record adjust entries for the correctable discrepancies
+}
+
+// PointReconciliationReport is the result of a point ledger reconciliation
+type PointReconciliationReport struct {
+	CheckedAt      time.Time          `json:"checked_at"`
+	BiddersChecked int                `json:"bidders_checked"`
+	Discrepancies  []PointDiscrepancy `json:"discrepancies"`
+	Corrections    []PointHistory     `json:"corrections"`   // Adjust entries recorded, when corrections were requested
+	ManualReview   []string           `json:"manual_review"` // Bidders whose balances cannot be corrected without creating points
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+// WSTicketResponse represents a short-lived, single-use ticket for opening a WebSocket connection
+type WSTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,181 @@
+// Package events defines the versioned schema of the real-time events exchanged between the
+// API server, the WebSocket server and clients.
+//
+// Publishers build a typed payload and publish it wrapped in an Envelope; the WebSocket server
+// decodes the envelope, projects the payload per viewer role and forwards it to clients as
+// {"type", "version", "data"}. The JSON Schema of every event is exported with Schema.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SchemaVersion is the version of the event schema. Bump it on any incompatible payload change
+// (removing or renaming a field, changing a field type); adding optional fields is compatible.
+const SchemaVersion = 1
+
+// Type identifies an event
+type Type string
+
+// Auction room events (delivered to everyone watching the auction)
+const (
+	TypeAuctionStarted      Type = "auction:started"
+	TypeAuctionEnded        Type = "auction:ended"
+	TypeAuctionCancelled    Type = "auction:cancelled"
+	TypeAuctionAnnouncement Type = "auction:announcement"
+	TypeItemStarted         Type = "item:started"
+	TypePriceOpened         Type = "price:opened"
+	TypeBidPlaced           Type = "bid:placed"
+	TypeItemEnded           Type = "item:ended"
+	TypeChatMessage         Type = "chat:message" // delivered only to admins and the sending bidder
+	TypeChatMessageDeleted  Type = "chat:message_deleted"
+)
+
+// Bidder notifications (delivered only to the addressed bidder)
+const (
+	TypeBidOutbid     Type = "bid:outbid"
+	TypePointsUpdated Type = "points:updated"
+	TypeItemWon       Type = "item:won"
+	TypeItemLost      Type = "item:lost"
+	TypeChatMuted     Type = "chat:muted"
+)
+
+// Admin notifications (delivered only to the addressed admin)
+const (
+	TypePointGrantDecided Type = "points:grant_decided"
+)
+
+// Redis Pub/Sub channels
+const (
+	// ChannelAuctionEvents carries every auction room event; the event type is in the envelope
+	ChannelAuctionEvents = "auction:events"
+
+	// ChannelBidderNotifications carries notifications addressed to a single bidder. Every
+	// WebSocket server subscribes to it and delivers each message only to the addressed
+	// bidder's local connections.
+	ChannelBidderNotifications = "bidder:notification"
+
+	// ChannelAdminNotifications carries notifications addressed to a single admin, delivered the
+	// same way as bidder notifications
+	ChannelAdminNotifications = "admin:notification"
+)
+
+// Payload is implemented by every event payload
+type Payload interface {
+	EventType() Type
+}
+
+// Envelope is the wire format of a published event
+type Envelope struct {
+	Type    Type            `json:"type"`
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// BidderEnvelope is the wire format of a bidder notification
+type BidderEnvelope struct {
+	BidderID string `json:"bidder_id"`
+	Envelope
+}
+
+// AdminEnvelope is the wire format of an admin notification
+type AdminEnvelope struct {
+	AdminID int64 `json:"admin_id"`
+	Envelope
+}
+
+// NewEnvelope wraps a payload in an envelope of the current schema version
+func NewEnvelope(payload Payload) (*Envelope, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s payload: %w", payload.EventType(), err)
+	}
+
+	return &Envelope{
+		Type:    payload.EventType(),
+		Version: SchemaVersion,
+		Data:    data,
+	}, nil
+}
+
+// Marshal encodes a payload as an envelope
+func Marshal(payload Payload) ([]byte, error) {
+	envelope, err := NewEnvelope(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope)
+}
+
+// MarshalForBidder encodes a payload as a notification addressed to a bidder
+func MarshalForBidder(bidderID string, payload Payload) ([]byte, error) {
+	envelope, err := NewEnvelope(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(BidderEnvelope{BidderID: bidderID, Envelope: *envelope})
+}
+
+// MarshalForAdmin encodes a payload as a notification addressed to an admin
+func MarshalForAdmin(adminID int64, payload Payload) ([]byte, error) {
+	envelope, err := NewEnvelope(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(AdminEnvelope{AdminID: adminID, Envelope: *envelope})
+}
+
+// Publish publishes an auction room event
+func Publish(ctx context.Context, redisClient *redis.Client, payload Payload) error {
+	if redisClient == nil {
+		return nil
+	}
+
+	message, err := Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if err := redisClient.Publish(ctx, ChannelAuctionEvents, message).Err(); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", payload.EventType(), err)
+	}
+	return nil
+}
+
+// PublishToBidder publishes a notification addressed to a single bidder
+func PublishToBidder(ctx context.Context, redisClient *redis.Client, bidderID string, payload Payload) error {
+	if redisClient == nil {
+		return nil
+	}
+
+	message, err := MarshalForBidder(bidderID, payload)
+	if err != nil {
+		return err
+	}
+
+	if err := redisClient.Publish(ctx, ChannelBidderNotifications, message).Err(); err != nil {
+		return fmt.Errorf("failed to publish %s notification: %w", payload.EventType(), err)
+	}
+	return nil
+}
+
+// PublishToAdmin publishes a notification addressed to a single admin
+func PublishToAdmin(ctx context.Context, redisClient *redis.Client, adminID int64, payload Payload) error {
+	if redisClient == nil {
+		return nil
+	}
+
+	message, err := MarshalForAdmin(adminID, payload)
+	if err != nil {
+		return err
+	}
+
+	if err := redisClient.Publish(ctx, ChannelAdminNotifications, message).Err(); err != nil {
+		return fmt.Errorf("failed to publish %s notification: %w", payload.EventType(), err)
+	}
+	return nil
+}
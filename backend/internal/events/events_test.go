@@ -0,0 +1,125 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fixtureTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// fixtures holds a representative payload of every event, as published by the services
+var fixtures = []Payload{
+	AuctionStarted{AuctionID: "auction-1", Status: "active", StartedAt: fixtureTime},
+	AuctionEnded{AuctionID: "auction-1", Status: "ended", EndedAt: fixtureTime},
+	AuctionCancelled{AuctionID: "auction-1", Status: "cancelled", Reason: "weather", CancelledAt: fixtureTime},
+	AuctionAnnouncement{AuctionID: "auction-1", MessageID: 1, Message: "Next lot in 5 minutes", AnnouncedBy: "1", AnnouncedAt: fixtureTime},
+	ItemStarted{AuctionID: "auction-1", Item: ItemSummary{ID: "item-1", AuctionID: "auction-1", Name: "Vase", CurrentPrice: 1000, StartedAt: fixtureTime, Status: "active"}},
+	PriceOpened{AuctionID: "auction-1", ItemID: "item-1", Price: 1200, PriceHistory: PriceDisclosure{ID: 7, ItemID: "item-1", Price: 1200, DisclosedBy: 1, HadBid: true, DisclosedAt: fixtureTime}},
+	BidPlaced{AuctionID: "auction-1", ItemID: "item-1", Bid: BidInfo{ID: 9, BidderID: "bidder-1", BidderName: "Alice", PaddleNumber: 12, Price: 1200, IsWinning: true, BidAt: fixtureTime}},
+	ItemEnded{AuctionID: "auction-1", ItemID: "item-1", Item: ItemResult{ID: "item-1", AuctionID: "auction-1", Name: "Vase", FinalPrice: 1200, WinnerID: "bidder-1", WinnerPaddleNumber: 12, EndedAt: fixtureTime, Status: "ended"}},
+	ChatMessage{AuctionID: "auction-1", MessageID: 2, BidderID: "bidder-1", BidderName: "Alice", Message: "Is lot 12 withdrawn?", SentAt: fixtureTime},
+	ChatMessageDeleted{AuctionID: "auction-1", MessageID: 2, DeletedAt: fixtureTime},
+	BidOutbid{AuctionID: "auction-1", ItemID: "item-1", OutbidBidID: 8, OutbidPrice: 1100, CurrentPrice: 1200},
+	PointsUpdated{Points: PointsBalance{BidderID: "bidder-1", TotalPoints: 5000, AvailablePoints: 3800, ReservedPoints: 1200, UpdatedAt: fixtureTime}},
+	ItemWon{AuctionID: "auction-1", ItemID: "item-1", ItemName: "Vase", FinalPrice: 1200},
+	ItemLost{AuctionID: "auction-1", ItemID: "item-1", ItemName: "Vase", FinalPrice: 1200},
+	ChatMuted{AuctionID: "auction-1", Muted: true},
+	PointGrantDecided{RequestID: 3, BidderID: "bidder-1", Points: 500000, Status: "rejected", DecidedBy: 2, DecisionNote: "Not budgeted", DecidedAt: fixtureTime},
+}
+
+func TestFixtures_CoverEveryEventType(t *testing.T) {
+	covered := map[Type]bool{}
+	for _, fixture := range fixtures {
+		covered[fixture.EventType()] = true
+	}
+
+	for _, eventType := range Types() {
+		assert.True(t, covered[eventType], "no fixture for %s", eventType)
+	}
+}
+
+func TestValidate_PublishedEvents(t *testing.T) {
+	for _, fixture := range fixtures {
+		t.Run(string(fixture.EventType()), func(t *testing.T) {
+			message, err := Marshal(fixture)
+			require.NoError(t, err)
+			assert.NoError(t, Validate(message))
+
+			var envelope map[string]interface{}
+			require.NoError(t, json.Unmarshal(message, &envelope))
+			assert.Equal(t, float64(SchemaVersion), envelope["version"])
+		})
+	}
+}
+
+func TestValidate_AnonymizedEvents(t *testing.T) {
+	message, err := Marshal(BidPlaced{AuctionID: "auction-1", ItemID: "item-1", Bid: BidInfo{ID: 9, Price: 1200, IsWinning: true, BidAt: fixtureTime, IsMine: true}})
+	require.NoError(t, err)
+	assert.NoError(t, Validate(message))
+}
+
+func TestValidate_BidderNotificationEnvelope(t *testing.T) {
+	message, err := MarshalForBidder("bidder-1", BidOutbid{AuctionID: "auction-1", ItemID: "item-1", OutbidBidID: 8, OutbidPrice: 1100, CurrentPrice: 1200})
+	require.NoError(t, err)
+
+	var envelope BidderEnvelope
+	require.NoError(t, json.Unmarshal(message, &envelope))
+	assert.Equal(t, "bidder-1", envelope.BidderID)
+
+	// Clients receive the envelope without the routing field
+	forwarded, err := json.Marshal(envelope.Envelope)
+	require.NoError(t, err)
+	assert.NoError(t, Validate(forwarded))
+}
+
+func TestValidate_AdminNotificationEnvelope(t *testing.T) {
+	message, err := MarshalForAdmin(1, PointGrantDecided{RequestID: 3, BidderID: "bidder-1", Points: 500000, Status: "approved", DecidedBy: 2, DecidedAt: fixtureTime})
+	require.NoError(t, err)
+
+	var envelope AdminEnvelope
+	require.NoError(t, json.Unmarshal(message, &envelope))
+	assert.Equal(t, int64(1), envelope.AdminID)
+
+	// Clients receive the envelope without the routing field
+	forwarded, err := json.Marshal(envelope.Envelope)
+	require.NoError(t, err)
+	assert.NoError(t, Validate(forwarded))
+}
+
+func TestValidate_RejectsInvalidEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		errText string
+	}{
+		{"unknown type", `{"type":"bid:unknown","version":1,"data":{}}`, "unknown event type"},
+		{"wrong version", `{"type":"auction:ended","version":2,"data":{"auction_id":"a","status":"ended","ended_at":"2026-01-02T03:04:05Z"}}`, "version: must be 1"},
+		{"missing field", `{"type":"auction:ended","version":1,"data":{"auction_id":"a","status":"ended"}}`, `missing required property "ended_at"`},
+		{"unexpected field", `{"type":"item:won","version":1,"data":{"auction_id":"a","item_id":"i","item_name":"n","final_price":1,"extra":true}}`, `unexpected property "extra"`},
+		{"wrong type", `{"type":"bid:outbid","version":1,"data":{"auction_id":"a","item_id":"i","outbid_bid_id":"8","outbid_price":1,"current_price":2}}`, "data.outbid_bid_id: must be an integer"},
+		{"bad date-time", `{"type":"auction:started","version":1,"data":{"auction_id":"a","status":"active","started_at":"yesterday"}}`, "data.started_at: must be an RFC 3339 date-time"},
+		{"missing type", `{"version":1,"data":{}}`, "must match exactly one schema"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate([]byte(tt.message))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errText)
+		})
+	}
+}
+
+func TestSchemaJSON_MatchesExportedFile(t *testing.T) {
+	expected, err := SchemaJSON()
+	require.NoError(t, err)
+
+	exported, err := os.ReadFile("../../../docs/events.schema.json")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(exported), "docs/events.schema.json is stale; run: go run ./cmd/eventschema -o ../docs/events.schema.json")
+}
@@ -0,0 +1,230 @@
+package events
+
+import "time"
+
+// Payload fields marked omitempty are optional in the schema. The WebSocket server removes
+// bidder identities (bidder_id, bidder_name, winner_id, disclosed_by) before forwarding events to
+// non-admin viewers, and sets is_mine for bidders.
+
+// AuctionStarted is published when an auction opens
+type AuctionStarted struct {
+	AuctionID string    `json:"auction_id"`
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// AuctionEnded is published when an auction closes
+type AuctionEnded struct {
+	AuctionID string    `json:"auction_id"`
+	Status    string    `json:"status"`
+	EndedAt   time.Time `json:"ended_at"`
+}
+
+// AuctionCancelled is published when an auction is cancelled
+type AuctionCancelled struct {
+	AuctionID   string    `json:"auction_id"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason,omitempty"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+// AuctionAnnouncement is a message from the auctioneer to the auction room
+type AuctionAnnouncement struct {
+	AuctionID   string    `json:"auction_id"`
+	MessageID   int64     `json:"message_id"`
+	Message     string    `json:"message"`
+	AnnouncedBy string    `json:"announced_by"`
+	AnnouncedAt time.Time `json:"announced_at"`
+}
+
+// ItemStarted is published when an item goes on the block
+type ItemStarted struct {
+	AuctionID string      `json:"auction_id"`
+	Item      ItemSummary `json:"item"`
+}
+
+// ItemSummary describes an item that has started
+type ItemSummary struct {
+	ID           string    `json:"id"`
+	AuctionID    string    `json:"auction_id"`
+	Name         string    `json:"name"`
+	CurrentPrice int64     `json:"current_price"`
+	StartedAt    time.Time `json:"started_at"`
+	Status       string    `json:"status"`
+}
+
+// PriceOpened is published when the auctioneer discloses a new price
+type PriceOpened struct {
+	AuctionID    string          `json:"auction_id"`
+	ItemID       string          `json:"item_id"`
+	Price        int64           `json:"price"`
+	PriceHistory PriceDisclosure `json:"price_history"`
+}
+
+// PriceDisclosure is the price history entry recorded for a disclosed price
+type PriceDisclosure struct {
+	ID          int64     `json:"id"`
+	ItemID      string    `json:"item_id"`
+	Price       int64     `json:"price"`
+	DisclosedBy int64     `json:"disclosed_by,omitempty"`
+	HadBid      bool      `json:"had_bid"`
+	DisclosedAt time.Time `json:"disclosed_at"`
+}
+
+// BidPlaced is published when a bid is accepted
+type BidPlaced struct {
+	AuctionID string  `json:"auction_id"`
+	ItemID    string  `json:"item_id"`
+	Bid       BidInfo `json:"bid"`
+}
+
+// BidInfo describes an accepted bid
+type BidInfo struct {
+	ID           int64     `json:"id"`
+	BidderID     string    `json:"bidder_id,omitempty"`
+	BidderName   string    `json:"bidder_name,omitempty"`
+	PaddleNumber int64     `json:"paddle_number,omitempty"`
+	Price        int64     `json:"price"`
+	IsWinning    bool      `json:"is_winning"`
+	BidAt        time.Time `json:"bid_at"`
+	IsMine       bool      `json:"is_mine,omitempty"`
+}
+
+// ItemEnded is published when an item is hammered down
+type ItemEnded struct {
+	AuctionID string     `json:"auction_id"`
+	ItemID    string     `json:"item_id"`
+	Item      ItemResult `json:"item"`
+}
+
+// ItemResult describes the outcome of an ended item
+type ItemResult struct {
+	ID                 string    `json:"id"`
+	AuctionID          string    `json:"auction_id"`
+	Name               string    `json:"name"`
+	FinalPrice         int64     `json:"final_price"`
+	WinnerID           string    `json:"winner_id,omitempty"`
+	WinnerPaddleNumber int64     `json:"winner_paddle_number,omitempty"`
+	EndedAt            time.Time `json:"ended_at"`
+	Status             string    `json:"status"`
+	IsMine             bool      `json:"is_mine,omitempty"`
+}
+
+// ChatMessage is a question sent by a bidder to the auctioneers
+type ChatMessage struct {
+	AuctionID  string    `json:"auction_id"`
+	MessageID  int64     `json:"message_id"`
+	BidderID   string    `json:"bidder_id,omitempty"`
+	BidderName string    `json:"bidder_name,omitempty"`
+	Message    string    `json:"message"`
+	SentAt     time.Time `json:"sent_at"`
+	IsMine     bool      `json:"is_mine,omitempty"`
+}
+
+// ChatMessageDeleted is published when a moderator deletes an announcement or question
+type ChatMessageDeleted struct {
+	AuctionID string    `json:"auction_id"`
+	MessageID int64     `json:"message_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// BidOutbid notifies a bidder that their winning bid was outbid
+type BidOutbid struct {
+	AuctionID    string `json:"auction_id"`
+	ItemID       string `json:"item_id"`
+	OutbidBidID  int64  `json:"outbid_bid_id"`
+	OutbidPrice  int64  `json:"outbid_price"`
+	CurrentPrice int64  `json:"current_price"`
+}
+
+// PointsUpdated notifies a bidder of their new point balance
+type PointsUpdated struct {
+	Points PointsBalance `json:"points"`
+}
+
+// PointsBalance is a bidder's point balance
+type PointsBalance struct {
+	BidderID        string    `json:"bidder_id"`
+	TotalPoints     int64     `json:"total_points"`
+	AvailablePoints int64     `json:"available_points"`
+	ReservedPoints  int64     `json:"reserved_points"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ItemOutcome describes an ended item from a bidder's point of view
+type ItemOutcome struct {
+	AuctionID  string `json:"auction_id"`
+	ItemID     string `json:"item_id"`
+	ItemName   string `json:"item_name"`
+	FinalPrice int64  `json:"final_price"`
+}
+
+// ItemWon notifies the winning bidder of an item
+type ItemWon ItemOutcome
+
+// ItemLost notifies the other bidders on an item that it went to someone else
+type ItemLost ItemOutcome
+
+// ChatMuted notifies a bidder that they were muted or unmuted in an auction
+type ChatMuted struct {
+	AuctionID string `json:"auction_id"`
+	Muted     bool   `json:"muted"`
+}
+
+// PointGrantDecided notifies the requesting admin that their point grant request was approved or
+// rejected
+type PointGrantDecided struct {
+	RequestID    int64     `json:"request_id"`
+	BidderID     string    `json:"bidder_id"`
+	Points       int64     `json:"points"`
+	Status       string    `json:"status"` // approved or rejected
+	DecidedBy    int64     `json:"decided_by"`
+	DecisionNote string    `json:"decision_note,omitempty"`
+	DecidedAt    time.Time `json:"decided_at"`
+}
+
+func (AuctionStarted) EventType() Type      { return TypeAuctionStarted }
+func (AuctionEnded) EventType() Type        { return TypeAuctionEnded }
+func (AuctionCancelled) EventType() Type    { return TypeAuctionCancelled }
+func (AuctionAnnouncement) EventType() Type { return TypeAuctionAnnouncement }
+func (ItemStarted) EventType() Type         { return TypeItemStarted }
+func (PriceOpened) EventType() Type         { return TypePriceOpened }
+func (BidPlaced) EventType() Type           { return TypeBidPlaced }
+func (ItemEnded) EventType() Type           { return TypeItemEnded }
+func (BidOutbid) EventType() Type           { return TypeBidOutbid }
+func (PointsUpdated) EventType() Type       { return TypePointsUpdated }
+func (ItemWon) EventType() Type             { return TypeItemWon }
+func (ItemLost) EventType() Type            { return TypeItemLost }
+func (ChatMessage) EventType() Type         { return TypeChatMessage }
+func (ChatMessageDeleted) EventType() Type  { return TypeChatMessageDeleted }
+func (ChatMuted) EventType() Type           { return TypeChatMuted }
+func (PointGrantDecided) EventType() Type   { return TypePointGrantDecided }
+
+// payloads lists every event with its payload, in schema order
+var payloads = []Payload{
+	AuctionStarted{},
+	AuctionEnded{},
+	AuctionCancelled{},
+	AuctionAnnouncement{},
+	ItemStarted{},
+	PriceOpened{},
+	BidPlaced{},
+	ItemEnded{},
+	ChatMessage{},
+	ChatMessageDeleted{},
+	BidOutbid{},
+	PointsUpdated{},
+	ItemWon{},
+	ItemLost{},
+	ChatMuted{},
+	PointGrantDecided{},
+}
+
+// Types returns every event type defined by the schema
+func Types() []Type {
+	types := make([]Type, len(payloads))
+	for i, payload := range payloads {
+		types[i] = payload.EventType()
+	}
+	return types
+}
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaID is the $id of the exported JSON Schema
+const SchemaID = "https://real-time-auction/schemas/events.schema.json"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Schema returns the JSON Schema (draft 2020-12) of every event envelope.
+// Each payload type is described under $defs and the envelope is one of the defined events.
+func Schema() map[string]interface{} {
+	defs := map[string]interface{}{}
+	variants := make([]interface{}, 0, len(payloads))
+
+	for _, payload := range payloads {
+		payloadType := reflect.TypeOf(payload)
+		defs[payloadType.Name()] = schemaForStruct(payloadType, defs)
+		variants = append(variants, map[string]interface{}{
+			"$ref": "#/$defs/" + envelopeDefName(payloadType),
+		})
+		defs[envelopeDefName(payloadType)] = map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"type":    map[string]interface{}{"const": string(payload.EventType())},
+				"version": map[string]interface{}{"const": SchemaVersion},
+				"data":    map[string]interface{}{"$ref": "#/$defs/" + payloadType.Name()},
+			},
+			"required":             []interface{}{"type", "version", "data"},
+			"additionalProperties": false,
+		}
+	}
+
+	return map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     SchemaID,
+		"title":   "Real-time auction events",
+		"version": SchemaVersion,
+		"oneOf":   variants,
+		"$defs":   defs,
+	}
+}
+
+// SchemaJSON returns the indented JSON encoding of Schema
+func SchemaJSON() ([]byte, error) {
+	schema, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, '\n'), nil
+}
+
+// envelopeDefName returns the $defs name of the envelope carrying a payload type
+func envelopeDefName(payloadType reflect.Type) string {
+	return payloadType.Name() + "Event"
+}
+
+// schemaForType returns the schema of a Go type, registering nested structs under defs
+func schemaForType(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	if t.Kind() == reflect.Pointer {
+		schema := schemaForType(t.Elem(), defs)
+		return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+	}
+
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaForType(t.Elem(), defs)}
+	case reflect.Struct:
+		if _, ok := defs[t.Name()]; !ok {
+			// Register the name first so recursive types terminate
+			defs[t.Name()] = nil
+			defs[t.Name()] = schemaForStruct(t, defs)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// schemaForStruct returns the object schema of a struct. Fields without omitempty are required.
+func schemaForStruct(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []interface{}{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = schemaForType(field.Type, defs)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
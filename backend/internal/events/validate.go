@@ -0,0 +1,212 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Validate checks an encoded event envelope against the exported JSON Schema.
+// It implements the subset of JSON Schema used by Schema: type, const, properties, required,
+// additionalProperties, items, anyOf, oneOf, local $ref and the date-time format.
+func Validate(message []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(message))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	// Round-trip the schema through JSON so it is checked exactly as exported
+	encoded, err := json.Marshal(Schema())
+	if err != nil {
+		return err
+	}
+	var schema map[string]interface{}
+	decoder = json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	if err := decoder.Decode(&schema); err != nil {
+		return err
+	}
+
+	v := validator{defs: schema["$defs"].(map[string]interface{})}
+
+	// Report errors against the variant selected by the envelope type rather than every variant
+	if envelope, ok := value.(map[string]interface{}); ok {
+		if eventType, ok := envelope["type"].(string); ok {
+			for _, payload := range payloads {
+				if string(payload.EventType()) == eventType {
+					return v.validate(value, map[string]interface{}{
+						"$ref": "#/$defs/" + envelopeDefName(reflect.TypeOf(payload)),
+					}, "")
+				}
+			}
+			return fmt.Errorf("type: unknown event type %q", eventType)
+		}
+	}
+
+	return v.validate(value, schema, "")
+}
+
+type validator struct {
+	defs map[string]interface{}
+}
+
+func (v validator) validate(value interface{}, schema map[string]interface{}, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/$defs/")
+		def, ok := v.defs[name].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: unresolved $ref %q", pathOrRoot(path), ref)
+		}
+		return v.validate(value, def, path)
+	}
+
+	if expected, ok := schema["const"]; ok && !equalJSON(value, expected) {
+		return fmt.Errorf("%s: must be %v", pathOrRoot(path), expected)
+	}
+
+	if variants, ok := schema["anyOf"].([]interface{}); ok {
+		if v.countMatches(value, variants, path) == 0 {
+			return fmt.Errorf("%s: does not match any allowed schema", pathOrRoot(path))
+		}
+	}
+	if variants, ok := schema["oneOf"].([]interface{}); ok {
+		if v.countMatches(value, variants, path) != 1 {
+			return fmt.Errorf("%s: must match exactly one schema", pathOrRoot(path))
+		}
+	}
+
+	schemaType, _ := schema["type"].(string)
+	switch schemaType {
+	case "":
+		return nil
+	case "object":
+		return v.validateObject(value, schema, path)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: must be an array", pathOrRoot(path))
+		}
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range items {
+				if err := v.validate(item, itemSchema, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: must be a string", pathOrRoot(path))
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+				return fmt.Errorf("%s: must be an RFC 3339 date-time", pathOrRoot(path))
+			}
+		}
+		return nil
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: must be an integer", pathOrRoot(path))
+		}
+		if _, err := n.Int64(); err != nil {
+			return fmt.Errorf("%s: must be an integer", pathOrRoot(path))
+		}
+		return nil
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return fmt.Errorf("%s: must be a number", pathOrRoot(path))
+		}
+		return nil
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: must be a boolean", pathOrRoot(path))
+		}
+		return nil
+	case "null":
+		if value != nil {
+			return fmt.Errorf("%s: must be null", pathOrRoot(path))
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s: unsupported schema type %q", pathOrRoot(path), schemaType)
+	}
+}
+
+func (v validator) validateObject(value interface{}, schema map[string]interface{}, path string) error {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s: must be an object", pathOrRoot(path))
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %q", pathOrRoot(path), name)
+			}
+		}
+	}
+
+	// Check properties in a stable order so the reported error is deterministic
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertySchema, ok := properties[name].(map[string]interface{})
+		if !ok {
+			if schema["additionalProperties"] == false {
+				return fmt.Errorf("%s: unexpected property %q", pathOrRoot(path), name)
+			}
+			continue
+		}
+		if err := v.validate(object[name], propertySchema, joinPath(path, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v validator) countMatches(value interface{}, variants []interface{}, path string) int {
+	matches := 0
+	for _, variant := range variants {
+		if variantSchema, ok := variant.(map[string]interface{}); ok && v.validate(value, variantSchema, path) == nil {
+			matches++
+		}
+	}
+	return matches
+}
+
+// equalJSON compares two decoded JSON values
+func equalJSON(a, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
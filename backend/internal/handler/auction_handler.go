@@ -297,6 +297,30 @@ func (h *AuctionHandler) GetAuctionDetail(c *gin.Context) {
 	c.JSON(http.StatusOK, auction)
 }
 
+// GetLiveState handles GET /api/auctions/:id/live
+// Returns the consolidated live-state snapshot without bidder identities
+func (h *AuctionHandler) GetLiveState(c *gin.Context) {
+	// Get auction ID from URL parameter
+	id := c.Param("id")
+
+	// Call service
+	state, err := h.auctionService.GetLiveState(id)
+	if err != nil {
+		if errors.Is(err, service.ErrAuctionNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Auction not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, state.ForViewer(nil, false))
+}
+
 // StartItem handles POST /api/items/:id/start
 func (h *AuctionHandler) StartItem(c *gin.Context) {
 	// Get item ID from URL parameter
@@ -381,6 +405,10 @@ func (h *AuctionHandler) OpenPrice(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error: "New price must be higher than current price",
 			})
+		case errors.Is(err, service.ErrStaleFenceToken):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Item was modified by another operation. Please try again",
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, ErrorResponse{
 				Error: "Internal server error",
@@ -418,6 +446,10 @@ func (h *AuctionHandler) EndItem(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error: "No bids found for this item",
 			})
+		case errors.Is(err, service.ErrStaleFenceToken):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Item was modified by another operation. Please try again",
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, ErrorResponse{
 				Error: "Internal server error",
@@ -496,6 +528,54 @@ func (h *AuctionHandler) GetParticipants(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetBidLatency handles GET /api/admin/auctions/:id/bid-latency
+func (h *AuctionHandler) GetBidLatency(c *gin.Context) {
+	// Get auction ID from URL parameter
+	auctionID := c.Param("id")
+
+	// Call service
+	response, err := h.auctionService.GetBidLatency(auctionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAuctionNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Auction not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error: "Internal server error",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBidQueue handles GET /api/admin/items/:id/bid-queue
+func (h *AuctionHandler) GetBidQueue(c *gin.Context) {
+	// Get item ID from URL parameter
+	itemID := c.Param("id")
+
+	// Call service
+	response, err := h.auctionService.GetBidQueue(itemID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrItemNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Item not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error: "Internal server error",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // CancelAuctionWithReason handles POST /api/auctions/:id/cancel with reason
 func (h *AuctionHandler) CancelAuctionWithReason(c *gin.Context) {
 	// Get auction ID from URL parameter
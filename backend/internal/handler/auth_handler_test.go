@@ -28,6 +28,14 @@ func (m *MockAuthService) LoginAdmin(email, password string) (*domain.LoginRespo
 	return args.Get(0).(*domain.LoginResponse), args.Error(1)
 }
 
+func (m *MockAuthService) LoginBidder(email, password string) (*domain.LoginResponse, error) {
+	args := m.Called(email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LoginResponse), args.Error(1)
+}
+
 func setupTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
@@ -16,6 +17,12 @@ type BidHandler struct {
 	bidService   *service.BidService
 }
 
+// OutbidAtPriceResponse is returned when another bid was accepted first at the requested price
+type OutbidAtPriceResponse struct {
+	Error string `json:"error"`
+	domain.OutbidAtPriceResult
+}
+
 // NewBidHandler creates a new BidHandler instance
 func NewBidHandler(pointService *service.PointService, bidService *service.BidService) *BidHandler {
 	return &BidHandler{
@@ -89,6 +96,9 @@ type PlaceBidRequest struct {
 
 // PlaceBid handles POST /api/bidder/items/:id/bid
 func (h *BidHandler) PlaceBid(c *gin.Context) {
+	// Record the receive time before any processing (evidence for first-come disputes)
+	receivedAt := time.Now()
+
 	// Get item ID from URL parameter
 	itemID := c.Param("id")
 	if itemID == "" {
@@ -135,12 +145,23 @@ func (h *BidHandler) PlaceBid(c *gin.Context) {
 
 	// Call service to place bid
 	response, err := h.bidService.PlaceBid(&service.PlaceBidRequest{
-		ItemID:   itemID,
-		BidderID: bidderID,
-		Price:    req.Price,
+		ItemID:     itemID,
+		BidderID:   bidderID,
+		Price:      req.Price,
+		ReceivedAt: receivedAt,
 	})
 
 	if err != nil {
+		// Name the accepted bid when another bid took the price first
+		var outbid *service.OutbidAtPriceError
+		if errors.As(err, &outbid) {
+			c.JSON(http.StatusConflict, OutbidAtPriceResponse{
+				Error:               "Another bid was accepted first at this price",
+				OutbidAtPriceResult: outbid.Result,
+			})
+			return
+		}
+
 		// Handle different error types
 		switch {
 		case errors.Is(err, service.ErrItemNotFound):
@@ -163,9 +184,13 @@ func (h *BidHandler) PlaceBid(c *gin.Context) {
 			c.JSON(http.StatusConflict, ErrorResponse{
 				Error: "Price has changed. Please check the latest price",
 			})
+		case errors.Is(err, service.ErrStaleFenceToken):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Item was modified by another operation. Please try again",
+			})
 		case errors.Is(err, service.ErrBidLockFailed):
 			c.JSON(http.StatusConflict, ErrorResponse{
-				Error: "Another bidder placed a bid first. Please try again",
+				Error: "The item is being updated. Please try again",
 			})
 		case errors.Is(err, service.ErrAlreadyWinningBidder):
 			c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -191,7 +191,7 @@ func (h *BidderHandler) GrantPoints(c *gin.Context) {
 	}
 
 	// Call service
-	response, err := h.bidderService.GrantPoints(bidderID, req.Points, adminID)
+	response, err := h.bidderService.GrantPoints(bidderID, req.Points, adminID, req.ExpiresAt)
 	if err != nil {
 		// Handle different error types
 		switch {
@@ -207,6 +207,10 @@ func (h *BidderHandler) GrantPoints(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error: "Points exceed maximum limit",
 			})
+		case errors.Is(err, service.ErrInvalidPointExpiry):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Point expiry must be in the future",
+			})
 		default:
 			// Log internal errors but don't expose details to client
 			c.JSON(http.StatusInternalServerError, ErrorResponse{
@@ -216,11 +220,96 @@ func (h *BidderHandler) GrantPoints(c *gin.Context) {
 		return
 	}
 
+	// Grants above the approval threshold await another admin's approval
+	if response.PendingRequest != nil {
+		c.JSON(http.StatusAccepted, response)
+		return
+	}
+
 	// Return successful response
 	c.JSON(http.StatusOK, response)
 }
 
+// AdjustPoints handles POST /api/admin/bidders/:id/points/adjustments
+func (h *BidderHandler) AdjustPoints(c *gin.Context) {
+	// Get bidder ID from URL parameter
+	bidderID := c.Param("id")
+
+	// Parse request body
+	var req domain.AdjustPointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	// Get admin ID from JWT claims
+	claims, exists := c.Get("claims")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	jwtClaims, ok := claims.(*domain.JWTClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Invalid token claims",
+		})
+		return
+	}
+
+	adminID, ok := jwtClaims.GetUserIDAsInt64()
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Invalid admin ID in token",
+		})
+		return
+	}
+
+	// Call service
+	response, err := h.bidderService.AdjustPoints(bidderID, req.Amount, req.Reason, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBidderNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Bidder not found",
+			})
+		case errors.Is(err, service.ErrInvalidAdjustmentAmount):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid adjustment amount",
+			})
+		case errors.Is(err, service.ErrInvalidAdjustmentReason):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Adjustment reason is required",
+			})
+		case errors.Is(err, service.ErrPointsExceedMaximum):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Points exceed maximum limit",
+			})
+		case errors.Is(err, service.ErrAdjustmentExceedsAvailable):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Adjustment exceeds available points",
+			})
+		case errors.Is(err, service.ErrAdjustmentRequiresApproval):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error: "Adjustments above the approval threshold must be granted for approval",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error: "Internal server error",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetPointHistory handles GET /api/admin/bidders/:id/points/history
+// Optional query parameters auction_id, item_id and type filter the history
 func (h *BidderHandler) GetPointHistory(c *gin.Context) {
 	// Get bidder ID from URL parameter
 	bidderID := c.Param("id")
@@ -242,11 +331,24 @@ func (h *BidderHandler) GetPointHistory(c *gin.Context) {
 		limit = 50
 	}
 
+	// Parse optional filters (auction_id, item_id, type)
+	var filter domain.PointHistoryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid query parameters",
+		})
+		return
+	}
+
 	// Call service
-	response, err := h.bidderService.GetPointHistory(bidderID, page, limit)
+	response, err := h.bidderService.GetPointHistory(bidderID, &filter, page, limit)
 	if err != nil {
 		// Handle different error types
 		switch {
+		case errors.Is(err, service.ErrInvalidPointHistoryFilter):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid point history filter",
+			})
 		case errors.Is(err, service.ErrBidderNotFound):
 			c.JSON(http.StatusNotFound, ErrorResponse{
 				Error: "Bidder not found",
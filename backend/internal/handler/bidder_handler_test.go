@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -36,16 +37,24 @@ func (m *MockBidderService) GetBidderList(req *domain.BidderListRequest) (*domai
 	return args.Get(0).(*domain.BidderListResponse), args.Error(1)
 }
 
-func (m *MockBidderService) GrantPoints(bidderID string, points int64, adminID int64) (*domain.GrantPointsResponse, error) {
-	args := m.Called(bidderID, points, adminID)
+func (m *MockBidderService) GrantPoints(bidderID string, points int64, adminID int64, expiresAt *time.Time) (*domain.GrantPointsResponse, error) {
+	args := m.Called(bidderID, points, adminID, expiresAt)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.GrantPointsResponse), args.Error(1)
 }
 
-func (m *MockBidderService) GetPointHistory(bidderID string, page int, limit int) (*domain.PointHistoryListResponse, error) {
-	args := m.Called(bidderID, page, limit)
+func (m *MockBidderService) AdjustPoints(bidderID string, amount int64, reason string, adminID int64) (*domain.AdjustPointsResponse, error) {
+	args := m.Called(bidderID, amount, reason, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AdjustPointsResponse), args.Error(1)
+}
+
+func (m *MockBidderService) GetPointHistory(bidderID string, filter *domain.PointHistoryFilter, page int, limit int) (*domain.PointHistoryListResponse, error) {
+	args := m.Called(bidderID, filter, page, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -339,12 +348,14 @@ func TestBidderHandler_GrantPoints(t *testing.T) {
 		// Add middleware to set claims
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -360,7 +371,7 @@ func TestBidderHandler_GrantPoints(t *testing.T) {
 		adminID := int64(1)
 		displayName := "Test Bidder"
 		expectedResponse := &domain.GrantPointsResponse{
-			Bidder: domain.BidderWithPoints{
+			Bidder: &domain.BidderWithPoints{
 				Bidder: domain.Bidder{
 					ID:          bidderID,
 					Email:       "bidder@example.com",
@@ -369,7 +380,7 @@ func TestBidderHandler_GrantPoints(t *testing.T) {
 				},
 				Points: 1500,
 			},
-			History: domain.PointHistory{
+			History: &domain.PointHistory{
 				BidderID:       bidderID,
 				Amount:         points,
 				Type:           domain.PointHistoryTypeGrant,
@@ -383,7 +394,7 @@ func TestBidderHandler_GrantPoints(t *testing.T) {
 			},
 		}
 
-		mockBidderService.On("GrantPoints", bidderID, points, adminID).
+		mockBidderService.On("GrantPoints", bidderID, points, adminID, (*time.Time)(nil)).
 			Return(expectedResponse, nil)
 
 		reqBody, _ := json.Marshal(grantReq)
@@ -451,12 +462,14 @@ func TestBidderHandler_GrantPoints(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -469,7 +482,7 @@ func TestBidderHandler_GrantPoints(t *testing.T) {
 			Points: points,
 		}
 
-		mockBidderService.On("GrantPoints", bidderID, points, int64(1)).
+		mockBidderService.On("GrantPoints", bidderID, points, int64(1), (*time.Time)(nil)).
 			Return(nil, service.ErrBidderNotFound)
 
 		reqBody, _ := json.Marshal(grantReq)
@@ -496,12 +509,14 @@ func TestBidderHandler_GrantPoints(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -538,12 +553,14 @@ func TestBidderHandler_GrantPoints(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -556,7 +573,7 @@ func TestBidderHandler_GrantPoints(t *testing.T) {
 			Points: points,
 		}
 
-		mockBidderService.On("GrantPoints", bidderID, points, int64(1)).
+		mockBidderService.On("GrantPoints", bidderID, points, int64(1), (*time.Time)(nil)).
 			Return(nil, service.ErrPointsExceedMaximum)
 
 		reqBody, _ := json.Marshal(grantReq)
@@ -620,7 +637,7 @@ func TestBidderHandler_GetPointHistory(t *testing.T) {
 			},
 		}
 
-		mockBidderService.On("GetPointHistory", bidderID, 1, 10).
+		mockBidderService.On("GetPointHistory", bidderID, &domain.PointHistoryFilter{}, 1, 10).
 			Return(expectedResponse, nil)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/admin/bidders/"+bidderID+"/points/history", nil)
@@ -663,7 +680,7 @@ func TestBidderHandler_GetPointHistory(t *testing.T) {
 			},
 		}
 
-		mockBidderService.On("GetPointHistory", bidderID, 2, 20).
+		mockBidderService.On("GetPointHistory", bidderID, &domain.PointHistoryFilter{}, 2, 20).
 			Return(expectedResponse, nil)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/admin/bidders/"+bidderID+"/points/history?page=2&limit=20", nil)
@@ -689,7 +706,7 @@ func TestBidderHandler_GetPointHistory(t *testing.T) {
 			Pagination: domain.Pagination{Total: 0, Page: 1, Limit: 50, TotalPages: 0},
 		}
 
-		mockBidderService.On("GetPointHistory", bidderID, 1, 50).
+		mockBidderService.On("GetPointHistory", bidderID, &domain.PointHistoryFilter{}, 1, 50).
 			Return(expectedResponse, nil)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/admin/bidders/"+bidderID+"/points/history?limit=100", nil)
@@ -709,7 +726,7 @@ func TestBidderHandler_GetPointHistory(t *testing.T) {
 
 		bidderID := "non-existent-id"
 
-		mockBidderService.On("GetPointHistory", bidderID, 1, 10).
+		mockBidderService.On("GetPointHistory", bidderID, &domain.PointHistoryFilter{}, 1, 10).
 			Return(nil, service.ErrBidderNotFound)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/admin/bidders/"+bidderID+"/points/history", nil)
@@ -898,12 +915,14 @@ func TestBidderHandler_RegisterBidder(t *testing.T) {
 		// Add middleware to set claims
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -960,12 +979,14 @@ func TestBidderHandler_RegisterBidder(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -1019,12 +1040,14 @@ func TestBidderHandler_RegisterBidder(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -1074,12 +1097,14 @@ func TestBidderHandler_RegisterBidder(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -1117,12 +1142,14 @@ func TestBidderHandler_RegisterBidder(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -1155,12 +1182,14 @@ func TestBidderHandler_RegisterBidder(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -1193,12 +1222,14 @@ func TestBidderHandler_RegisterBidder(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -1233,12 +1264,14 @@ func TestBidderHandler_RegisterBidder(t *testing.T) {
 
 		router.Use(func(c *gin.Context) {
 			claims := &domain.JWTClaims{
-				UserID:   1,
+				UserID:   int64(1),
 				Email:    "admin@example.com",
 				Role:     domain.RoleSystemAdmin,
 				UserType: domain.UserTypeAdmin,
 			}
 			c.Set("claims", claims)
+			c.Set("user_type", claims.UserType)
+			c.Set("user_id", int64(1))
 			c.Next()
 		})
 
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// ChatHandler handles auction announcement and chat moderation HTTP requests
+type ChatHandler struct {
+	chatService *service.ChatService
+}
+
+// NewChatHandler creates a new ChatHandler instance
+func NewChatHandler(chatService *service.ChatService) *ChatHandler {
+	return &ChatHandler{
+		chatService: chatService,
+	}
+}
+
+// GetAnnouncements handles GET /api/auctions/:id/messages (public)
+func (h *ChatHandler) GetAnnouncements(c *gin.Context) {
+	response, err := h.chatService.GetMessages(c.Param("id"), nil, false)
+	if err != nil {
+		respondChatError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBidderMessages handles GET /api/bidder/auctions/:id/messages
+// Returns announcements and the bidder's own questions
+func (h *ChatHandler) GetBidderMessages(c *gin.Context) {
+	bidderIDInterface, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+	bidderID, ok := bidderIDInterface.(string)
+	if !ok || bidderID == "" {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Forbidden: Only bidders can access this endpoint",
+		})
+		return
+	}
+
+	response, err := h.chatService.GetMessages(c.Param("id"), &bidderID, false)
+	if err != nil {
+		respondChatError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetAdminMessages handles GET /api/admin/auctions/:id/messages
+// Returns every message of the auction record, including deleted ones
+func (h *ChatHandler) GetAdminMessages(c *gin.Context) {
+	response, err := h.chatService.GetMessages(c.Param("id"), nil, true)
+	if err != nil {
+		respondChatError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Announce handles POST /api/admin/auctions/:id/announcements
+func (h *ChatHandler) Announce(c *gin.Context) {
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req domain.AnnounceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	senderName := ""
+	if claims, exists := c.Get("claims"); exists {
+		if jwtClaims, ok := claims.(*domain.JWTClaims); ok {
+			senderName = jwtClaims.DisplayName
+		}
+	}
+
+	message, err := h.chatService.Announce(c.Param("id"), adminID, senderName, req.Message)
+	if err != nil {
+		respondChatError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// UpdateChatSettings handles PUT /api/admin/auctions/:id/chat
+func (h *ChatHandler) UpdateChatSettings(c *gin.Context) {
+	var req domain.UpdateChatSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.chatService.SetChatEnabled(c.Param("id"), *req.Enabled); err != nil {
+		respondChatError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"auction_id":   c.Param("id"),
+		"chat_enabled": *req.Enabled,
+	})
+}
+
+// DeleteMessage handles DELETE /api/admin/auctions/:id/messages/:messageId
+func (h *ChatHandler) DeleteMessage(c *gin.Context) {
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	messageID, err := strconv.ParseInt(c.Param("messageId"), 10, 64)
+	if err != nil || messageID < 1 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid message ID",
+		})
+		return
+	}
+
+	if err := h.chatService.DeleteMessage(c.Param("id"), messageID, adminID); err != nil {
+		respondChatError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MuteBidder handles PUT /api/admin/auctions/:id/mutes/:bidderId
+func (h *ChatHandler) MuteBidder(c *gin.Context) {
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req domain.MuteBidderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.chatService.MuteBidder(c.Param("id"), c.Param("bidderId"), adminID, req.Reason); err != nil {
+		respondChatError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnmuteBidder handles DELETE /api/admin/auctions/:id/mutes/:bidderId
+func (h *ChatHandler) UnmuteBidder(c *gin.Context) {
+	if err := h.chatService.UnmuteBidder(c.Param("id"), c.Param("bidderId")); err != nil {
+		respondChatError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// adminIDFromContext returns the admin ID set by the auth middleware.
+// Writes an error response and returns false when it is missing.
+func adminIDFromContext(c *gin.Context) (int64, bool) {
+	adminIDInterface, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return 0, false
+	}
+	adminID, ok := adminIDInterface.(int64)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Invalid admin ID",
+		})
+		return 0, false
+	}
+	return adminID, true
+}
+
+// respondChatError writes the HTTP response for a ChatService error
+func respondChatError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAuctionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Auction not found",
+		})
+	case errors.Is(err, service.ErrBidderNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Bidder not found",
+		})
+	case errors.Is(err, service.ErrMessageNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Message not found",
+		})
+	case errors.Is(err, service.ErrInvalidMessage):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Message must be 1-" + strconv.Itoa(service.MaxChatMessageLength) + " characters",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// maxPointGrantUploadSize is the maximum size of an uploaded bulk point grant CSV
+const maxPointGrantUploadSize = 1 << 20 // 1MB
+
+// PointGrantBatchHandler handles bulk point grant requests
+type PointGrantBatchHandler struct {
+	batchService *service.PointGrantBatchService
+}
+
+// NewPointGrantBatchHandler creates a new PointGrantBatchHandler instance
+func NewPointGrantBatchHandler(batchService *service.PointGrantBatchService) *PointGrantBatchHandler {
+	return &PointGrantBatchHandler{
+		batchService: batchService,
+	}
+}
+
+// CreateBatch handles POST /api/admin/points/grant-batches
+// Accepts the rows as JSON, a text/csv body or a multipart "file" upload with bidder, points and
+// reason columns. Runs as a dry run unless dry_run=false is given.
+func (h *PointGrantBatchHandler) CreateBatch(c *gin.Context) {
+	dryRun := true
+	if value := c.Query("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid dry_run value",
+			})
+			return
+		}
+		dryRun = parsed
+	}
+
+	rows, err := bindPointGrantRows(c)
+	if err != nil {
+		message := "Invalid request body"
+		if errors.Is(err, service.ErrInvalidPointGrantCSV) {
+			message = err.Error()
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: message,
+		})
+		return
+	}
+
+	var result *domain.PointGrantBatchResult
+	if dryRun {
+		result, err = h.batchService.Preview(rows)
+	} else {
+		adminID, ok := adminIDFromContext(c)
+		if !ok {
+			return
+		}
+		result, err = h.batchService.Apply(rows, adminID)
+	}
+
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyPointGrantBatch):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "No rows to grant",
+			})
+		case errors.Is(err, service.ErrTooManyPointGrantRows):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Too many rows",
+			})
+		case errors.Is(err, service.ErrPointGrantBatchInvalid):
+			// Nothing was applied; return the validation results
+			c.JSON(http.StatusUnprocessableEntity, result)
+		default:
+			log.Printf("Failed to grant points in bulk: %v", err)
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error: "Internal server error",
+			})
+		}
+		return
+	}
+
+	if dryRun {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetBatch handles GET /api/admin/points/grant-batches/:id
+func (h *PointGrantBatchHandler) GetBatch(c *gin.Context) {
+	detail, err := h.batchService.GetBatch(c.Param("id"))
+	if err != nil {
+		respondPointGrantBatchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// ReverseBatch handles POST /api/admin/points/grant-batches/:id/reverse
+func (h *PointGrantBatchHandler) ReverseBatch(c *gin.Context) {
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	detail, err := h.batchService.Reverse(c.Param("id"), adminID)
+	if err != nil {
+		respondPointGrantBatchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// bindPointGrantRows reads the bulk point grant rows from a multipart upload, a CSV body or JSON
+func bindPointGrantRows(c *gin.Context) ([]domain.PointGrantRow, error) {
+	switch c.ContentType() {
+	case "multipart/form-data":
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		if fileHeader.Size > maxPointGrantUploadSize {
+			return nil, errors.New("file too large")
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return service.ParsePointGrantCSV(file)
+	case "text/csv":
+		return service.ParsePointGrantCSV(io.LimitReader(c.Request.Body, maxPointGrantUploadSize))
+	default:
+		var req domain.PointGrantBatchRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, err
+		}
+		return req.Rows, nil
+	}
+}
+
+// respondPointGrantBatchError writes the HTTP response for a PointGrantBatchService error
+func respondPointGrantBatchError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPointGrantBatchNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Point grant batch not found",
+		})
+	case errors.Is(err, service.ErrPointGrantBatchAlreadyReversed):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: "Point grant batch is already reversed",
+		})
+	case errors.Is(err, service.ErrPointGrantReversalInsufficient):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+		})
+	default:
+		log.Printf("Failed to handle point grant batch: %v", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// PointGrantRequestHandler handles the approval of point grants above the approval threshold
+type PointGrantRequestHandler struct {
+	requestService *service.PointGrantRequestService
+}
+
+// NewPointGrantRequestHandler creates a new PointGrantRequestHandler instance
+func NewPointGrantRequestHandler(requestService *service.PointGrantRequestService) *PointGrantRequestHandler {
+	return &PointGrantRequestHandler{
+		requestService: requestService,
+	}
+}
+
+// ListRequests handles GET /api/admin/points/grant-requests
+// Query parameter status filters by pending, approved or rejected (default: pending)
+func (h *PointGrantRequestHandler) ListRequests(c *gin.Context) {
+	response, err := h.requestService.ListRequests(c.DefaultQuery("status", string(domain.PointGrantRequestStatusPending)))
+	if err != nil {
+		respondPointGrantRequestError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveRequest handles POST /api/admin/points/grant-requests/:id/approve
+func (h *PointGrantRequestHandler) ApproveRequest(c *gin.Context) {
+	requestID, ok := parsePointGrantRequestID(c)
+	if !ok {
+		return
+	}
+
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	decision, err := h.requestService.Approve(requestID, adminID)
+	if err != nil {
+		respondPointGrantRequestError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, decision)
+}
+
+// RejectRequest handles POST /api/admin/points/grant-requests/:id/reject
+func (h *PointGrantRequestHandler) RejectRequest(c *gin.Context) {
+	requestID, ok := parsePointGrantRequestID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.RejectPointGrantRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid request body",
+			})
+			return
+		}
+	}
+
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	decision, err := h.requestService.Reject(requestID, adminID, req.Note)
+	if err != nil {
+		respondPointGrantRequestError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, decision)
+}
+
+// parsePointGrantRequestID parses the request ID from the URL parameter
+func parsePointGrantRequestID(c *gin.Context) (int64, bool) {
+	requestID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || requestID <= 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request ID",
+		})
+		return 0, false
+	}
+	return requestID, true
+}
+
+// respondPointGrantRequestError writes the HTTP response for a PointGrantRequestService error
+func respondPointGrantRequestError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidPointGrantRequestStatus):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid status",
+		})
+	case errors.Is(err, service.ErrInvalidDecisionNote):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Decision note is too long",
+		})
+	case errors.Is(err, service.ErrPointGrantRequestNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Point grant request not found",
+		})
+	case errors.Is(err, service.ErrPointGrantRequestNotPending):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: "Point grant request was already decided",
+		})
+	case errors.Is(err, service.ErrSelfApproval):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Point grant request must be decided by another admin",
+		})
+	case errors.Is(err, service.ErrPointGrantRequestExpired):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: "The expiry of the requested points has passed",
+		})
+	case errors.Is(err, service.ErrPointGrantBidderNotActive):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: "Bidder is no longer active",
+		})
+	default:
+		log.Printf("Failed to handle point grant request: %v", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// PointReconciliationHandler handles point ledger reconciliation requests
+type PointReconciliationHandler struct {
+	reconciliationService *service.PointReconciliationService
+}
+
+// NewPointReconciliationHandler creates a new PointReconciliationHandler instance
+func NewPointReconciliationHandler(reconciliationService *service.PointReconciliationService) *PointReconciliationHandler {
+	return &PointReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// GetReconciliation handles GET /api/admin/points/reconciliation
+// Reports the point ledger discrepancies without correcting them
+func (h *PointReconciliationHandler) GetReconciliation(c *gin.Context) {
+	report, err := h.reconciliationService.Reconcile(false, nil)
+	if err != nil {
+		log.Printf("Failed to reconcile points: %v", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Reconcile handles POST /api/admin/points/reconciliation
+// Reports the point ledger discrepancies and optionally records adjust entries correcting them
+func (h *PointReconciliationHandler) Reconcile(c *gin.Context) {
+	var req domain.PointReconciliationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reconciliationService.Reconcile(req.ApplyCorrections, &adminID)
+	if err != nil {
+		log.Printf("Failed to reconcile points: %v", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
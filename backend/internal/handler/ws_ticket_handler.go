@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// WSTicketHandler handles WebSocket ticket HTTP requests
+type WSTicketHandler struct {
+	ticketService *service.WSTicketService
+}
+
+// NewWSTicketHandler creates a new WSTicketHandler instance
+func NewWSTicketHandler(ticketService *service.WSTicketService) *WSTicketHandler {
+	return &WSTicketHandler{
+		ticketService: ticketService,
+	}
+}
+
+// IssueTicket handles POST /api/ws/ticket
+// Returns a single-use ticket that the client passes as ?ticket= when opening the WebSocket
+func (h *WSTicketHandler) IssueTicket(c *gin.Context) {
+	claims, exists := c.Get("claims")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	jwtClaims, ok := claims.(*domain.JWTClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Invalid token claims",
+		})
+		return
+	}
+
+	ticket, err := h.ticketService.IssueTicket(jwtClaims)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to issue WebSocket ticket",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, ticket)
+}
@@ -7,35 +7,53 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// AllowedOrigins returns the origins configured in CORS_ORIGINS (comma-separated).
+// Returns ["*"] when unset, which allows any origin (development default).
+func AllowedOrigins() []string {
+	allowedOrigins := os.Getenv("CORS_ORIGINS")
+	if allowedOrigins == "" {
+		// Default for development
+		return []string{"*"}
+	}
+
+	origins := make([]string, 0)
+	for _, o := range strings.Split(allowedOrigins, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			origins = append(origins, o)
+		}
+	}
+	return origins
+}
+
+// IsOriginAllowed reports whether the origin matches one of the allowed origins
+func IsOriginAllowed(origin string, allowedOrigins []string) bool {
+	for _, o := range allowedOrigins {
+		if o == "*" || o == origin {
+			return true
+		}
+	}
+	return false
+}
+
 // CORSMiddleware handles Cross-Origin Resource Sharing
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get allowed origins from environment variable
-		allowedOrigins := os.Getenv("CORS_ORIGINS")
-		if allowedOrigins == "" {
-			// Default for development
-			allowedOrigins = "*"
-		}
+		allowedOrigins := AllowedOrigins()
 
 		// Get request origin
 		origin := c.Request.Header.Get("Origin")
 
 		// Check if origin is allowed
-		if allowedOrigins == "*" {
+		if len(allowedOrigins) == 1 && allowedOrigins[0] == "*" {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		} else {
-			origins := strings.Split(allowedOrigins, ",")
-			for _, o := range origins {
-				if strings.TrimSpace(o) == origin {
-					c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-					break
-				}
-			}
+		} else if IsOriginAllowed(origin, allowedOrigins) {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
 		}
 
 		// Set CORS headers
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, Authorization, Accept, Origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, Authorization, Accept, Origin, Cache-Control, X-Requested-With, Idempotency-Key")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 		c.Writer.Header().Set("Access-Control-Max-Age", "3600")
 
@@ -0,0 +1,276 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// IdempotencyKeyHeader は冪等性キーを指定するリクエストヘッダー
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader は保存済みの応答を再送したことを示すレスポンスヘッダー
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// DefaultIdempotencyTTL は冪等性キーと応答を保持するデフォルトの期間
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	// IdempotencyProcessingTTL は処理中のキーを保持する期間
+	// プロセスが応答を保存・解放できずに停止しても、この期間が過ぎればキーを再利用できる
+	IdempotencyProcessingTTL = 2 * time.Minute
+
+	// MaxIdempotencyKeyLength は冪等性キーの最大長
+	MaxIdempotencyKeyLength = 255
+
+	// 冪等性を適用するリクエストボディの最大サイズ
+	maxIdempotentBodySize = 1 << 20 // 1MB
+)
+
+// IdempotencyRecord は冪等性キーごとに保存するリクエストと応答の記録
+type IdempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`            // メソッド・パス・クエリ・ボディのハッシュ
+	Completed   bool   `json:"completed"`              // 応答が保存済みかどうか（falseは処理中）
+	StatusCode  int    `json:"status_code,omitempty"`  // 保存済みの応答ステータス
+	ContentType string `json:"content_type,omitempty"` // 保存済みの応答のContent-Type
+	Body        []byte `json:"body,omitempty"`         // 保存済みの応答ボディ
+}
+
+// IdempotencyStore は冪等性キーの記録を保持するストア
+type IdempotencyStore interface {
+	// Reserve はキーが未使用なら処理中の記録を保存してtrueを返す
+	// 既に使用済みの場合は保存済みの記録とfalseを返す
+	Reserve(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (*IdempotencyRecord, bool, error)
+	// Complete は処理済みの記録でキーを上書きする
+	Complete(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+	// Release はキーを削除して再試行できるようにする
+	Release(ctx context.Context, key string) error
+}
+
+// RedisIdempotencyStore はRedisに冪等性キーの記録を保持する
+type RedisIdempotencyStore struct {
+	client *redis.Client
+}
+
+// NewRedisIdempotencyStore は新しいRedisIdempotencyStoreを作成する
+func NewRedisIdempotencyStore(client *redis.Client) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{client: client}
+}
+
+// Reserve はキーが未使用なら処理中の記録を保存する
+func (s *RedisIdempotencyStore) Reserve(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return nil, false, err
+	}
+
+	reserved, err := s.client.SetNX(ctx, key, payload, ttl).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if reserved {
+		return nil, true, nil
+	}
+
+	stored, err := s.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			// 直前に期限切れになった場合は再度確保を試みる
+			return s.Reserve(ctx, key, record, ttl)
+		}
+		return nil, false, err
+	}
+
+	var existing IdempotencyRecord
+	if err := json.Unmarshal(stored, &existing); err != nil {
+		return nil, false, err
+	}
+	return &existing, false, nil
+}
+
+// Complete は処理済みの記録でキーを上書きする
+func (s *RedisIdempotencyStore) Complete(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, key, payload, ttl).Err()
+}
+
+// Release はキーを削除する
+func (s *RedisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+// LoadIdempotencyTTL は環境変数IDEMPOTENCY_TTL_SECONDSから冪等性キーの保持期間を読み込む
+func LoadIdempotencyTTL() time.Duration {
+	if ttl := os.Getenv("IDEMPOTENCY_TTL_SECONDS"); ttl != "" {
+		if seconds, err := strconv.Atoi(ttl); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return DefaultIdempotencyTTL
+}
+
+// Idempotency はIdempotency-Keyヘッダー付きのリクエストを一度だけ処理するミドルウェア
+// 処理中のキーはIdempotencyProcessingTTLの間だけ確保し、最初の応答をユーザー・ルート・キーごとにttlの間保存する
+// 同じキーの再送には保存済みの応答を返す
+// 同じキーを異なるリクエストで再利用した場合は422を返す
+// 認証ミドルウェアの後に適用すること（ユーザーの識別に認証情報を使用する）
+func Idempotency(store IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			c.Next()
+			return
+		}
+
+		if len(idempotencyKey) > MaxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Idempotency-Key must be at most %d characters", MaxIdempotencyKeyLength),
+			})
+			c.Abort()
+			return
+		}
+
+		userType, _ := c.Get("user_type")
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Authentication required",
+			})
+			c.Abort()
+			return
+		}
+
+		// リクエストボディを読み込み、ハンドラー用に復元する
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxIdempotentBodySize+1))
+		if err != nil || len(body) > maxIdempotentBodySize {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid request body",
+			})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		key := fmt.Sprintf("idempotency:%v:%v:%s:%s:%s", userType, userID, c.Request.Method, c.FullPath(), idempotencyKey)
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.RequestURI(), body)
+
+		existing, reserved, err := store.Reserve(ctx, key, &IdempotencyRecord{Fingerprint: fingerprint}, IdempotencyProcessingTTL)
+		if err != nil {
+			log.Printf("[Idempotency] Failed to reserve key: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal server error",
+			})
+			c.Abort()
+			return
+		}
+
+		if !reserved {
+			switch {
+			case existing.Fingerprint != fingerprint:
+				c.JSON(http.StatusUnprocessableEntity, gin.H{
+					"error": "Idempotency-Key was already used for a different request",
+				})
+			case !existing.Completed:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": "A request with this Idempotency-Key is already being processed",
+				})
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		// リクエストのコンテキストがキャンセルされていても記録できるよう新しいコンテキストを使用する
+		storeCtx := context.Background()
+
+		// ハンドラーがパニックした場合もキーを解放して再試行できるようにする
+		// パニックはそのまま上位のRecoveryミドルウェアに伝える
+		defer func() {
+			if r := recover(); r != nil {
+				if err := store.Release(storeCtx, key); err != nil {
+					log.Printf("[Idempotency] Failed to release key: %v", err)
+				}
+				panic(r)
+			}
+		}()
+
+		// 応答を記録しながらハンドラーを実行する
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// 再試行で結果が変わりうる応答はキーを解放する
+		if isRetryableStatus(recorder.Status()) {
+			if err := store.Release(storeCtx, key); err != nil {
+				log.Printf("[Idempotency] Failed to release key: %v", err)
+			}
+			return
+		}
+
+		record := &IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}
+		// 処理中の短い保持期間を応答の保持期間に延長する
+		if err := store.Complete(storeCtx, key, record, ttl); err != nil {
+			log.Printf("[Idempotency] Failed to store response: %v", err)
+		}
+	}
+}
+
+// isRetryableStatus は同じリクエストの再試行で結果が変わりうる応答ステータスかどうかを返す
+// サーバーエラー、競合（ロック取得失敗や他の操作との衝突）、レート制限は処理が反映されていないため保存しない
+func isRetryableStatus(status int) bool {
+	return status >= http.StatusInternalServerError ||
+		status == http.StatusConflict ||
+		status == http.StatusTooManyRequests
+}
+
+// requestFingerprint はリクエストのメソッド・パス（クエリを含む）・ボディからハッシュを作成する
+func requestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder はクライアントに書き込んだ応答ボディを記録する
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
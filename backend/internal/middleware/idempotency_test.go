@@ -0,0 +1,211 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotencyStore はテスト用のメモリ上のストア
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+	ttls    map[string]time.Duration
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{
+		records: make(map[string]IdempotencyRecord),
+		ttls:    make(map[string]time.Duration),
+	}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[key]; ok {
+		return &existing, false, nil
+	}
+	s.records[key] = *record
+	s.ttls[key] = ttl
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = *record
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// setupIdempotencyRouter は呼び出し回数を数えるハンドラーに冪等性ミドルウェアを適用したルーターを作成する
+func setupIdempotencyRouter(store IdempotencyStore, status int) (*gin.Engine, *int) {
+	calls := 0
+	router := gin.New()
+	router.POST("/items/:id/bid", func(c *gin.Context) {
+		c.Set("user_type", "bidder")
+		c.Set("user_id", "bidder-1")
+		c.Next()
+	}, Idempotency(store, time.Hour), func(c *gin.Context) {
+		calls++
+		c.JSON(status, gin.H{"call": calls})
+	})
+	return router, &calls
+}
+
+func postWithKey(router *gin.Engine, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysFirstResponse(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newMemoryIdempotencyStore(), http.StatusOK)
+
+	first := postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+	second := postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestIdempotency_ProcessingTTLExtendedOnComplete(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	key := "idempotency:bidder:bidder-1:POST:/items/:id/bid:key-1"
+
+	var reservedTTL time.Duration
+	router := gin.New()
+	router.POST("/items/:id/bid", func(c *gin.Context) {
+		c.Set("user_type", "bidder")
+		c.Set("user_id", "bidder-1")
+		c.Next()
+	}, Idempotency(store, time.Hour), func(c *gin.Context) {
+		reservedTTL = store.ttls[key]
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+
+	// 処理中は短い期間だけ確保し、応答の保存時に延長する
+	assert.Equal(t, IdempotencyProcessingTTL, reservedTTL)
+	assert.Equal(t, time.Hour, store.ttls[key])
+}
+
+func TestIdempotency_HandlerPanicReleasesKey(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.POST("/items/:id/bid", func(c *gin.Context) {
+		c.Set("user_type", "bidder")
+		c.Set("user_id", "bidder-1")
+		c.Next()
+	}, Idempotency(store, time.Hour), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("handler failed")
+		}
+		c.JSON(http.StatusOK, gin.H{"call": calls})
+	})
+
+	first := postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+	assert.Empty(t, store.records)
+
+	// パニック後も同じキーで再試行できる
+	second := postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_RejectsMismatchedRequest(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newMemoryIdempotencyStore(), http.StatusOK)
+
+	postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+
+	// ボディが異なる
+	w := postWithKey(router, "/items/1/bid", "key-1", `{"price":2000}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// 対象の商品が異なる
+	w = postWithKey(router, "/items/2/bid", "key-1", `{"price":1000}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	assert.Equal(t, 1, *calls)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	router, calls := setupIdempotencyRouter(store, http.StatusOK)
+
+	// 同じキーのリクエストが処理中
+	store.records["idempotency:bidder:bidder-1:POST:/items/:id/bid:key-1"] = IdempotencyRecord{
+		Fingerprint: requestFingerprint(http.MethodPost, "/items/1/bid", []byte(`{"price":1000}`)),
+	}
+
+	w := postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, 0, *calls)
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newMemoryIdempotencyStore(), http.StatusInternalServerError)
+
+	postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+	postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+
+	assert.Equal(t, 2, *calls)
+}
+
+func TestIdempotency_RetryableConflictReleasesKey(t *testing.T) {
+	for _, status := range []int{http.StatusConflict, http.StatusTooManyRequests} {
+		router, calls := setupIdempotencyRouter(newMemoryIdempotencyStore(), status)
+
+		postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+		w := postWithKey(router, "/items/1/bid", "key-1", `{"price":1000}`)
+
+		assert.Equal(t, status, w.Code)
+		assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, 2, *calls)
+	}
+}
+
+func TestIdempotency_WithoutKey(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newMemoryIdempotencyStore(), http.StatusOK)
+
+	postWithKey(router, "/items/1/bid", "", `{"price":1000}`)
+	postWithKey(router, "/items/1/bid", "", `{"price":1000}`)
+
+	assert.Equal(t, 2, *calls)
+}
+
+func TestLoadIdempotencyTTL(t *testing.T) {
+	t.Setenv("IDEMPOTENCY_TTL_SECONDS", "600")
+	assert.Equal(t, 10*time.Minute, LoadIdempotencyTTL())
+
+	t.Setenv("IDEMPOTENCY_TTL_SECONDS", "invalid")
+	assert.Equal(t, DefaultIdempotencyTTL, LoadIdempotencyTTL())
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AuctionMessageRepository handles database operations for auction messages and chat moderation
+type AuctionMessageRepository struct {
+	db *gorm.DB
+}
+
+// NewAuctionMessageRepository creates a new AuctionMessageRepository instance
+func NewAuctionMessageRepository(db *gorm.DB) *AuctionMessageRepository {
+	return &AuctionMessageRepository{db: db}
+}
+
+// Create creates a new auction message
+func (r *AuctionMessageRepository) Create(message *domain.AuctionMessage) error {
+	return r.db.Create(message).Error
+}
+
+// FindByID retrieves a message by ID
+func (r *AuctionMessageRepository) FindByID(id int64) (*domain.AuctionMessage, error) {
+	var message domain.AuctionMessage
+
+	result := r.db.First(&message, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &message, nil
+}
+
+// FindByAuctionID retrieves every message of an auction, including deleted ones, oldest first
+func (r *AuctionMessageRepository) FindByAuctionID(auctionID uuid.UUID) ([]domain.AuctionMessage, error) {
+	var messages []domain.AuctionMessage
+
+	result := r.db.Where("auction_id = ?", auctionID).
+		Order("created_at ASC, id ASC").
+		Find(&messages)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return messages, nil
+}
+
+// FindVisible retrieves the non-deleted announcements of an auction, plus the questions sent by
+// the given bidder when bidderID is set, oldest first
+func (r *AuctionMessageRepository) FindVisible(auctionID uuid.UUID, bidderID *uuid.UUID) ([]domain.AuctionMessage, error) {
+	var messages []domain.AuctionMessage
+
+	query := r.db.Where("auction_id = ? AND deleted_at IS NULL", auctionID)
+	if bidderID != nil {
+		query = query.Where("(kind = ? OR bidder_id = ?)", domain.AuctionMessageKindAnnouncement, *bidderID)
+	} else {
+		query = query.Where("kind = ?", domain.AuctionMessageKindAnnouncement)
+	}
+
+	result := query.Order("created_at ASC, id ASC").Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return messages, nil
+}
+
+// MarkDeleted marks a message as deleted by a moderator.
+// Returns false when the message does not exist or was already deleted.
+func (r *AuctionMessageRepository) MarkDeleted(id int64, adminID int64, deletedAt time.Time) (bool, error) {
+	result := r.db.Model(&domain.AuctionMessage{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": deletedAt,
+			"deleted_by": adminID,
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Mute mutes a bidder in an auction, replacing the reason of an existing mute
+func (r *AuctionMessageRepository) Mute(mute *domain.AuctionChatMute) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "auction_id"}, {Name: "bidder_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"muted_by", "reason"}),
+	}).Create(mute).Error
+}
+
+// Unmute removes a bidder's mute in an auction
+func (r *AuctionMessageRepository) Unmute(auctionID, bidderID uuid.UUID) error {
+	return r.db.Where("auction_id = ? AND bidder_id = ?", auctionID, bidderID).
+		Delete(&domain.AuctionChatMute{}).Error
+}
+
+// IsMuted checks whether a bidder is muted in an auction
+func (r *AuctionMessageRepository) IsMuted(auctionID, bidderID uuid.UUID) (bool, error) {
+	var count int64
+
+	result := r.db.Model(&domain.AuctionChatMute{}).
+		Where("auction_id = ? AND bidder_id = ?", auctionID, bidderID).
+		Count(&count)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// SetChatEnabled enables or disables bidder messages for an auction.
+// Returns false when the auction does not exist.
+func (r *AuctionMessageRepository) SetChatEnabled(auctionID uuid.UUID, enabled bool) (bool, error) {
+	result := r.db.Model(&domain.Auction{}).
+		Where("id = ?", auctionID).
+		Update("chat_enabled", enabled)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
+
+func TestAuctionMessageRepository_Create(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewAuctionMessageRepository(db)
+
+	t.Run("Success - Create question", func(t *testing.T) {
+		bidderID := uuid.New()
+		message := &domain.AuctionMessage{
+			AuctionID:  uuid.New(),
+			Kind:       domain.AuctionMessageKindQuestion,
+			BidderID:   &bidderID,
+			SenderName: "Bidder",
+			Body:       "Is lot 12 still available?",
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "auction_messages"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		err := repo.Create(message)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), message.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuctionMessageRepository_FindVisible(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewAuctionMessageRepository(db)
+
+	columns := []string{"id", "auction_id", "kind", "admin_id", "bidder_id", "sender_name", "body", "deleted_at", "deleted_by", "created_at"}
+
+	t.Run("Success - Bidder sees announcements and own questions", func(t *testing.T) {
+		auctionID := uuid.New()
+		bidderID := uuid.New()
+		now := time.Now()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, auctionID, "announcement", 1, nil, "Auctioneer", "Lot 12 withdrawn", nil, nil, now).
+			AddRow(2, auctionID, "question", nil, bidderID, "Bidder", "Why?", nil, nil, now)
+
+		mock.ExpectQuery(`SELECT \* FROM "auction_messages" WHERE \(auction_id = \$1 AND deleted_at IS NULL\) AND \(\(kind = \$2 OR bidder_id = \$3\)\)`).
+			WithArgs(auctionID, domain.AuctionMessageKindAnnouncement, bidderID).
+			WillReturnRows(rows)
+
+		messages, err := repo.FindVisible(auctionID, &bidderID)
+
+		assert.NoError(t, err)
+		assert.Len(t, messages, 2)
+		assert.Equal(t, domain.AuctionMessageKindQuestion, messages[1].Kind)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success - Spectator sees announcements only", func(t *testing.T) {
+		auctionID := uuid.New()
+
+		mock.ExpectQuery(`SELECT \* FROM "auction_messages" WHERE \(auction_id = \$1 AND deleted_at IS NULL\) AND kind = \$2`).
+			WithArgs(auctionID, domain.AuctionMessageKindAnnouncement).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		messages, err := repo.FindVisible(auctionID, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, messages)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuctionMessageRepository_MarkDeleted(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewAuctionMessageRepository(db)
+
+	t.Run("Success - Mark message deleted", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "auction_messages" SET "deleted_at"=\$1,"deleted_by"=\$2 WHERE id = \$3 AND deleted_at IS NULL`).
+			WithArgs(sqlmock.AnyArg(), int64(1), int64(10)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		deleted, err := repo.MarkDeleted(10, 1, time.Now())
+
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already deleted - Returns false", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "auction_messages"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		deleted, err := repo.MarkDeleted(10, 1, time.Now())
+
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuctionMessageRepository_IsMuted(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewAuctionMessageRepository(db)
+
+	auctionID := uuid.New()
+	bidderID := uuid.New()
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "auction_chat_mutes" WHERE auction_id = \$1 AND bidder_id = \$2`).
+		WithArgs(auctionID, bidderID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	muted, err := repo.IsMuted(auctionID, bidderID)
+
+	assert.NoError(t, err)
+	assert.True(t, muted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -421,6 +421,39 @@ func (r *AuctionRepository) FindItemByID(itemID string) (*domain.Item, error) {
 	return &item, nil
 }
 
+// FindItemForUpdate finds an item and locks its row until the end of the transaction.
+// Bids, price disclosures and item end take this lock so that they are serialized per item.
+func (r *AuctionRepository) FindItemForUpdate(itemID uuid.UUID, tx *gorm.DB) (*domain.Item, error) {
+	var item domain.Item
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, "id = ?", itemID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &item, nil
+}
+
+// AdvanceFenceToken increments the item's fence token if it still equals token, the value the
+// operation read under the item lock. Returns false when another operation has written to the
+// item since, so the caller's writes are stale.
+func (r *AuctionRepository) AdvanceFenceToken(itemID uuid.UUID, token int64, tx *gorm.DB) (bool, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	result := db.Exec("UPDATE items SET fence_token = fence_token + 1 WHERE id = ? AND fence_token = ?", itemID, token)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 // StartItem starts an item by setting its current_price to starting_price and recording started_at
 func (r *AuctionRepository) StartItem(itemID string) (*domain.Item, error) {
 	id, err := uuid.Parse(itemID)
@@ -702,6 +735,37 @@ func (r *AuctionRepository) GetBidderInfo(bidderID uuid.UUID, auctionIDStr strin
 	return &result, nil
 }
 
+// GetBiddersInfo retrieves participant information for multiple bidders in a single query
+func (r *AuctionRepository) GetBiddersInfo(bidderIDs []uuid.UUID, auctionIDStr string) ([]domain.ParticipantInfo, error) {
+	results := make([]domain.ParticipantInfo, 0, len(bidderIDs))
+	if len(bidderIDs) == 0 {
+		return results, nil
+	}
+
+	// Parse auction_id UUID string
+	auctionID, err := uuid.Parse(auctionIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.Table("bidders bd").
+		Select(`bd.id as bidder_id,
+			bd.display_name,
+			COALESCE(COUNT(b.id), 0) as bid_count,
+			true as is_online,
+			MAX(b.bid_at) as last_bid_at`).
+		Joins("LEFT JOIN bids b ON bd.id = b.bidder_id AND b.item_id IN (SELECT id FROM items WHERE auction_id = ?)", auctionID).
+		Where("bd.id IN ?", bidderIDs).
+		Group("bd.id, bd.display_name").
+		Order("bd.display_name ASC")
+
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 // CancelAuctionWithRefunds cancels an auction and refunds all reserved points
 func (r *AuctionRepository) CancelAuctionWithRefunds(auctionID string, reason string) (*domain.CancelAuctionResponse, error) {
 	id, err := uuid.Parse(auctionID)
@@ -744,6 +808,7 @@ func (r *AuctionRepository) CancelAuctionWithRefunds(auctionID string, reason st
 
 		var totalRefunded int64
 		refundedCount := int64(len(bidderPoints))
+		refundedBidderIDs := make([]uuid.UUID, 0, len(bidderPoints))
 
 		// Refund reserved points for each bidder
 		for _, bp := range bidderPoints {
@@ -780,15 +845,14 @@ func (r *AuctionRepository) CancelAuctionWithRefunds(auctionID string, reason st
 				ReservedAfter:    newReserved,
 				TotalBefore:      currentPoints.TotalPoints,
 				TotalAfter:       currentPoints.TotalPoints,
-				// Note: related_auction_id is BIGINT in DB but auction.id is UUID
-				// We can't set this field until schema is fixed
-				RelatedAuctionID: nil,
+				RelatedAuctionID: &id,
 			}
 			if err := tx.Create(pointHistory).Error; err != nil {
 				return err
 			}
 
 			totalRefunded += bp.ReservedPoints
+			refundedBidderIDs = append(refundedBidderIDs, bp.BidderID)
 		}
 
 		// Build response
@@ -798,6 +862,7 @@ func (r *AuctionRepository) CancelAuctionWithRefunds(auctionID string, reason st
 			RefundedBidders:     refundedCount,
 			TotalRefundedPoints: totalRefunded,
 			CancelledAt:         now,
+			RefundedBidderIDs:   refundedBidderIDs,
 		}
 
 		return nil
@@ -19,8 +19,13 @@ func NewBidRepository(db *gorm.DB) *BidRepository {
 }
 
 // CreateBid creates a new bid record
-func (r *BidRepository) CreateBid(bid *domain.Bid) error {
-	return r.db.Create(bid).Error
+func (r *BidRepository) CreateBid(bid *domain.Bid, tx *gorm.DB) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	return db.Create(bid).Error
 }
 
 // FindBidsByItemID retrieves bids for a specific item with pagination
@@ -58,10 +63,31 @@ func (r *BidRepository) CountBidsByItemID(itemID uuid.UUID) (int64, error) {
 	return count, nil
 }
 
+// FindBidderIDsByItemID retrieves the distinct bidders who placed bids on an item
+func (r *BidRepository) FindBidderIDsByItemID(itemID uuid.UUID) ([]uuid.UUID, error) {
+	var bidderIDs []uuid.UUID
+
+	result := r.db.Model(&domain.Bid{}).
+		Distinct("bidder_id").
+		Where("item_id = ?", itemID).
+		Pluck("bidder_id", &bidderIDs)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return bidderIDs, nil
+}
+
 // FindWinningBidByItemID retrieves the current winning bid for an item
-func (r *BidRepository) FindWinningBidByItemID(itemID uuid.UUID) (*domain.Bid, error) {
+func (r *BidRepository) FindWinningBidByItemID(itemID uuid.UUID, tx *gorm.DB) (*domain.Bid, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
 	var bid domain.Bid
-	result := r.db.Where("item_id = ? AND is_winning = ?", itemID, true).First(&bid)
+	result := db.Where("item_id = ? AND is_winning = ?", itemID, true).First(&bid)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -97,3 +123,89 @@ func (r *BidRepository) UpdateBidWinningStatus(itemID uuid.UUID, bidID int64, tx
 
 	return nil
 }
+
+// FindBidByID retrieves a bid by ID
+func (r *BidRepository) FindBidByID(id int64) (*domain.Bid, error) {
+	var bid domain.Bid
+	result := r.db.First(&bid, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &bid, nil
+}
+
+// CreateQueueEntry records how a bid request passing through an item's bid queue was resolved
+func (r *BidRepository) CreateQueueEntry(entry *domain.BidQueueEntry, tx *gorm.DB) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	return db.Create(entry).Error
+}
+
+// FindQueueEntriesByItemID retrieves the bid queue audit records of an item in arrival order
+func (r *BidRepository) FindQueueEntriesByItemID(itemID uuid.UUID) ([]domain.BidQueueEntry, error) {
+	var entries []domain.BidQueueEntry
+
+	result := r.db.Where("item_id = ?", itemID).
+		Order("position ASC NULLS LAST, id ASC").
+		Find(&entries)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return entries, nil
+}
+
+// latencyColumns selects the round-trip time distribution of the bids in a query
+const latencyColumns = "COUNT(*) AS bid_count, COUNT(b.client_rtt_ms) AS samples, " +
+	"COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY b.client_rtt_ms), 0) AS p50, " +
+	"COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY b.client_rtt_ms), 0) AS p90, " +
+	"COALESCE(percentile_cont(0.99) WITHIN GROUP (ORDER BY b.client_rtt_ms), 0) AS p99, " +
+	"COALESCE(MAX(b.client_rtt_ms), 0) AS max"
+
+// GetLatencyDistribution retrieves the round-trip time distribution of every bid in an auction
+func (r *BidRepository) GetLatencyDistribution(auctionID uuid.UUID) (*domain.BidLatencyDistribution, error) {
+	var distribution domain.BidLatencyDistribution
+
+	result := r.db.Table("bids b").
+		Select(latencyColumns).
+		Joins("JOIN items i ON b.item_id = i.id").
+		Where("i.auction_id = ?", auctionID).
+		Scan(&distribution)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &distribution, nil
+}
+
+// GetBidderLatencyDistributions retrieves the round-trip time distribution of each bidder's bids
+// in an auction, most active bidders first
+func (r *BidRepository) GetBidderLatencyDistributions(auctionID uuid.UUID) ([]domain.BidderBidLatency, error) {
+	var distributions []domain.BidderBidLatency
+
+	result := r.db.Table("bids b").
+		Select("b.bidder_id, COALESCE(bd.display_name, bd.email) AS bidder_name, "+latencyColumns+
+			", AVG(b.clock_offset_ms) AS mean_clock_offset_ms").
+		Joins("JOIN items i ON b.item_id = i.id").
+		Joins("LEFT JOIN bidders bd ON b.bidder_id = bd.id").
+		Where("i.auction_id = ?", auctionID).
+		Group("b.bidder_id, bd.display_name, bd.email").
+		Order("bid_count DESC, b.bidder_id").
+		Scan(&distributions)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return distributions, nil
+}
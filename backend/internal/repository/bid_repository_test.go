@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
+
+func TestBidRepository_GetBidderLatencyDistributions(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewBidRepository(db)
+
+	auctionID := uuid.New()
+	bidderID := uuid.New()
+
+	rows := sqlmock.NewRows([]string{"bidder_id", "bidder_name", "bid_count", "samples", "p50", "p90", "p99", "max", "mean_clock_offset_ms"}).
+		AddRow(bidderID, "Taro", 4, 3, 35.0, 80.0, 98.0, 100.0, -12.5)
+
+	mock.ExpectQuery(`SELECT b.bidder_id, COALESCE\(bd.display_name, bd.email\) AS bidder_name, COUNT\(\*\) AS bid_count, COUNT\(b.client_rtt_ms\) AS samples, .* FROM bids b JOIN items i ON b.item_id = i.id LEFT JOIN bidders bd ON b.bidder_id = bd.id WHERE i.auction_id = \$1 GROUP BY`).
+		WithArgs(auctionID).
+		WillReturnRows(rows)
+
+	distributions, err := repo.GetBidderLatencyDistributions(auctionID)
+
+	assert.NoError(t, err)
+	assert.Len(t, distributions, 1)
+	assert.Equal(t, bidderID, distributions[0].BidderID)
+	assert.Equal(t, int64(4), distributions[0].BidCount)
+	assert.Equal(t, int64(3), distributions[0].Samples)
+	assert.Equal(t, 35.0, distributions[0].P50)
+	assert.Equal(t, -12.5, *distributions[0].MeanClockOffsetMs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBidRepository_FindQueueEntriesByItemID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewBidRepository(db)
+
+	itemID := uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "item_id", "bidder_id", "price", "position", "outcome", "bid_id", "reason", "received_at", "processed_at"}).
+		AddRow(1, itemID, uuid.New(), 2000, 1, "accepted", 7, nil, now, now).
+		AddRow(2, itemID, uuid.New(), 2000, 2, "outbid_at_price", 7, nil, now, now)
+
+	mock.ExpectQuery(`SELECT \* FROM "bid_queue_entries" WHERE item_id = \$1 ORDER BY position ASC NULLS LAST, id ASC`).
+		WithArgs(itemID).
+		WillReturnRows(rows)
+
+	entries, err := repo.FindQueueEntriesByItemID(itemID)
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, domain.BidQueueOutcomeOutbidAtPrice, entries[1].Outcome)
+	assert.Equal(t, int64(7), *entries[1].BidID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,9 +3,27 @@ package repository
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrNegativeAvailablePoints is returned when an adjustment would make available points negative
+	ErrNegativeAvailablePoints = errors.New("adjustment would make available points negative")
+
+	// ErrPointGrantRequestNotFound is returned when a point grant request does not exist
+	ErrPointGrantRequestNotFound = errors.New("point grant request not found")
+	// ErrPointGrantRequestNotPending is returned when a point grant request was already decided
+	ErrPointGrantRequestNotPending = errors.New("point grant request is not pending")
+	// ErrSelfApproval is returned when an admin decides their own point grant request
+	ErrSelfApproval = errors.New("point grant request must be decided by another admin")
+	// ErrPointGrantRequestExpired is returned when the expiry of the requested points passed before approval
+	ErrPointGrantRequestExpired = errors.New("point grant request expiry has passed")
+	// ErrPointGrantBidderNotActive is returned when the bidder is no longer active at approval
+	ErrPointGrantBidderNotActive = errors.New("bidder of the point grant request is not active")
 )
 
 // BidderRepository handles database operations for Bidder entities
@@ -146,24 +164,260 @@ func (r *BidderRepository) GetBidderPoints(bidderID string) (*domain.BidderPoint
 	return &points, nil
 }
 
-// GrantPoints grants points to a bidder (within a transaction)
-func (r *BidderRepository) GrantPoints(bidderID string, points int64, adminID int64) (*domain.BidderWithPoints, *domain.PointHistory, error) {
+// GrantPoints grants points to a bidder (within a transaction) and records them as a point lot
+// expiring at expiresAt (nil for points that never expire)
+func (r *BidderRepository) GrantPoints(bidderID string, points int64, adminID int64, expiresAt *time.Time) (*domain.BidderWithPoints, *domain.PointHistory, error) {
+	var result *domain.BidderWithPoints
+	var history *domain.PointHistory
+
+	// Execute within a transaction
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if history, err = grantPoints(tx, bidderID, points, adminID, expiresAt, nil); err != nil {
+			return err
+		}
+
+		result, err = findBidderWithPoints(tx, bidderID)
+		return err
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, history, nil
+}
+
+// grantPoints adds points to a bidder's available and total points, records the grant history entry
+// and the point lot
+func grantPoints(tx *gorm.DB, bidderID string, points int64, adminID int64, expiresAt *time.Time, reason *string) (*domain.PointHistory, error) {
+	// Get current bidder points, locking them against concurrent bids and point expiry
+	var currentPoints domain.BidderPoints
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("bidder_id = ?", bidderID).
+		First(&currentPoints).Error; err != nil {
+		return nil, fmt.Errorf("failed to get current points: %w", err)
+	}
+
+	// Calculate new values
+	newTotalPoints := currentPoints.TotalPoints + points
+	newAvailablePoints := currentPoints.AvailablePoints + points
+
+	// Update bidder_points
+	if err := tx.Model(&domain.BidderPoints{}).
+		Where("bidder_id = ?", bidderID).
+		Updates(map[string]interface{}{
+			"total_points":     newTotalPoints,
+			"available_points": newAvailablePoints,
+		}).Error; err != nil {
+		return nil, fmt.Errorf("failed to update bidder points: %w", err)
+	}
+
+	// Create point history record
+	history := &domain.PointHistory{
+		BidderID:       bidderID,
+		Amount:         points,
+		Type:           domain.PointHistoryTypeGrant,
+		Reason:         reason,
+		AdminID:        &adminID,
+		BalanceBefore:  currentPoints.AvailablePoints,
+		BalanceAfter:   newAvailablePoints,
+		ReservedBefore: currentPoints.ReservedPoints,
+		ReservedAfter:  currentPoints.ReservedPoints,
+		TotalBefore:    currentPoints.TotalPoints,
+		TotalAfter:     newTotalPoints,
+	}
+
+	if err := tx.Create(history).Error; err != nil {
+		return nil, fmt.Errorf("failed to create point history: %w", err)
+	}
+
+	lot := domain.PointLot{
+		BidderID:  bidderID,
+		HistoryID: &history.ID,
+		Amount:    points,
+		ExpiresAt: expiresAt,
+	}
+	if err := tx.Create(&lot).Error; err != nil {
+		return nil, fmt.Errorf("failed to create point lot: %w", err)
+	}
+
+	return history, nil
+}
+
+// findBidderWithPoints retrieves a bidder with their total points
+func findBidderWithPoints(tx *gorm.DB, bidderID string) (*domain.BidderWithPoints, error) {
 	var result domain.BidderWithPoints
+	if err := tx.Table("bidders b").
+		Select("b.id, b.email, b.display_name, b.status, b.created_at, b.updated_at, COALESCE(bp.total_points, 0) as points").
+		Joins("LEFT JOIN bidder_points bp ON b.id = bp.bidder_id").
+		Where("b.id = ?", bidderID).
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get updated bidder: %w", err)
+	}
+	return &result, nil
+}
+
+// CreatePointGrantRequest records a point grant awaiting approval
+func (r *BidderRepository) CreatePointGrantRequest(request *domain.PointGrantRequest) error {
+	return r.db.Create(request).Error
+}
+
+// FindPointGrantRequests retrieves the point grant requests with the given status (all when empty),
+// oldest first
+func (r *BidderRepository) FindPointGrantRequests(status domain.PointGrantRequestStatus) ([]domain.PointGrantRequest, error) {
+	query := r.db.Model(&domain.PointGrantRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var requests []domain.PointGrantRequest
+	if err := query.Order("created_at ASC, id ASC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// ApprovePointGrantRequest applies a pending point grant request on behalf of the approving admin,
+// who must not be the requester. The request may have waited for approval, so the expiry of the
+// points must still be in the future and the bidder must still be active.
+func (r *BidderRepository) ApprovePointGrantRequest(requestID int64, approverID int64) (*domain.PointGrantRequest, *domain.PointHistory, error) {
+	var request *domain.PointGrantRequest
+	var history *domain.PointHistory
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if request, err = findPendingGrantRequest(tx, requestID, approverID); err != nil {
+			return err
+		}
+
+		if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+			return ErrPointGrantRequestExpired
+		}
+
+		// Lock the bidder so it cannot be suspended or deleted until the grant commits
+		var bidder domain.Bidder
+		if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+			Select("id", "status").
+			Where("id = ?", request.BidderID).
+			First(&bidder).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPointGrantBidderNotActive
+			}
+			return fmt.Errorf("failed to get bidder: %w", err)
+		}
+		if !bidder.IsActive() {
+			return ErrPointGrantBidderNotActive
+		}
+
+		reason := fmt.Sprintf("Approved point grant request #%d", request.ID)
+		if history, err = grantPoints(tx, request.BidderID, request.Points, approverID, request.ExpiresAt, &reason); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(request).Updates(map[string]interface{}{
+			"status":     domain.PointGrantRequestStatusApproved,
+			"decided_by": approverID,
+			"decided_at": now,
+			"history_id": history.ID,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update point grant request: %w", err)
+		}
+
+		request.Status = domain.PointGrantRequestStatusApproved
+		request.DecidedBy = &approverID
+		request.DecidedAt = &now
+		request.HistoryID = &history.ID
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return request, history, nil
+}
+
+// RejectPointGrantRequest rejects a pending point grant request. The rejecting admin must not be
+// the requester.
+func (r *BidderRepository) RejectPointGrantRequest(requestID int64, approverID int64, note *string) (*domain.PointGrantRequest, error) {
+	var request *domain.PointGrantRequest
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if request, err = findPendingGrantRequest(tx, requestID, approverID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(request).Updates(map[string]interface{}{
+			"status":        domain.PointGrantRequestStatusRejected,
+			"decided_by":    approverID,
+			"decided_at":    now,
+			"decision_note": note,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update point grant request: %w", err)
+		}
+
+		request.Status = domain.PointGrantRequestStatusRejected
+		request.DecidedBy = &approverID
+		request.DecidedAt = &now
+		request.DecisionNote = note
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// findPendingGrantRequest locks a point grant request and checks that it is pending and that the
+// deciding admin is not the requester
+func findPendingGrantRequest(tx *gorm.DB, requestID int64, approverID int64) (*domain.PointGrantRequest, error) {
+	var request domain.PointGrantRequest
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", requestID).
+		First(&request).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPointGrantRequestNotFound
+		}
+		return nil, err
+	}
+
+	if request.Status != domain.PointGrantRequestStatusPending {
+		return nil, ErrPointGrantRequestNotPending
+	}
+	if request.RequestedBy == approverID {
+		return nil, ErrSelfApproval
+	}
+	return &request, nil
+}
+
+// AdjustPoints applies a signed manual adjustment to a bidder's available and total points and
+// records it as an adjust history entry with its reason.
+// Returns ErrNegativeAvailablePoints when the adjustment would make available points negative.
+func (r *BidderRepository) AdjustPoints(bidderID string, amount int64, reason string, adminID int64) (*domain.BidderWithPoints, *domain.PointHistory, error) {
+	var result *domain.BidderWithPoints
 	var history domain.PointHistory
 
-	// Execute within a transaction
 	err := r.db.Transaction(func(tx *gorm.DB) error {
-		// Get current bidder points
+		// Lock the bidder's points so concurrent bids cannot spend the deducted points
 		var currentPoints domain.BidderPoints
-		if err := tx.Where("bidder_id = ?", bidderID).First(&currentPoints).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("bidder_id = ?", bidderID).
+			First(&currentPoints).Error; err != nil {
 			return fmt.Errorf("failed to get current points: %w", err)
 		}
 
-		// Calculate new values
-		newTotalPoints := currentPoints.TotalPoints + points
-		newAvailablePoints := currentPoints.AvailablePoints + points
+		newAvailablePoints := currentPoints.AvailablePoints + amount
+		if newAvailablePoints < 0 {
+			return ErrNegativeAvailablePoints
+		}
+		newTotalPoints := currentPoints.TotalPoints + amount
 
-		// Update bidder_points
 		if err := tx.Model(&domain.BidderPoints{}).
 			Where("bidder_id = ?", bidderID).
 			Updates(map[string]interface{}{
@@ -172,12 +426,15 @@ func (r *BidderRepository) GrantPoints(bidderID string, points int64, adminID in
 			}).Error; err != nil {
 			return fmt.Errorf("failed to update bidder points: %w", err)
 		}
+		if err := spendLots(tx, bidderID, -amount); err != nil {
+			return fmt.Errorf("failed to spend point lots: %w", err)
+		}
 
-		// Create point history record
 		history = domain.PointHistory{
 			BidderID:       bidderID,
-			Amount:         points,
-			Type:           domain.PointHistoryTypeGrant,
+			Amount:         amount,
+			Type:           domain.PointHistoryTypeAdjust,
+			Reason:         &reason,
 			AdminID:        &adminID,
 			BalanceBefore:  currentPoints.AvailablePoints,
 			BalanceAfter:   newAvailablePoints,
@@ -191,35 +448,30 @@ func (r *BidderRepository) GrantPoints(bidderID string, points int64, adminID in
 			return fmt.Errorf("failed to create point history: %w", err)
 		}
 
-		// Get updated bidder with points
-		if err := tx.Table("bidders b").
-			Select("b.id, b.email, b.display_name, b.status, b.created_at, b.updated_at, COALESCE(bp.total_points, 0) as points").
-			Joins("LEFT JOIN bidder_points bp ON b.id = bp.bidder_id").
-			Where("b.id = ?", bidderID).
-			Scan(&result).Error; err != nil {
-			return fmt.Errorf("failed to get updated bidder: %w", err)
-		}
-
-		return nil
+		var err error
+		result, err = findBidderWithPoints(tx, bidderID)
+		return err
 	})
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return &result, &history, nil
+	return result, &history, nil
 }
 
-// GetPointHistory retrieves the point history for a bidder with pagination
-func (r *BidderRepository) GetPointHistory(bidderID string, page int, limit int) ([]domain.PointHistoryWithAuction, error) {
+// GetPointHistory retrieves the point history for a bidder matching the filter with pagination
+func (r *BidderRepository) GetPointHistory(bidderID string, filter *domain.PointHistoryFilter, page int, limit int) ([]domain.PointHistoryWithAuction, error) {
 	var results []domain.PointHistoryWithAuction
 
 	offset := (page - 1) * limit
 
 	query := r.db.Table("point_history ph").
-		Select("ph.*, a.title as auction_title").
+		Select("ph.*, a.title as auction_title, i.name as item_name").
 		Joins("LEFT JOIN auctions a ON ph.related_auction_id = a.id").
-		Where("ph.bidder_id = ?", bidderID).
+		Joins("LEFT JOIN items i ON ph.related_item_id = i.id").
+		Where("ph.bidder_id = ?", bidderID)
+	query = filterPointHistory(query, filter).
 		Order("ph.created_at DESC").
 		Limit(limit).
 		Offset(offset)
@@ -232,12 +484,13 @@ func (r *BidderRepository) GetPointHistory(bidderID string, page int, limit int)
 	return results, nil
 }
 
-// CountPointHistory counts the total number of point history entries for a bidder
-func (r *BidderRepository) CountPointHistory(bidderID string) (int64, error) {
+// CountPointHistory counts the point history entries for a bidder matching the filter
+func (r *BidderRepository) CountPointHistory(bidderID string, filter *domain.PointHistoryFilter) (int64, error) {
 	var count int64
 
-	result := r.db.Model(&domain.PointHistory{}).
-		Where("bidder_id = ?", bidderID).
+	query := r.db.Table("point_history ph").
+		Where("ph.bidder_id = ?", bidderID)
+	result := filterPointHistory(query, filter).
 		Count(&count)
 
 	if result.Error != nil {
@@ -247,6 +500,23 @@ func (r *BidderRepository) CountPointHistory(bidderID string) (int64, error) {
 	return count, nil
 }
 
+// filterPointHistory narrows a point_history query (aliased as ph) by the non-empty filter fields
+func filterPointHistory(query *gorm.DB, filter *domain.PointHistoryFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+	if filter.AuctionID != "" {
+		query = query.Where("ph.related_auction_id = ?", filter.AuctionID)
+	}
+	if filter.ItemID != "" {
+		query = query.Where("ph.related_item_id = ?", filter.ItemID)
+	}
+	if filter.Type != "" {
+		query = query.Where("ph.type = ?", filter.Type)
+	}
+	return query
+}
+
 // UpdateBidderStatus updates the status of a bidder account
 func (r *BidderRepository) UpdateBidderStatus(id string, status domain.BidderStatus) error {
 	return r.db.Model(&domain.Bidder{}).
@@ -297,6 +567,15 @@ func (r *BidderRepository) CreateBidderWithPoints(bidder *domain.Bidder, initial
 			if err := tx.Create(pointHistory).Error; err != nil {
 				return fmt.Errorf("failed to create point history: %w", err)
 			}
+
+			lot := &domain.PointLot{
+				BidderID:  bidder.ID,
+				HistoryID: &pointHistory.ID,
+				Amount:    initialPoints,
+			}
+			if err := tx.Create(lot).Error; err != nil {
+				return fmt.Errorf("failed to create point lot: %w", err)
+			}
 		}
 
 		// Build response
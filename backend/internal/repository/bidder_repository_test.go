@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBidderRepository_ApprovePointGrantRequest(t *testing.T) {
+	bidderID := uuid.New().String()
+	requestColumns := []string{"id", "bidder_id", "points", "expires_at", "status", "requested_by"}
+
+	t.Run("Rejects a request whose expiry has passed", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewBidderRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "point_grant_requests" WHERE id = \$1 .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows(requestColumns).
+				AddRow(int64(1), bidderID, int64(200000), time.Now().Add(-time.Minute), "pending", int64(1)))
+		mock.ExpectRollback()
+
+		request, history, err := repo.ApprovePointGrantRequest(1, 2)
+
+		assert.ErrorIs(t, err, ErrPointGrantRequestExpired)
+		assert.Nil(t, request)
+		assert.Nil(t, history)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rejects a request for a bidder who is no longer active", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewBidderRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "point_grant_requests" WHERE id = \$1 .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows(requestColumns).
+				AddRow(int64(1), bidderID, int64(200000), time.Now().Add(time.Hour), "pending", int64(1)))
+		mock.ExpectQuery(`SELECT "id","status" FROM "bidders" WHERE id = \$1 .* FOR SHARE`).
+			WithArgs(bidderID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(bidderID, "suspended"))
+		mock.ExpectRollback()
+
+		request, history, err := repo.ApprovePointGrantRequest(1, 2)
+
+		assert.ErrorIs(t, err, ErrPointGrantBidderNotActive)
+		assert.Nil(t, request)
+		assert.Nil(t, history)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -54,6 +54,20 @@ func (r *DashboardRepository) GetStats() (*domain.DashboardStats, error) {
 		stats.TotalPoints = *totalPoints
 	}
 
+	// Sum granted points and the net amount of manual adjustments
+	var pointTotals struct {
+		Granted  int64
+		Adjusted int64
+	}
+	if err := r.db.Model(&domain.PointHistory{}).
+		Select("COALESCE(SUM(amount) FILTER (WHERE type = ?), 0) AS granted, COALESCE(SUM(amount) FILTER (WHERE type = ?), 0) AS adjusted",
+			domain.PointHistoryTypeGrant, domain.PointHistoryTypeAdjust).
+		Scan(&pointTotals).Error; err != nil {
+		return nil, err
+	}
+	stats.TotalGrantedPoints = pointTotals.Granted
+	stats.TotalAdjustedPoints = pointTotals.Adjusted
+
 	return &stats, nil
 }
 
@@ -100,6 +114,32 @@ func (r *DashboardRepository) GetNewBidders(limit int) ([]domain.NewBidder, erro
 	return results, nil
 }
 
+// GetPendingPointGrants retrieves the oldest point grants awaiting approval
+func (r *DashboardRepository) GetPendingPointGrants(limit int) ([]domain.PendingPointGrant, error) {
+	var results []domain.PendingPointGrant
+
+	query := r.db.Table("point_grant_requests pgr").
+		Select(`pgr.id,
+			pgr.bidder_id,
+			bd.email as bidder_email,
+			bd.display_name as bidder_name,
+			pgr.points,
+			pgr.requested_by,
+			ad.display_name as requested_by_name,
+			pgr.created_at as requested_at`).
+		Joins("JOIN bidders bd ON pgr.bidder_id = bd.id").
+		Joins("JOIN admins ad ON pgr.requested_by = ad.id").
+		Where("pgr.status = ?", domain.PointGrantRequestStatusPending).
+		Order("pgr.created_at ASC").
+		Limit(limit)
+
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 // GetEndedAuctions retrieves recently ended items (limit 5)
 func (r *DashboardRepository) GetEndedAuctions(limit int) ([]domain.EndedAuction, error) {
 	var results []domain.EndedAuction
@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"gorm.io/gorm"
 )
 
 // AdminRepositoryInterface defines the interface for admin repository operations
@@ -27,9 +30,14 @@ type BidderRepositoryInterface interface {
 	FindBiddersWithFilters(req *domain.BidderListRequest) ([]domain.BidderWithPoints, error)
 	CountBiddersWithFilters(req *domain.BidderListRequest) (int64, error)
 	GetBidderPoints(bidderID string) (*domain.BidderPoints, error)
-	GrantPoints(bidderID string, points int64, adminID int64) (*domain.BidderWithPoints, *domain.PointHistory, error)
-	GetPointHistory(bidderID string, page int, limit int) ([]domain.PointHistoryWithAuction, error)
-	CountPointHistory(bidderID string) (int64, error)
+	GrantPoints(bidderID string, points int64, adminID int64, expiresAt *time.Time) (*domain.BidderWithPoints, *domain.PointHistory, error)
+	AdjustPoints(bidderID string, amount int64, reason string, adminID int64) (*domain.BidderWithPoints, *domain.PointHistory, error)
+	CreatePointGrantRequest(request *domain.PointGrantRequest) error
+	FindPointGrantRequests(status domain.PointGrantRequestStatus) ([]domain.PointGrantRequest, error)
+	ApprovePointGrantRequest(requestID int64, approverID int64) (*domain.PointGrantRequest, *domain.PointHistory, error)
+	RejectPointGrantRequest(requestID int64, approverID int64, note *string) (*domain.PointGrantRequest, error)
+	GetPointHistory(bidderID string, filter *domain.PointHistoryFilter, page int, limit int) ([]domain.PointHistoryWithAuction, error)
+	CountPointHistory(bidderID string, filter *domain.PointHistoryFilter) (int64, error)
 	UpdateBidderStatus(id string, status domain.BidderStatus) error
 	CreateBidderWithPoints(bidder *domain.Bidder, initialPoints int64, adminID int64) (*domain.BidderResponse, error)
 	UpdateBidder(id string, req *domain.BidderUpdateRequest, passwordHash *string) error
@@ -52,6 +60,8 @@ type AuctionRepositoryInterface interface {
 
 	// Item operations
 	FindItemByID(itemID string) (*domain.Item, error)
+	FindItemForUpdate(itemID uuid.UUID, tx *gorm.DB) (*domain.Item, error)
+	AdvanceFenceToken(itemID uuid.UUID, token int64, tx *gorm.DB) (bool, error)
 	StartItem(itemID string) (*domain.Item, error)
 	UpdateItemCurrentPrice(itemID string, price int64) error
 	EndItem(itemID string, winnerID uuid.UUID, finalPrice int64) (*domain.Item, error)
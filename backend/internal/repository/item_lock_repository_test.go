@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuctionRepository_FindItemForUpdate(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewAuctionRepository(db)
+
+	t.Run("Success - Item locked", func(t *testing.T) {
+		itemID := uuid.New()
+
+		mock.ExpectQuery(`SELECT \* FROM "items" WHERE id = \$1 ORDER BY "items"."id" LIMIT 1 FOR UPDATE`).
+			WithArgs(itemID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(itemID, "Vase"))
+
+		item, err := repo.FindItemForUpdate(itemID, db)
+
+		assert.NoError(t, err)
+		assert.Equal(t, itemID, item.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found - Returns nil", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "items" WHERE id = \$1 ORDER BY "items"."id" LIMIT 1 FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		item, err := repo.FindItemForUpdate(uuid.New(), db)
+
+		assert.NoError(t, err)
+		assert.Nil(t, item)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPointRepository_GetPointsForUpdate(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewPointRepository(db)
+
+	first := uuid.New().String()
+	second := uuid.New().String()
+
+	mock.ExpectQuery(`SELECT \* FROM "bidder_points" WHERE bidder_id IN \(\$1,\$2\) ORDER BY bidder_id FOR UPDATE`).
+		WithArgs(first, second).
+		WillReturnRows(sqlmock.NewRows([]string{"bidder_id", "total_points", "available_points", "reserved_points"}).
+			AddRow(first, 1000, 800, 200))
+
+	points, err := repo.GetPointsForUpdate([]string{first, second}, db)
+
+	assert.NoError(t, err)
+	assert.Len(t, points, 1)
+	assert.Equal(t, int64(800), points[first].AvailablePoints)
+	assert.Nil(t, points[second])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuctionRepository_AdvanceFenceToken(t *testing.T) {
+	itemID := uuid.New()
+
+	t.Run("Advances the token the operation read", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewAuctionRepository(db)
+
+		mock.ExpectExec(`UPDATE items SET fence_token = fence_token \+ 1 WHERE id = \$1 AND fence_token = \$2`).
+			WithArgs(itemID, int64(9)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		advanced, err := repo.AdvanceFenceToken(itemID, 9, db)
+
+		assert.NoError(t, err)
+		assert.True(t, advanced)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rejects a stale token", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewAuctionRepository(db)
+
+		mock.ExpectExec(`UPDATE items SET fence_token = fence_token \+ 1 WHERE id = \$1 AND fence_token = \$2`).
+			WithArgs(itemID, int64(9)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		advanced, err := repo.AdvanceFenceToken(itemID, 9, db)
+
+		assert.NoError(t, err)
+		assert.False(t, advanced)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PointGrantBatchRepository handles database operations for bulk point grants
+type PointGrantBatchRepository struct {
+	db *gorm.DB
+}
+
+// NewPointGrantBatchRepository creates a new PointGrantBatchRepository instance
+func NewPointGrantBatchRepository(db *gorm.DB) *PointGrantBatchRepository {
+	return &PointGrantBatchRepository{db: db}
+}
+
+// FindBiddersByIdentifiers retrieves the bidders matching the given IDs or email addresses.
+// Identifiers that parse as UUIDs are matched against bidder IDs, the others against emails.
+func (r *PointGrantBatchRepository) FindBiddersByIdentifiers(identifiers []string, tx *gorm.DB) ([]domain.Bidder, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	var ids, emails []string
+	for _, identifier := range identifiers {
+		if _, err := uuid.Parse(identifier); err == nil {
+			ids = append(ids, identifier)
+		} else {
+			emails = append(emails, strings.ToLower(identifier))
+		}
+	}
+
+	var bidders []domain.Bidder
+	if len(ids) == 0 && len(emails) == 0 {
+		return bidders, nil
+	}
+
+	var query *gorm.DB
+	switch {
+	case len(ids) > 0 && len(emails) > 0:
+		query = db.Where("id IN ? OR LOWER(email) IN ?", ids, emails)
+	case len(ids) > 0:
+		query = db.Where("id IN ?", ids)
+	default:
+		query = db.Where("LOWER(email) IN ?", emails)
+	}
+
+	if err := query.Find(&bidders).Error; err != nil {
+		return nil, err
+	}
+	return bidders, nil
+}
+
+// CreateBatch creates a new bulk point grant record
+func (r *PointGrantBatchRepository) CreateBatch(batch *domain.PointGrantBatch, tx *gorm.DB) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	return db.Create(batch).Error
+}
+
+// FindBatchByID retrieves a bulk point grant by ID
+func (r *PointGrantBatchRepository) FindBatchByID(id string) (*domain.PointGrantBatch, error) {
+	var batch domain.PointGrantBatch
+	result := r.db.Where("id = ?", id).First(&batch)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &batch, nil
+}
+
+// FindBatchForUpdate retrieves a bulk point grant by ID and locks its row until the end of the transaction
+func (r *PointGrantBatchRepository) FindBatchForUpdate(id string, tx *gorm.DB) (*domain.PointGrantBatch, error) {
+	var batch domain.PointGrantBatch
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&batch)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &batch, nil
+}
+
+// FindHistoriesByBatchID retrieves the point history entries recorded for a bulk point grant,
+// oldest first
+func (r *PointGrantBatchRepository) FindHistoriesByBatchID(batchID string, tx *gorm.DB) ([]domain.PointHistory, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	var histories []domain.PointHistory
+	if err := db.Where("batch_id = ?", batchID).
+		Order("id ASC").
+		Find(&histories).Error; err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// MarkBatchReversed marks a bulk point grant as reversed
+func (r *PointGrantBatchRepository) MarkBatchReversed(batch *domain.PointGrantBatch, adminID int64, tx *gorm.DB) error {
+	now := time.Now()
+
+	if err := tx.Model(batch).Updates(map[string]interface{}{
+		"status":      domain.PointGrantBatchStatusReversed,
+		"reversed_by": adminID,
+		"reversed_at": now,
+	}).Error; err != nil {
+		return err
+	}
+
+	batch.Status = domain.PointGrantBatchStatusReversed
+	batch.ReversedBy = &adminID
+	batch.ReversedAt = &now
+	return nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointGrantBatchRepository_FindBiddersByIdentifiers(t *testing.T) {
+	t.Run("Matches IDs and emails", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewPointGrantBatchRepository(db)
+
+		bidderID := uuid.New().String()
+
+		mock.ExpectQuery(`SELECT \* FROM "bidders" WHERE id IN \(\$1\) OR LOWER\(email\) IN \(\$2\)`).
+			WithArgs(bidderID, "bidder@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "status"}).
+				AddRow(bidderID, "other@example.com", "active").
+				AddRow(uuid.New().String(), "bidder@example.com", "active"))
+
+		bidders, err := repo.FindBiddersByIdentifiers([]string{bidderID, "Bidder@Example.com"}, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, bidders, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No identifiers", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewPointGrantBatchRepository(db)
+
+		bidders, err := repo.FindBiddersByIdentifiers(nil, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, bidders)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PointRepository handles database operations for BidderPoints entities
@@ -69,6 +71,33 @@ func (r *PointRepository) UpdatePoints(bidderID string, availableDelta, reserved
 	return nil
 }
 
+// SetPoints overwrites a bidder's balances (used to correct ledger discrepancies)
+func (r *PointRepository) SetPoints(bidderID string, total, available, reserved int64, tx *gorm.DB) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	result := db.Exec(`
+		UPDATE bidder_points
+		SET total_points = ?,
+		    available_points = ?,
+		    reserved_points = ?,
+		    updated_at = NOW()
+		WHERE bidder_id = ?
+	`, total, available, reserved, bidderID)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
 // CreatePointHistory creates a new point history record
 func (r *PointRepository) CreatePointHistory(history *domain.PointHistory, tx *gorm.DB) error {
 	db := r.db
@@ -79,6 +108,26 @@ func (r *PointRepository) CreatePointHistory(history *domain.PointHistory, tx *g
 	return db.Create(history).Error
 }
 
+// GetPointsForUpdate retrieves the points of several bidders and locks their rows until the end of
+// the transaction. Rows are locked in bidder ID order so that concurrent transactions cannot deadlock.
+func (r *PointRepository) GetPointsForUpdate(bidderIDs []string, tx *gorm.DB) (map[string]*domain.BidderPoints, error) {
+	var points []domain.BidderPoints
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("bidder_id IN ?", bidderIDs).
+		Order("bidder_id").
+		Find(&points)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	byBidder := make(map[string]*domain.BidderPoints, len(points))
+	for i := range points {
+		byBidder[points[i].BidderID] = &points[i]
+	}
+	return byBidder, nil
+}
+
 // GetCurrentPoints retrieves current points within a transaction (for consistency)
 func (r *PointRepository) GetCurrentPoints(bidderID string, tx *gorm.DB) (*domain.BidderPoints, error) {
 	db := r.db
@@ -98,3 +147,221 @@ func (r *PointRepository) GetCurrentPoints(bidderID string, tx *gorm.DB) (*domai
 
 	return &points, nil
 }
+
+// FindAllPoints retrieves the points of every bidder, ordered by bidder ID
+func (r *PointRepository) FindAllPoints(tx *gorm.DB) ([]domain.BidderPoints, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	var points []domain.BidderPoints
+	if err := db.Order("bidder_id").Find(&points).Error; err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// FindBrokenHistoryLinks retrieves the history entries whose before-balances differ from the
+// after-balances of the same bidder's previous entry
+func (r *PointRepository) FindBrokenHistoryLinks(tx *gorm.DB) ([]domain.PointHistoryLink, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	var links []domain.PointHistoryLink
+	result := db.Raw(`
+		SELECT * FROM (
+			SELECT id, bidder_id, balance_before, reserved_before, total_before,
+			       LAG(balance_after) OVER w AS previous_balance_after,
+			       LAG(reserved_after) OVER w AS previous_reserved_after,
+			       LAG(total_after) OVER w AS previous_total_after
+			FROM point_history
+			WINDOW w AS (PARTITION BY bidder_id ORDER BY id)
+		) chain
+		WHERE previous_balance_after IS NOT NULL
+		  AND (balance_before <> previous_balance_after
+		       OR reserved_before <> previous_reserved_after
+		       OR total_before <> previous_total_after)
+		ORDER BY bidder_id, id
+	`).Scan(&links)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return links, nil
+}
+
+// FindLatestHistories retrieves the latest history entry of every bidder
+func (r *PointRepository) FindLatestHistories(tx *gorm.DB) ([]domain.PointHistory, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	var histories []domain.PointHistory
+	result := db.Raw(`
+		SELECT DISTINCT ON (bidder_id) *
+		FROM point_history
+		ORDER BY bidder_id, id DESC
+	`).Scan(&histories)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return histories, nil
+}
+
+// SumOpenWinningBids returns the sum of the winning bids on items that have not ended, by bidder.
+// These are the points that should be reserved. Restricts to the given bidders when bidderIDs is set.
+func (r *PointRepository) SumOpenWinningBids(bidderIDs []string, tx *gorm.DB) (map[string]int64, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	var rows []struct {
+		BidderID string
+		Total    int64
+	}
+
+	query := db.Table("bids b").
+		Select("b.bidder_id, SUM(b.price) AS total").
+		Joins("JOIN items i ON b.item_id = i.id").
+		Where("b.is_winning = ? AND i.ended_at IS NULL", true)
+	if len(bidderIDs) > 0 {
+		query = query.Where("b.bidder_id IN ?", bidderIDs)
+	}
+
+	if err := query.Group("b.bidder_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	sums := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		sums[row.BidderID] = row.Total
+	}
+	return sums, nil
+}
+
+// CreateLot creates a new point lot for a grant
+func (r *PointRepository) CreateLot(lot *domain.PointLot, tx *gorm.DB) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	return db.Create(lot).Error
+}
+
+// FindOpenLots retrieves a bidder's lots that have not expired or been reversed, oldest first
+func (r *PointRepository) FindOpenLots(bidderID string, tx *gorm.DB) ([]domain.PointLot, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	var lots []domain.PointLot
+	if err := db.Where("bidder_id = ? AND closed_at IS NULL", bidderID).
+		Order("created_at ASC, id ASC").
+		Find(&lots).Error; err != nil {
+		return nil, err
+	}
+	return lots, nil
+}
+
+// FindLotsByHistoryIDs retrieves the lots (open or closed) created by the given grant history
+// entries, keyed by history ID
+func (r *PointRepository) FindLotsByHistoryIDs(historyIDs []int64, tx *gorm.DB) (map[int64]domain.PointLot, error) {
+	lots := map[int64]domain.PointLot{}
+	if len(historyIDs) == 0 {
+		return lots, nil
+	}
+
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	var rows []domain.PointLot
+	if err := db.Where("history_id IN ?", historyIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, lot := range rows {
+		lots[*lot.HistoryID] = lot
+	}
+	return lots, nil
+}
+
+// SpendLots takes spent points from a bidder's open lots, oldest first. The caller must hold the
+// bidder's points row lock. Points beyond the unused points of the open lots were credited
+// without a lot and are not recorded.
+func (r *PointRepository) SpendLots(bidderID string, amount int64, tx *gorm.DB) error {
+	return spendLots(tx, bidderID, amount)
+}
+
+func spendLots(tx *gorm.DB, bidderID string, amount int64) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	var lots []domain.PointLot
+	if err := tx.Where("bidder_id = ? AND closed_at IS NULL AND spent_amount < amount", bidderID).
+		Order("created_at ASC, id ASC").
+		Find(&lots).Error; err != nil {
+		return err
+	}
+
+	for _, lot := range lots {
+		if amount == 0 {
+			break
+		}
+		spent := min(lot.Amount-lot.SpentAmount, amount)
+		if err := tx.Model(&domain.PointLot{}).
+			Where("id = ?", lot.ID).
+			Update("spent_amount", lot.SpentAmount+spent).Error; err != nil {
+			return err
+		}
+		amount -= spent
+	}
+	return nil
+}
+
+// FindBiddersWithDueLots returns the bidders having open lots that expire at or before the given time
+func (r *PointRepository) FindBiddersWithDueLots(now time.Time) ([]string, error) {
+	var bidderIDs []string
+	if err := r.db.Model(&domain.PointLot{}).
+		Distinct("bidder_id").
+		Where("closed_at IS NULL AND expires_at <= ?", now).
+		Order("bidder_id").
+		Pluck("bidder_id", &bidderIDs).Error; err != nil {
+		return nil, err
+	}
+	return bidderIDs, nil
+}
+
+// CloseLot closes a lot, recording how many of its points expired
+func (r *PointRepository) CloseLot(lotID int64, expiredAmount int64, closedAt time.Time, tx *gorm.DB) error {
+	return tx.Model(&domain.PointLot{}).
+		Where("id = ?", lotID).
+		Updates(map[string]interface{}{
+			"expired_amount": expiredAmount,
+			"closed_at":      closedAt,
+		}).Error
+}
+
+// CloseLotsByHistoryIDs closes the open lots created by the given grant history entries
+// (used when the grants are reversed)
+func (r *PointRepository) CloseLotsByHistoryIDs(historyIDs []int64, closedAt time.Time, tx *gorm.DB) error {
+	if len(historyIDs) == 0 {
+		return nil
+	}
+
+	return tx.Model(&domain.PointLot{}).
+		Where("history_id IN ? AND closed_at IS NULL", historyIDs).
+		Update("closed_at", closedAt).Error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointRepository_SumOpenWinningBids(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewPointRepository(db)
+
+	bidderID := uuid.New().String()
+
+	mock.ExpectQuery(`SELECT b.bidder_id, SUM\(b.price\) AS total FROM bids b JOIN items i ON b.item_id = i.id WHERE \(b.is_winning = \$1 AND i.ended_at IS NULL\) AND b.bidder_id IN \(\$2\) GROUP BY "b"."bidder_id"`).
+		WithArgs(true, bidderID).
+		WillReturnRows(sqlmock.NewRows([]string{"bidder_id", "total"}).AddRow(bidderID, 3000))
+
+	sums, err := repo.SumOpenWinningBids([]string{bidderID}, db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000), sums[bidderID])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"math"
 	"time"
@@ -10,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/events"
 	"github.com/tsutsumi389/real-time-auction/internal/repository"
 	"gorm.io/gorm"
 )
@@ -154,6 +154,12 @@ func (s *AuctionService) StartAuction(id string) (*domain.AuctionWithItemCount,
 	if err != nil {
 		return nil, err
 	}
+	s.refreshLiveState(id)
+	s.publishEvent(events.AuctionStarted{
+		AuctionID: id,
+		Status:    string(domain.AuctionStatusActive),
+		StartedAt: time.Now(),
+	})
 
 	// Return updated auction with item count
 	return &domain.AuctionWithItemCount{
@@ -195,6 +201,12 @@ func (s *AuctionService) EndAuction(id string) (*domain.AuctionWithItemCount, er
 	if err != nil {
 		return nil, err
 	}
+	s.refreshLiveState(id)
+	s.publishEvent(events.AuctionEnded{
+		AuctionID: id,
+		Status:    string(domain.AuctionStatusEnded),
+		EndedAt:   time.Now(),
+	})
 
 	// TODO: Set ended_at for all items and finalize winners
 	// This will be implemented when we add item-level operations
@@ -239,6 +251,12 @@ func (s *AuctionService) CancelAuction(id string) (*domain.AuctionWithItemCount,
 	if err != nil {
 		return nil, err
 	}
+	s.refreshLiveState(id)
+	s.publishEvent(events.AuctionCancelled{
+		AuctionID:   id,
+		Status:      string(domain.AuctionStatusCancelled),
+		CancelledAt: time.Now(),
+	})
 
 	// TODO: Invalidate bids and refund reserved points
 	// This will be implemented when we add bid and point operations
@@ -396,26 +414,24 @@ func (s *AuctionService) StartItem(itemID string) (*domain.StartItemResponse, er
 		return nil, ErrItemNotFound
 	}
 
-	// Publish WebSocket event to Redis Pub/Sub
-	if s.redisClient != nil {
-		event := map[string]interface{}{
-			"type": "item:started",
-			"item": map[string]interface{}{
-				"id":            item.ID.String(),
-				"auction_id":    item.AuctionID.String(),
-				"name":          item.Name,
-				"current_price": item.CurrentPrice,
-				"started_at":    item.StartedAt,
-				"status":        "active",
-			},
-		}
-		eventJSON, err := json.Marshal(event)
-		if err == nil {
-			channel := "auction:item_started"
-			_ = s.redisClient.Publish(s.ctx, channel, eventJSON).Err()
-		}
+	// Refresh the live-state snapshot before announcing the change
+	if item.AuctionID != nil {
+		s.refreshLiveState(item.AuctionID.String())
 	}
 
+	// Publish WebSocket event to Redis Pub/Sub
+	s.publishEvent(events.ItemStarted{
+		AuctionID: item.AuctionID.String(),
+		Item: events.ItemSummary{
+			ID:           item.ID.String(),
+			AuctionID:    item.AuctionID.String(),
+			Name:         item.Name,
+			CurrentPrice: int64OrZero(item.CurrentPrice),
+			StartedAt:    timeOrNow(item.StartedAt),
+			Status:       string(domain.ItemStatusActive),
+		},
+	})
+
 	// Build response
 	return &domain.StartItemResponse{
 		ItemID:       item.ID,
@@ -425,62 +441,77 @@ func (s *AuctionService) StartItem(itemID string) (*domain.StartItemResponse, er
 	}, nil
 }
 
-// OpenPrice opens a new price for an item
+// OpenPrice opens a new price for an item.
+// The item row is locked for the whole operation so that it is serialized with bids and item end.
 func (s *AuctionService) OpenPrice(itemID string, newPrice int64, adminID int64) (*domain.OpenPriceResponse, error) {
-	// Find the item
-	item, err := s.auctionRepo.FindItemByID(itemID)
+	id, err := uuid.Parse(itemID)
 	if err != nil {
 		return nil, err
 	}
-	if item == nil {
-		return nil, ErrItemNotFound
-	}
-
-	// Check if item has been started
-	if item.StartedAt == nil {
-		return nil, ErrItemNotStarted
-	}
-
-	// Check if item has already ended
-	if item.EndedAt != nil {
-		return nil, ErrItemAlreadyEnded
-	}
-
-	// Check if new price is higher than current price
-	if item.CurrentPrice != nil && newPrice <= *item.CurrentPrice {
-		return nil, ErrPriceTooLow
-	}
-
-	// Get previous price
-	previousPrice := int64(0)
-	if item.CurrentPrice != nil {
-		previousPrice = *item.CurrentPrice
-	}
 
 	// Execute transaction to update price and release reserved points
+	var item *domain.Item
+	var previousPrice int64
 	var priceHistory *domain.PriceHistory
 	var hadBid bool
+	var releasedBid *domain.Bid
+	var releasedPoints *domain.BidderPoints
 
 	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the item (waits for in-flight bids on this item to finish)
+		item, err = s.auctionRepo.FindItemForUpdate(id, tx)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return ErrItemNotFound
+		}
+
+		// Check if item has been started
+		if item.StartedAt == nil {
+			return ErrItemNotStarted
+		}
+
+		// Check if item has already ended
+		if item.EndedAt != nil {
+			return ErrItemAlreadyEnded
+		}
+
+		// Check if new price is higher than current price
+		if item.CurrentPrice != nil && newPrice <= *item.CurrentPrice {
+			return ErrPriceTooLow
+		}
+
+		// Get previous price
+		if item.CurrentPrice != nil {
+			previousPrice = *item.CurrentPrice
+		}
+
 		// Check if there was a bid at the previous price
 		var winningBid *domain.Bid
 		if previousPrice > 0 {
-			winningBid, err = s.bidRepo.FindWinningBidByItemID(item.ID)
+			winningBid, err = s.bidRepo.FindWinningBidByItemID(item.ID, tx)
 			if err != nil {
 				return fmt.Errorf("failed to find winning bid: %w", err)
 			}
 			hadBid = winningBid != nil && winningBid.Price == previousPrice
 		}
 
+		// Reject the writes if another operation has written to the item since it was read
+		if err := checkFenceToken(s.auctionRepo, item.ID, item.FenceToken, tx); err != nil {
+			return err
+		}
+
 		// If there is a winning bid, release the reserved points (price has changed, old bid is invalid)
 		if winningBid != nil {
 			bidderIDStr := winningBid.BidderID.String()
 
-			// Get bidder's current points
-			currentPoints, err := s.pointRepo.GetCurrentPoints(bidderIDStr, tx)
+			// Lock bidder's current points (a concurrent bid on another item may update them)
+			points, err := s.pointRepo.GetPointsForUpdate([]string{bidderIDStr}, tx)
 			if err != nil {
 				return fmt.Errorf("failed to get current points: %w", err)
 			}
+			currentPoints := points[bidderIDStr]
 			if currentPoints == nil {
 				return ErrPointsNotFound
 			}
@@ -492,16 +523,18 @@ func (s *AuctionService) OpenPrice(itemID string, newPrice int64, adminID int64)
 
 			// Create point history for release
 			releaseHistory := &domain.PointHistory{
-				BidderID:       bidderIDStr,
-				Amount:         winningBid.Price,
-				Type:           domain.PointHistoryTypeRelease,
-				RelatedBidID:   &winningBid.ID,
-				BalanceBefore:  currentPoints.AvailablePoints,
-				BalanceAfter:   currentPoints.AvailablePoints + winningBid.Price,
-				ReservedBefore: currentPoints.ReservedPoints,
-				ReservedAfter:  currentPoints.ReservedPoints - winningBid.Price,
-				TotalBefore:    currentPoints.TotalPoints,
-				TotalAfter:     currentPoints.TotalPoints,
+				BidderID:         bidderIDStr,
+				Amount:           winningBid.Price,
+				Type:             domain.PointHistoryTypeRelease,
+				RelatedAuctionID: item.AuctionID,
+				RelatedItemID:    &item.ID,
+				RelatedBidID:     &winningBid.ID,
+				BalanceBefore:    currentPoints.AvailablePoints,
+				BalanceAfter:     currentPoints.AvailablePoints + winningBid.Price,
+				ReservedBefore:   currentPoints.ReservedPoints,
+				ReservedAfter:    currentPoints.ReservedPoints - winningBid.Price,
+				TotalBefore:      currentPoints.TotalPoints,
+				TotalAfter:       currentPoints.TotalPoints,
 			}
 			if err := s.pointRepo.CreatePointHistory(releaseHistory, tx); err != nil {
 				return fmt.Errorf("failed to create release history: %w", err)
@@ -511,6 +544,13 @@ func (s *AuctionService) OpenPrice(itemID string, newPrice int64, adminID int64)
 			if err := s.bidRepo.UpdateBidWinningStatus(item.ID, 0, tx); err != nil {
 				return fmt.Errorf("failed to update winning status: %w", err)
 			}
+
+			// Get released bidder's updated points (for notification)
+			releasedBid = winningBid
+			releasedPoints, err = s.pointRepo.GetCurrentPoints(bidderIDStr, tx)
+			if err != nil {
+				return fmt.Errorf("failed to get updated points: %w", err)
+			}
 		}
 
 		// Update item current price (using tx for transaction consistency)
@@ -538,26 +578,29 @@ func (s *AuctionService) OpenPrice(itemID string, newPrice int64, adminID int64)
 		return nil, err
 	}
 
+	// Refresh the live-state snapshot before announcing the change
+	if item.AuctionID != nil {
+		s.refreshLiveState(item.AuctionID.String())
+	}
+
 	// Publish WebSocket event to Redis Pub/Sub
-	if s.redisClient != nil {
-		event := map[string]interface{}{
-			"type":    "price:opened",
-			"item_id": item.ID.String(),
-			"price":   newPrice,
-			"price_history": map[string]interface{}{
-				"id":           priceHistory.ID,
-				"item_id":      priceHistory.ItemID.String(),
-				"price":        priceHistory.Price,
-				"disclosed_by": priceHistory.DisclosedBy,
-				"had_bid":      priceHistory.HadBid,
-				"disclosed_at": priceHistory.DisclosedAt,
-			},
-		}
-		eventJSON, err := json.Marshal(event)
-		if err == nil {
-			channel := "auction:price_open"
-			_ = s.redisClient.Publish(s.ctx, channel, eventJSON).Err()
-		}
+	s.publishEvent(events.PriceOpened{
+		AuctionID: item.AuctionID.String(),
+		ItemID:    item.ID.String(),
+		Price:     newPrice,
+		PriceHistory: events.PriceDisclosure{
+			ID:          priceHistory.ID,
+			ItemID:      priceHistory.ItemID.String(),
+			Price:       priceHistory.Price,
+			DisclosedBy: priceHistory.DisclosedBy,
+			HadBid:      priceHistory.HadBid,
+			DisclosedAt: priceHistory.DisclosedAt,
+		},
+	})
+
+	// Notify the bidder whose reserved points were released
+	if releasedBid != nil && releasedPoints != nil {
+		s.notifyBidder(releasedBid.BidderID.String(), pointsUpdatedEvent(releasedPoints))
 	}
 
 	// Build response
@@ -570,77 +613,67 @@ func (s *AuctionService) OpenPrice(itemID string, newPrice int64, adminID int64)
 	}, nil
 }
 
-// EndItem ends an item auction and processes point transactions
+// EndItem ends an item auction and processes point transactions.
+// The item row is locked for the whole operation so that no bid can be accepted after the
+// winning bid has been read.
 func (s *AuctionService) EndItem(itemID string) (*domain.EndItemResponse, error) {
-	// Find the item
-	item, err := s.auctionRepo.FindItemByID(itemID)
-	if err != nil {
-		return nil, err
-	}
-	if item == nil {
-		return nil, ErrItemNotFound
-	}
-
-	// Check if item has been started
-	if item.StartedAt == nil {
-		return nil, ErrItemNotStarted
-	}
-
-	// Check if item has already ended
-	if item.EndedAt != nil {
-		return nil, ErrItemAlreadyEnded
-	}
-
-	// Find the winning bid (may be nil if no bids)
-	winningBid, err := s.auctionRepo.FindWinningBidByItemID(itemID)
+	id, err := uuid.Parse(itemID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get winner name from winning bid
-	var winnerName *string
-	if winningBid != nil {
-		// Get the winning bid with bidder info
-		allBids, err := s.auctionRepo.FindBidsByItemID(itemID, 1, 0)
-		if err == nil && len(allBids) > 0 && allBids[0].IsWinning {
-			winnerName = &allBids[0].BidderName
-		}
-	}
-
 	// Variables to store results
 	var endedItem *domain.Item
+	var winningBid *domain.Bid
+	var winnerPoints *domain.BidderPoints
 	var finalPrice int64
-	if winningBid != nil {
-		finalPrice = winningBid.Price
-	}
 
 	// Execute everything in a single transaction
 	err = s.db.Transaction(func(tx *gorm.DB) error {
-		// End the item (update status, set winner, end time)
-		id, err := uuid.Parse(itemID)
+		// Lock the item (waits for in-flight bids on this item to finish)
+		itemToEnd, err := s.auctionRepo.FindItemForUpdate(id, tx)
 		if err != nil {
 			return err
 		}
+		if itemToEnd == nil {
+			return ErrItemNotFound
+		}
+
+		// Check if item has been started
+		if itemToEnd.StartedAt == nil {
+			return ErrItemNotStarted
+		}
+
+		// Check if item has already ended
+		if itemToEnd.EndedAt != nil {
+			return ErrItemAlreadyEnded
+		}
 
-		var itemToEnd domain.Item
-		if err := tx.First(&itemToEnd, "id = ?", id).Error; err != nil {
+		// Find the winning bid (may be nil if no bids)
+		winningBid, err = s.bidRepo.FindWinningBidByItemID(id, tx)
+		if err != nil {
+			return fmt.Errorf("failed to find winning bid: %w", err)
+		}
+
+		// Reject the writes if another operation has written to the item since it was read
+		if err := checkFenceToken(s.auctionRepo, id, itemToEnd.FenceToken, tx); err != nil {
 			return err
 		}
 
+		// End the item (update status, set winner, end time)
 		now := time.Now()
 		if winningBid != nil {
+			finalPrice = winningBid.Price
 			itemToEnd.WinnerID = &winningBid.BidderID
-		}
-		if finalPrice > 0 {
 			itemToEnd.CurrentPrice = &finalPrice
 		}
 		itemToEnd.EndedAt = &now
 
-		if err := tx.Save(&itemToEnd).Error; err != nil {
+		if err := tx.Save(itemToEnd).Error; err != nil {
 			return err
 		}
 
-		endedItem = &itemToEnd
+		endedItem = itemToEnd
 
 		// Process winner's points only
 		// Note: In our bidding system, when a new bid is placed, the previous bidder's 
@@ -649,11 +682,12 @@ func (s *AuctionService) EndItem(itemID string) (*domain.EndItemResponse, error)
 		if winningBid != nil {
 			winnerIDStr := winningBid.BidderID.String()
 
-			// Get current points
-			currentPoints, err := s.pointRepo.GetCurrentPoints(winnerIDStr, tx)
+			// Lock current points (a concurrent bid on another item may update them)
+			points, err := s.pointRepo.GetPointsForUpdate([]string{winnerIDStr}, tx)
 			if err != nil {
 				return fmt.Errorf("failed to get points for winner %s: %w", winnerIDStr, err)
 			}
+			currentPoints := points[winnerIDStr]
 			if currentPoints == nil {
 				return fmt.Errorf("points not found for winner %s", winnerIDStr)
 			}
@@ -663,24 +697,35 @@ func (s *AuctionService) EndItem(itemID string) (*domain.EndItemResponse, error)
 			if err != nil {
 				return fmt.Errorf("failed to consume points for winner %s: %w", winnerIDStr, err)
 			}
+			if err := s.pointRepo.SpendLots(winnerIDStr, winningBid.Price, tx); err != nil {
+				return fmt.Errorf("failed to spend point lots for winner %s: %w", winnerIDStr, err)
+			}
 
 			// Create point history record for consumption
 			history := &domain.PointHistory{
-				BidderID:       currentPoints.BidderID,
-				Amount:         winningBid.Price,
-				Type:           domain.PointHistoryTypeConsume,
-				Reason:         stringPtr(fmt.Sprintf("Won item %s at price %d", itemID, winningBid.Price)),
-				RelatedBidID:   &winningBid.ID,
-				BalanceBefore:  currentPoints.AvailablePoints,
-				BalanceAfter:   currentPoints.AvailablePoints,
-				ReservedBefore: currentPoints.ReservedPoints,
-				ReservedAfter:  currentPoints.ReservedPoints - winningBid.Price,
-				TotalBefore:    currentPoints.TotalPoints,
-				TotalAfter:     currentPoints.TotalPoints - winningBid.Price,
+				BidderID:         currentPoints.BidderID,
+				Amount:           winningBid.Price,
+				Type:             domain.PointHistoryTypeConsume,
+				Reason:           stringPtr(fmt.Sprintf("Won item %s at price %d", itemID, winningBid.Price)),
+				RelatedAuctionID: itemToEnd.AuctionID,
+				RelatedItemID:    &itemToEnd.ID,
+				RelatedBidID:     &winningBid.ID,
+				BalanceBefore:    currentPoints.AvailablePoints,
+				BalanceAfter:     currentPoints.AvailablePoints,
+				ReservedBefore:   currentPoints.ReservedPoints,
+				ReservedAfter:    currentPoints.ReservedPoints - winningBid.Price,
+				TotalBefore:      currentPoints.TotalPoints,
+				TotalAfter:       currentPoints.TotalPoints - winningBid.Price,
 			}
 			if err := s.pointRepo.CreatePointHistory(history, tx); err != nil {
 				return fmt.Errorf("failed to create point history for winner %s: %w", winnerIDStr, err)
 			}
+
+			// Get winner's updated points (for notification)
+			winnerPoints, err = s.pointRepo.GetCurrentPoints(winnerIDStr, tx)
+			if err != nil {
+				return fmt.Errorf("failed to get updated points for winner %s: %w", winnerIDStr, err)
+			}
 		}
 
 		return nil
@@ -690,28 +735,49 @@ func (s *AuctionService) EndItem(itemID string) (*domain.EndItemResponse, error)
 		return nil, err
 	}
 
+	// Get winner name from the winning bid
+	var winnerName *string
+	if winningBid != nil {
+		allBids, err := s.auctionRepo.FindBidsByItemID(itemID, 1, 0)
+		if err == nil && len(allBids) > 0 && allBids[0].IsWinning {
+			winnerName = &allBids[0].BidderName
+		}
+	}
+
+	// Refresh the live-state snapshot before announcing the change
+	if endedItem.AuctionID != nil {
+		s.refreshLiveState(endedItem.AuctionID.String())
+	}
+
 	// Publish WebSocket event to Redis Pub/Sub
 	if s.redisClient != nil {
-		event := map[string]interface{}{
-			"type":    "item:ended",
-			"item_id": endedItem.ID.String(),
-			"item": map[string]interface{}{
-				"id":          endedItem.ID.String(),
-				"auction_id":  endedItem.AuctionID.String(),
-				"name":        endedItem.Name,
-				"final_price": finalPrice,
-				"winner_id":   endedItem.WinnerID,
-				"ended_at":    endedItem.EndedAt,
-				"status":      "ended",
-			},
-		}
-		eventJSON, err := json.Marshal(event)
-		if err == nil {
-			channel := "auction:item_ended"
-			_ = s.redisClient.Publish(s.ctx, channel, eventJSON).Err()
+		result := events.ItemResult{
+			ID:         endedItem.ID.String(),
+			AuctionID:  endedItem.AuctionID.String(),
+			Name:       endedItem.Name,
+			FinalPrice: finalPrice,
+			EndedAt:    timeOrNow(endedItem.EndedAt),
+			Status:     string(domain.ItemStatusEnded),
+		}
+		if endedItem.WinnerID != nil {
+			result.WinnerID = endedItem.WinnerID.String()
+		}
+		if winningBid != nil {
+			paddleNumber, err := AssignPaddleNumber(s.ctx, s.redisClient, endedItem.AuctionID.String(), winningBid.BidderID.String())
+			if err == nil {
+				result.WinnerPaddleNumber = paddleNumber
+			}
 		}
+		s.publishEvent(events.ItemEnded{
+			AuctionID: endedItem.AuctionID.String(),
+			ItemID:    endedItem.ID.String(),
+			Item:      result,
+		})
 	}
 
+	// Notify winner and losing bidders
+	s.notifyItemResult(endedItem, winningBid, winnerPoints, finalPrice)
+
 	// Build response
 	return &domain.EndItemResponse{
 		ItemID:     endedItem.ID,
@@ -784,6 +850,71 @@ func (s *AuctionService) GetParticipants(auctionID string) (*domain.Participants
 	}, nil
 }
 
+// GetBidLatency retrieves the connection latency recorded with the bids of an auction,
+// so that first-come disputes can be settled with the server receive times and round-trip times
+func (s *AuctionService) GetBidLatency(auctionID string) (*domain.AuctionBidLatencyResponse, error) {
+	auctionUUID, err := uuid.Parse(auctionID)
+	if err != nil {
+		return nil, ErrAuctionNotFound
+	}
+
+	auction, err := s.auctionRepo.FindByID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if auction == nil {
+		return nil, ErrAuctionNotFound
+	}
+
+	overall, err := s.bidRepo.GetLatencyDistribution(auctionUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latency distribution: %w", err)
+	}
+
+	bidders, err := s.bidRepo.GetBidderLatencyDistributions(auctionUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bidder latency distributions: %w", err)
+	}
+	if bidders == nil {
+		bidders = []domain.BidderBidLatency{}
+	}
+
+	return &domain.AuctionBidLatencyResponse{
+		AuctionID: auctionUUID,
+		Overall:   *overall,
+		Bidders:   bidders,
+	}, nil
+}
+
+// GetBidQueue retrieves the audit records of the bid requests queued on an item, in arrival order
+func (s *AuctionService) GetBidQueue(itemID string) (*domain.BidQueueResponse, error) {
+	itemUUID, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, ErrItemNotFound
+	}
+
+	item, err := s.auctionRepo.FindItemByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrItemNotFound
+	}
+
+	entries, err := s.bidRepo.FindQueueEntriesByItemID(itemUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bid queue entries: %w", err)
+	}
+	if entries == nil {
+		entries = []domain.BidQueueEntry{}
+	}
+
+	return &domain.BidQueueResponse{
+		ItemID:  itemUUID,
+		Entries: entries,
+	}, nil
+}
+
 // CancelAuctionWithReason cancels an auction with a reason
 func (s *AuctionService) CancelAuctionWithReason(auctionID string, reason string) (*domain.CancelAuctionResponse, error) {
 	// Find auction
@@ -805,6 +936,18 @@ func (s *AuctionService) CancelAuctionWithReason(auctionID string, reason string
 	if err != nil {
 		return nil, err
 	}
+	s.refreshLiveState(auctionID)
+	s.publishEvent(events.AuctionCancelled{
+		AuctionID:   auctionID,
+		Status:      string(domain.AuctionStatusCancelled),
+		Reason:      reason,
+		CancelledAt: time.Now(),
+	})
+
+	// Notify refunded bidders of their new balances
+	for _, bidderID := range response.RefundedBidderIDs {
+		s.notifyPointsUpdated(bidderID.String())
+	}
 
 	return response, nil
 }
@@ -937,6 +1080,72 @@ func (s *AuctionService) ReorderItems(auctionID string, req *domain.ReorderItems
 	return s.auctionRepo.ReorderItems(auctionID, req.ItemIDs)
 }
 
+// publishEvent publishes an auction room event, logging failures without failing the operation
+func (s *AuctionService) publishEvent(payload events.Payload) {
+	if err := events.Publish(s.ctx, s.redisClient, payload); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// notifyBidder publishes a targeted notification, logging failures without failing the operation
+func (s *AuctionService) notifyBidder(bidderID string, payload events.Payload) {
+	if err := publishBidderNotification(s.ctx, s.redisClient, bidderID, payload); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// notifyPointsUpdated sends the bidder's current balance as a points:updated notification
+func (s *AuctionService) notifyPointsUpdated(bidderID string) {
+	if s.pointRepo == nil {
+		return
+	}
+
+	points, err := s.pointRepo.FindPointsByBidderID(bidderID)
+	if err != nil || points == nil {
+		return
+	}
+
+	s.notifyBidder(bidderID, pointsUpdatedEvent(points))
+}
+
+// notifyItemResult sends item:won to the winner and item:lost to every other bidder on the item
+func (s *AuctionService) notifyItemResult(item *domain.Item, winningBid *domain.Bid, winnerPoints *domain.BidderPoints, finalPrice int64) {
+	if s.redisClient == nil {
+		return
+	}
+
+	result := events.ItemOutcome{
+		AuctionID:  item.AuctionID.String(),
+		ItemID:     item.ID.String(),
+		ItemName:   item.Name,
+		FinalPrice: finalPrice,
+	}
+
+	if winningBid != nil {
+		s.notifyBidder(winningBid.BidderID.String(), events.ItemWon(result))
+		if winnerPoints != nil {
+			s.notifyBidder(winningBid.BidderID.String(), pointsUpdatedEvent(winnerPoints))
+		}
+	}
+
+	if s.bidRepo == nil {
+		return
+	}
+
+	bidderIDs, err := s.bidRepo.FindBidderIDsByItemID(item.ID)
+	if err != nil {
+		fmt.Printf("Warning: failed to find bidders for item %s: %v\n", item.ID, err)
+		return
+	}
+
+	for _, bidderID := range bidderIDs {
+		if winningBid != nil && bidderID == winningBid.BidderID {
+			continue
+		}
+		s.notifyBidder(bidderID.String(), events.ItemLost(result))
+	}
+}
+
 // stringPtr is a helper function to create a string pointer
 func stringPtr(s string) *string {
 	return &s
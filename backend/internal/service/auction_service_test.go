@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"gorm.io/gorm"
 )
 
 // MockAuctionRepository is a mock implementation of AuctionRepository
@@ -102,6 +103,19 @@ func (m *MockAuctionRepository) UpdateItemCurrentPrice(itemID string, price int6
 	return args.Error(0)
 }
 
+func (m *MockAuctionRepository) FindItemForUpdate(itemID uuid.UUID, tx *gorm.DB) (*domain.Item, error) {
+	args := m.Called(itemID, tx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Item), args.Error(1)
+}
+
+func (m *MockAuctionRepository) AdvanceFenceToken(itemID uuid.UUID, token int64, tx *gorm.DB) (bool, error) {
+	args := m.Called(itemID, token, tx)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockAuctionRepository) EndItem(itemID string, winnerID uuid.UUID, finalPrice int64) (*domain.Item, error) {
 	args := m.Called(itemID, winnerID, finalPrice)
 	if args.Get(0) == nil {
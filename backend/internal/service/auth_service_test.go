@@ -65,6 +65,19 @@ func (m *MockAdminRepository) UpdateAdminStatus(id int64, status domain.AdminSta
 	return args.Error(0)
 }
 
+func (m *MockAdminRepository) CountActiveSystemAdmins() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAdminRepository) FindByEmailExcludeID(email string, excludeID int64) (*domain.Admin, error) {
+	args := m.Called(email, excludeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Admin), args.Error(1)
+}
+
 // MockJWTService is a mock implementation of JWTService
 type MockJWTService struct {
 	mock.Mock
@@ -75,6 +88,11 @@ func (m *MockJWTService) GenerateTokenForAdmin(admin *domain.Admin) (string, err
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockJWTService) GenerateTokenForBidder(bidder *domain.Bidder) (string, error) {
+	args := m.Called(bidder)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockJWTService) ValidateToken(tokenString string) (*domain.JWTClaims, error) {
 	args := m.Called(tokenString)
 	if args.Get(0) == nil {
@@ -87,7 +105,7 @@ func TestAuthService_LoginAdmin(t *testing.T) {
 	t.Run("Success - Valid credentials", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
 		mockJWTService := new(MockJWTService)
-		authService := NewAuthService(mockAdminRepo, mockJWTService)
+		authService := NewAuthService(mockAdminRepo, new(MockBidderRepository), mockJWTService)
 
 		email := "admin@example.com"
 		password := "password123"
@@ -127,7 +145,7 @@ func TestAuthService_LoginAdmin(t *testing.T) {
 	t.Run("Error - Invalid email", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
 		mockJWTService := new(MockJWTService)
-		authService := NewAuthService(mockAdminRepo, mockJWTService)
+		authService := NewAuthService(mockAdminRepo, new(MockBidderRepository), mockJWTService)
 
 		email := "notfound@example.com"
 		password := "password123"
@@ -146,7 +164,7 @@ func TestAuthService_LoginAdmin(t *testing.T) {
 	t.Run("Error - Invalid password", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
 		mockJWTService := new(MockJWTService)
-		authService := NewAuthService(mockAdminRepo, mockJWTService)
+		authService := NewAuthService(mockAdminRepo, new(MockBidderRepository), mockJWTService)
 
 		email := "admin@example.com"
 		password := "wrongpassword"
@@ -175,7 +193,7 @@ func TestAuthService_LoginAdmin(t *testing.T) {
 	t.Run("Error - Account suspended", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
 		mockJWTService := new(MockJWTService)
-		authService := NewAuthService(mockAdminRepo, mockJWTService)
+		authService := NewAuthService(mockAdminRepo, new(MockBidderRepository), mockJWTService)
 
 		email := "admin@example.com"
 		password := "password123"
@@ -204,7 +222,7 @@ func TestAuthService_LoginAdmin(t *testing.T) {
 	t.Run("Error - Account deleted", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
 		mockJWTService := new(MockJWTService)
-		authService := NewAuthService(mockAdminRepo, mockJWTService)
+		authService := NewAuthService(mockAdminRepo, new(MockBidderRepository), mockJWTService)
 
 		email := "admin@example.com"
 		password := "password123"
@@ -233,7 +251,7 @@ func TestAuthService_LoginAdmin(t *testing.T) {
 	t.Run("Error - Repository error", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
 		mockJWTService := new(MockJWTService)
-		authService := NewAuthService(mockAdminRepo, mockJWTService)
+		authService := NewAuthService(mockAdminRepo, new(MockBidderRepository), mockJWTService)
 
 		email := "admin@example.com"
 		password := "password123"
@@ -252,7 +270,7 @@ func TestAuthService_LoginAdmin(t *testing.T) {
 	t.Run("Error - JWT generation failed", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
 		mockJWTService := new(MockJWTService)
-		authService := NewAuthService(mockAdminRepo, mockJWTService)
+		authService := NewAuthService(mockAdminRepo, new(MockBidderRepository), mockJWTService)
 
 		email := "admin@example.com"
 		password := "password123"
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
+
+// bidQueueTTL is how long an item's queue counters are kept after the last bid
+const bidQueueTTL = 6 * time.Hour
+
+// bidTurnPollInterval is how often a queued bid request checks whether its turn has come
+const bidTurnPollInterval = 5 * time.Millisecond
+
+// enqueueBidScript atomically assigns the next arrival position in an item's bid queue and
+// returns the ID of the bid already accepted at the requested price, if any.
+//
+// KEYS[1]: arrival counter, KEYS[2]: accepted bid IDs by price
+// ARGV[1]: price, ARGV[2]: TTL in seconds
+var enqueueBidScript = redis.NewScript(`
+local position = redis.call('INCR', KEYS[1])
+redis.call('EXPIRE', KEYS[1], ARGV[2])
+local accepted = redis.call('HGET', KEYS[2], ARGV[1])
+return {position, accepted}
+`)
+
+// finishBidTurnScript records that the request at a position has been processed. The counter
+// never moves backwards, so a request that finishes after a later one changes nothing.
+//
+// KEYS[1]: processed position, ARGV[1]: position, ARGV[2]: TTL in seconds
+var finishBidTurnScript = redis.NewScript(`
+local processed = tonumber(redis.call('GET', KEYS[1]) or '0')
+if processed < tonumber(ARGV[1]) then
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+redis.call('EXPIRE', KEYS[1], ARGV[2])
+return 0
+`)
+
+// OutbidAtPriceError is returned when an earlier bid at the requested price was already accepted
+type OutbidAtPriceError struct {
+	Result domain.OutbidAtPriceResult
+}
+
+func (e *OutbidAtPriceError) Error() string {
+	return fmt.Sprintf("bid %d was accepted first at price %d", e.Result.AcceptedBidID, e.Result.Price)
+}
+
+func (e *OutbidAtPriceError) Unwrap() error {
+	return ErrOutbidAtPrice
+}
+
+// newOutbidAtPriceError builds the result naming the accepted bid
+func newOutbidAtPriceError(accepted *domain.Bid, position *int64) *OutbidAtPriceError {
+	return &OutbidAtPriceError{Result: domain.OutbidAtPriceResult{
+		Result:        domain.BidQueueOutcomeOutbidAtPrice,
+		AcceptedBidID: accepted.ID,
+		Price:         accepted.Price,
+		AcceptedAt:    accepted.BidAt,
+		Position:      position,
+	}}
+}
+
+func bidQueueKey(itemID string) string {
+	return fmt.Sprintf("item:bid_queue:%s", itemID)
+}
+
+func bidAcceptedKey(itemID string) string {
+	return fmt.Sprintf("item:bid_accepted:%s", itemID)
+}
+
+func bidTurnKey(itemID string) string {
+	return fmt.Sprintf("item:bid_turn:%s", itemID)
+}
+
+// enqueueBid assigns the bid request its position in the item's queue. It returns the ID of the
+// bid already accepted at the price (0 if none) so later requests can be answered without
+// touching the database. Returns a nil position when Redis is not configured.
+func enqueueBid(ctx context.Context, redisClient *redis.Client, itemID string, price int64) (*int64, int64, error) {
+	if redisClient == nil {
+		return nil, 0, nil
+	}
+
+	keys := []string{bidQueueKey(itemID), bidAcceptedKey(itemID)}
+	values, err := enqueueBidScript.Run(ctx, redisClient, keys, price, int(bidQueueTTL.Seconds())).Slice()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to enqueue bid: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, 0, errors.New("failed to enqueue bid: empty script result")
+	}
+
+	position, ok := values[0].(int64)
+	if !ok {
+		return nil, 0, fmt.Errorf("failed to enqueue bid: unexpected position %v", values[0])
+	}
+
+	var acceptedBidID int64
+	if len(values) > 1 {
+		if accepted, ok := values[1].(string); ok {
+			acceptedBidID, _ = strconv.ParseInt(accepted, 10, 64)
+		}
+	}
+
+	return &position, acceptedBidID, nil
+}
+
+// waitForBidTurn blocks until every earlier request in the item's queue has been processed, so
+// bids are validated and accepted in queue order. After timeout the request proceeds anyway so
+// that a request that died before finishing its turn cannot stall the item.
+func waitForBidTurn(ctx context.Context, redisClient *redis.Client, itemID string, position *int64, timeout time.Duration) {
+	if redisClient == nil || position == nil {
+		return
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		processed, err := redisClient.Get(ctx, bidTurnKey(itemID)).Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			fmt.Printf("Warning: failed to read bid turn: %v\n", err)
+			return
+		}
+		if processed >= *position-1 {
+			return
+		}
+		if time.Now().After(deadline) {
+			fmt.Printf("Warning: bid turn timed out: item=%s position=%d processed=%d\n", itemID, *position, processed)
+			return
+		}
+		time.Sleep(bidTurnPollInterval)
+	}
+}
+
+// finishBidTurn hands the item's queue over to the next request
+func finishBidTurn(ctx context.Context, redisClient *redis.Client, itemID string, position *int64) {
+	if redisClient == nil || position == nil {
+		return
+	}
+
+	if err := finishBidTurnScript.Run(ctx, redisClient, []string{bidTurnKey(itemID)}, *position, int(bidQueueTTL.Seconds())).Err(); err != nil {
+		fmt.Printf("Warning: failed to finish bid turn: %v\n", err)
+	}
+}
+
+// markBidAccepted records the bid accepted at its price so later requests are resolved in Redis
+func markBidAccepted(ctx context.Context, redisClient *redis.Client, bid *domain.Bid) error {
+	if redisClient == nil {
+		return nil
+	}
+
+	key := bidAcceptedKey(bid.ItemID.String())
+	pipe := redisClient.TxPipeline()
+	pipe.HSetNX(ctx, key, strconv.FormatInt(bid.Price, 10), bid.ID)
+	pipe.Expire(ctx, key, bidQueueTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to mark accepted bid: %w", err)
+	}
+	return nil
+}
+
+// bidRejectReason returns the reason recorded in the queue audit for a rejected bid
+func bidRejectReason(err error) string {
+	switch {
+	case errors.Is(err, ErrItemNotFound):
+		return "item_not_found"
+	case errors.Is(err, ErrItemNotStarted):
+		return "item_not_started"
+	case errors.Is(err, ErrItemAlreadyEnded):
+		return "item_already_ended"
+	case errors.Is(err, ErrPriceMismatch):
+		return "price_mismatch"
+	case errors.Is(err, ErrAlreadyWinningBidder):
+		return "already_winning_bidder"
+	case errors.Is(err, ErrInsufficientPoints):
+		return "insufficient_points"
+	case errors.Is(err, ErrPointsNotFound):
+		return "points_not_found"
+	case errors.Is(err, ErrBidLockFailed):
+		return "lock_timeout"
+	case errors.Is(err, ErrStaleFenceToken):
+		return "stale_fence_token"
+	default:
+		return "internal_error"
+	}
+}
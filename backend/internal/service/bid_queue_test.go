@@ -0,0 +1,67 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPlaceBid_OutbidAtPrice(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+
+	service := NewBidService(db, nil, repository.NewBidRepository(db), repository.NewPointRepository(db), repository.NewAuctionRepository(db))
+
+	itemID := uuid.New()
+	bidderID := uuid.New()
+	firstBidderID := uuid.New()
+	startedAt := time.Now().Add(-time.Minute)
+	acceptedAt := time.Now().Add(-time.Second)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET LOCAL lock_timeout`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`SAVEPOINT bid`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT \* FROM "items" WHERE id = \$1 .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "started_at", "current_price"}).AddRow(itemID, startedAt, 2000))
+	mock.ExpectQuery(`SELECT \* FROM "bids" WHERE item_id = \$1 AND is_winning = \$2`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "item_id", "bidder_id", "price", "is_winning", "bid_at"}).
+			AddRow(7, itemID, firstBidderID, 2000, true, acceptedAt))
+	// The rejection is recorded in the same transaction
+	mock.ExpectExec(`ROLLBACK TO SAVEPOINT bid`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`INSERT INTO "bid_queue_entries"`).
+		WithArgs(itemID, bidderID, int64(2000), nil, domain.BidQueueOutcomeOutbidAtPrice, int64(7), nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	_, err = service.PlaceBid(&PlaceBidRequest{
+		ItemID:   itemID.String(),
+		BidderID: bidderID.String(),
+		Price:    2000,
+	})
+
+	var outbid *OutbidAtPriceError
+	require.True(t, errors.As(err, &outbid))
+	assert.ErrorIs(t, err, ErrOutbidAtPrice)
+	assert.Equal(t, domain.BidQueueOutcomeOutbidAtPrice, outbid.Result.Result)
+	assert.Equal(t, int64(7), outbid.Result.AcceptedBidID)
+	assert.Equal(t, int64(2000), outbid.Result.Price)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBidRejectReason(t *testing.T) {
+	assert.Equal(t, "price_mismatch", bidRejectReason(ErrPriceMismatch))
+	assert.Equal(t, "insufficient_points", bidRejectReason(ErrInsufficientPoints))
+	assert.Equal(t, "lock_timeout", bidRejectReason(ErrBidLockFailed))
+	assert.Equal(t, "internal_error", bidRejectReason(errors.New("connection reset")))
+}
@@ -2,14 +2,15 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/redis/go-redis/v9"
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/events"
 	"github.com/tsutsumi389/real-time-auction/internal/repository"
 	"gorm.io/gorm"
 )
@@ -18,15 +19,23 @@ var (
 	// Bid-specific errors (reuse existing errors from errors.go where possible)
 	ErrInsufficientPoints   = errors.New("insufficient available points")
 	ErrPriceMismatch        = errors.New("price does not match current price")
-	ErrBidLockFailed        = errors.New("failed to acquire bid lock, please try again")
+	ErrBidLockFailed        = errors.New("item is busy, please try again")
 	ErrAlreadyWinningBidder = errors.New("you are already the winning bidder")
+	ErrOutbidAtPrice        = errors.New("another bid was accepted first at this price")
 )
 
 const (
-	BidLockTimeout = 5 * time.Second // Redis lock timeout
-	BidLockRetries = 0               // No retries, fail fast
+	BidLockTimeout = 5 * time.Second // Maximum wait for the item row lock
+	BidTurnTimeout = 5 * time.Second // Maximum wait for earlier requests in the item's bid queue
 )
 
+// bidSavepoint marks the start of a bid's writes; rejected bids roll back to it and keep only
+// their queue entry
+const bidSavepoint = "bid"
+
+// pgLockNotAvailable is the PostgreSQL error code raised when lock_timeout expires
+const pgLockNotAvailable = "55P03"
+
 // BidService handles bid-related business logic
 type BidService struct {
 	db              *gorm.DB
@@ -60,6 +69,11 @@ type PlaceBidRequest struct {
 	ItemID   string
 	BidderID string
 	Price    int64
+
+	// Timing evidence recorded with the bid (optional)
+	ReceivedAt    time.Time // Server time the request was received; defaults to now
+	ClientRTTMs   *int64    // Connection round-trip time, when measured
+	ClockOffsetMs *int64    // Client clock offset reported by the client, when synced
 }
 
 // PlaceBidResponse represents the response after placing a bid
@@ -68,8 +82,20 @@ type PlaceBidResponse struct {
 	Points *domain.BidderPoints  `json:"points"`
 }
 
-// PlaceBid executes the bid placement with distributed locking and transaction
+// PlaceBid executes the bid placement in a single transaction.
+// The item row is locked before any check, so the price, status and winning-bid checks are
+// serialized with price disclosures (OpenPrice) and item end (EndItem), which take the same lock.
+//
+// Each request first takes a position in the item's bid queue and is processed only after the
+// requests before it, so the first valid bid at a price in queue order is accepted; later
+// requests at that price fail with an *OutbidAtPriceError naming the accepted bid. The position,
+// timing and outcome of every request are recorded for audit in the bid's transaction.
 func (s *BidService) PlaceBid(req *PlaceBidRequest) (*PlaceBidResponse, error) {
+	receivedAt := req.ReceivedAt
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+
 	// Parse item ID
 	itemID, err := uuid.Parse(req.ItemID)
 	if err != nil {
@@ -82,80 +108,102 @@ func (s *BidService) PlaceBid(req *PlaceBidRequest) (*PlaceBidResponse, error) {
 		return nil, fmt.Errorf("invalid bidder ID: %w", err)
 	}
 
-	// Step 1: Validate item exists and is eligible for bidding
-	item, err := s.auctionRepo.FindItemByID(req.ItemID)
+	// Take a position in the item's bid queue and wait until the earlier requests are processed,
+	// so the first valid request at a price is the one accepted
+	position, acceptedBidID, err := enqueueBid(s.ctx, s.redisClient, req.ItemID, req.Price)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find item: %w", err)
+		// The database check below still rejects later bids at an accepted price
+		fmt.Printf("Warning: %v\n", err)
 	}
-	if item == nil {
-		return nil, ErrItemNotFound
+	waitForBidTurn(s.ctx, s.redisClient, req.ItemID, position, BidTurnTimeout)
+	turnFinished := false
+	finishTurn := func() {
+		if !turnFinished {
+			turnFinished = true
+			finishBidTurn(s.ctx, s.redisClient, req.ItemID, position)
+		}
 	}
-
-	// Check if item has started
-	if item.StartedAt == nil {
-		return nil, ErrItemNotStarted
+	defer finishTurn()
+
+	queueEntry := &domain.BidQueueEntry{
+		ItemID:     itemID,
+		BidderID:   bidderID,
+		Price:      req.Price,
+		Position:   position,
+		ReceivedAt: receivedAt,
 	}
 
-	// Check if item has ended
-	if item.EndedAt != nil {
-		return nil, ErrItemAlreadyEnded
+	// Resolve requests at an already accepted price without taking the item lock
+	if acceptedBidID != 0 {
+		accepted, err := s.bidRepo.FindBidByID(acceptedBidID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find accepted bid: %w", err)
+		}
+		if accepted != nil && accepted.ItemID == itemID && accepted.BidderID != bidderID {
+			outbidErr := newOutbidAtPriceError(accepted, position)
+			if err := s.recordRejectedBid(queueEntry, outbidErr, nil); err != nil {
+				fmt.Printf("Warning: failed to record bid queue entry: %v\n", err)
+			}
+			return nil, outbidErr
+		}
 	}
 
-	// Check if current price is set
-	if item.CurrentPrice == nil {
-		return nil, ErrPriceMismatch
-	}
+	var item *domain.Item
+	var winningBid *domain.Bid
+	var bid *domain.Bid
+	var updatedPoints *domain.BidderPoints
+	var previousUpdatedPoints *domain.BidderPoints
 
-	// Validate price matches current price
-	if req.Price != *item.CurrentPrice {
-		return nil, ErrPriceMismatch
-	}
+	placeBid := func(tx *gorm.DB) error {
+		// Step 1: Lock the item and validate it is eligible for bidding
+		item, err = s.auctionRepo.FindItemForUpdate(itemID, tx)
+		if err != nil {
+			return fmt.Errorf("failed to find item: %w", err)
+		}
+		if item == nil {
+			return ErrItemNotFound
+		}
 
-	// Step 2: Acquire distributed lock for this item
-	lockKey := fmt.Sprintf("bid:lock:item:%s", req.ItemID)
-	lockValue := fmt.Sprintf("%s:%d", req.BidderID, time.Now().UnixNano())
+		// Check if item has started
+		if item.StartedAt == nil {
+			return ErrItemNotStarted
+		}
 
-	// Try to acquire lock with SET NX EX
-	acquired, err := s.redisClient.SetNX(s.ctx, lockKey, lockValue, BidLockTimeout).Result()
-	if err != nil {
-		return nil, fmt.Errorf("failed to acquire lock: %w", err)
-	}
-	if !acquired {
-		return nil, ErrBidLockFailed
-	}
+		// Check if item has ended
+		if item.EndedAt != nil {
+			return ErrItemAlreadyEnded
+		}
 
-	// Ensure lock is released
-	defer func() {
-		// Only delete lock if it's still ours
-		script := `
-			if redis.call("get", KEYS[1]) == ARGV[1] then
-				return redis.call("del", KEYS[1])
-			else
-				return 0
-			end
-		`
-		s.redisClient.Eval(s.ctx, script, []string{lockKey}, lockValue).Result()
-	}()
-
-	// Step 3: Check if bidder is already the winning bidder
-	winningBid, err := s.bidRepo.FindWinningBidByItemID(itemID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check winning bid: %w", err)
-	}
-	if winningBid != nil && winningBid.BidderID == bidderID {
-		return nil, ErrAlreadyWinningBidder
-	}
+		// Validate price matches current price
+		if item.CurrentPrice == nil || req.Price != *item.CurrentPrice {
+			return ErrPriceMismatch
+		}
 
-	// Step 4: Execute transaction
-	var bid *domain.Bid
-	var updatedPoints *domain.BidderPoints
+		// Step 2: Check if bidder is already the winning bidder
+		winningBid, err = s.bidRepo.FindWinningBidByItemID(itemID, tx)
+		if err != nil {
+			return fmt.Errorf("failed to check winning bid: %w", err)
+		}
+		if winningBid != nil && winningBid.BidderID == bidderID {
+			return ErrAlreadyWinningBidder
+		}
 
-	err = s.db.Transaction(func(tx *gorm.DB) error {
-		// Get current points within transaction (for consistency)
-		currentPoints, err := s.pointRepo.GetCurrentPoints(req.BidderID, tx)
+		// Only the first valid bid at a price is accepted
+		if winningBid != nil && winningBid.Price >= req.Price {
+			return newOutbidAtPriceError(winningBid, position)
+		}
+
+		// Step 3: Lock the points of the bidder and of the previous winning bidder
+		bidderIDs := []string{req.BidderID}
+		if winningBid != nil {
+			bidderIDs = append(bidderIDs, winningBid.BidderID.String())
+		}
+		points, err := s.pointRepo.GetPointsForUpdate(bidderIDs, tx)
 		if err != nil {
 			return fmt.Errorf("failed to get current points: %w", err)
 		}
+
+		currentPoints := points[req.BidderID]
 		if currentPoints == nil {
 			return ErrPointsNotFound
 		}
@@ -165,15 +213,16 @@ func (s *BidService) PlaceBid(req *PlaceBidRequest) (*PlaceBidResponse, error) {
 			return ErrInsufficientPoints
 		}
 
+		// Reject the writes if another operation has written to the item since it was read
+		if err := checkFenceToken(s.auctionRepo, itemID, item.FenceToken, tx); err != nil {
+			return err
+		}
+
 		// If there is a previous winning bid, release those reserved points
-		// (Note: We already checked that the bidder is not the current winning bidder in Step 3)
+		// (Note: We already checked that the bidder is not the current winning bidder in Step 2)
 		if winningBid != nil {
-			// Get the previous bidder's points
 			previousBidderIDStr := winningBid.BidderID.String()
-			previousPoints, err := s.pointRepo.GetCurrentPoints(previousBidderIDStr, tx)
-			if err != nil {
-				return fmt.Errorf("failed to get previous bidder points: %w", err)
-			}
+			previousPoints := points[previousBidderIDStr]
 			if previousPoints == nil {
 				return ErrPointsNotFound
 			}
@@ -185,34 +234,52 @@ func (s *BidService) PlaceBid(req *PlaceBidRequest) (*PlaceBidResponse, error) {
 
 			// Create point history for release
 			releaseHistory := &domain.PointHistory{
-				BidderID:       previousBidderIDStr,
-				Amount:         winningBid.Price,
-				Type:           domain.PointHistoryTypeRelease,
-				RelatedBidID:   &winningBid.ID,
-				BalanceBefore:  previousPoints.AvailablePoints,
-				BalanceAfter:   previousPoints.AvailablePoints + winningBid.Price,
-				ReservedBefore: previousPoints.ReservedPoints,
-				ReservedAfter:  previousPoints.ReservedPoints - winningBid.Price,
-				TotalBefore:    previousPoints.TotalPoints,
-				TotalAfter:     previousPoints.TotalPoints,
+				BidderID:         previousBidderIDStr,
+				Amount:           winningBid.Price,
+				Type:             domain.PointHistoryTypeRelease,
+				RelatedAuctionID: item.AuctionID,
+				RelatedItemID:    &item.ID,
+				RelatedBidID:     &winningBid.ID,
+				BalanceBefore:    previousPoints.AvailablePoints,
+				BalanceAfter:     previousPoints.AvailablePoints + winningBid.Price,
+				ReservedBefore:   previousPoints.ReservedPoints,
+				ReservedAfter:    previousPoints.ReservedPoints - winningBid.Price,
+				TotalBefore:      previousPoints.TotalPoints,
+				TotalAfter:       previousPoints.TotalPoints,
 			}
 			if err := s.pointRepo.CreatePointHistory(releaseHistory, tx); err != nil {
 				return fmt.Errorf("failed to create release history: %w", err)
 			}
+
+			// Get previous bidder's updated points (for notification)
+			previousUpdatedPoints, err = s.pointRepo.GetCurrentPoints(previousBidderIDStr, tx)
+			if err != nil {
+				return fmt.Errorf("failed to get previous bidder updated points: %w", err)
+			}
 		}
 
-		// Create bid record
+		// Step 4: Create bid record
 		bid = &domain.Bid{
-			ItemID:    itemID,
-			BidderID:  bidderID,
-			Price:     req.Price,
-			IsWinning: true,
-			BidAt:     time.Now(),
+			ItemID:        itemID,
+			BidderID:      bidderID,
+			Price:         req.Price,
+			IsWinning:     true,
+			BidAt:         time.Now(),
+			ReceivedAt:    &receivedAt,
+			ClientRTTMs:   req.ClientRTTMs,
+			ClockOffsetMs: req.ClockOffsetMs,
 		}
-		if err := s.bidRepo.CreateBid(bid); err != nil {
+		if err := s.bidRepo.CreateBid(bid, tx); err != nil {
 			return fmt.Errorf("failed to create bid: %w", err)
 		}
 
+		queueEntry.Outcome = domain.BidQueueOutcomeAccepted
+		queueEntry.BidID = &bid.ID
+		queueEntry.ProcessedAt = time.Now()
+		if err := s.bidRepo.CreateQueueEntry(queueEntry, tx); err != nil {
+			return fmt.Errorf("failed to record bid queue entry: %w", err)
+		}
+
 		// Update points: available -> reserved
 		if err := s.pointRepo.UpdatePoints(req.BidderID, -req.Price, req.Price, tx); err != nil {
 			return fmt.Errorf("failed to update points: %w", err)
@@ -220,16 +287,18 @@ func (s *BidService) PlaceBid(req *PlaceBidRequest) (*PlaceBidResponse, error) {
 
 		// Create point history for reserve
 		reserveHistory := &domain.PointHistory{
-			BidderID:       req.BidderID,
-			Amount:         req.Price,
-			Type:           domain.PointHistoryTypeReserve,
-			RelatedBidID:   &bid.ID,
-			BalanceBefore:  currentPoints.AvailablePoints,
-			BalanceAfter:   currentPoints.AvailablePoints - req.Price,
-			ReservedBefore: currentPoints.ReservedPoints,
-			ReservedAfter:  currentPoints.ReservedPoints + req.Price,
-			TotalBefore:    currentPoints.TotalPoints,
-			TotalAfter:     currentPoints.TotalPoints,
+			BidderID:         req.BidderID,
+			Amount:           req.Price,
+			Type:             domain.PointHistoryTypeReserve,
+			RelatedAuctionID: item.AuctionID,
+			RelatedItemID:    &item.ID,
+			RelatedBidID:     &bid.ID,
+			BalanceBefore:    currentPoints.AvailablePoints,
+			BalanceAfter:     currentPoints.AvailablePoints - req.Price,
+			ReservedBefore:   currentPoints.ReservedPoints,
+			ReservedAfter:    currentPoints.ReservedPoints + req.Price,
+			TotalBefore:      currentPoints.TotalPoints,
+			TotalAfter:       currentPoints.TotalPoints,
 		}
 		if err := s.pointRepo.CreatePointHistory(reserveHistory, tx); err != nil {
 			return fmt.Errorf("failed to create reserve history: %w", err)
@@ -247,18 +316,62 @@ func (s *BidService) PlaceBid(req *PlaceBidRequest) (*PlaceBidResponse, error) {
 		}
 
 		return nil
-	})
+	}
+
+	var bidErr error
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// Wait at most BidLockTimeout for concurrent operations on the same item
+		if err := setLockTimeout(tx, BidLockTimeout); err != nil {
+			return err
+		}
+		if err := tx.SavePoint(bidSavepoint).Error; err != nil {
+			return err
+		}
 
+		bidErr = placeBid(tx)
+		if bidErr == nil {
+			return nil
+		}
+		if isLockTimeout(bidErr) {
+			bidErr = ErrBidLockFailed
+		}
+
+		// Keep only the queue entry of the rejected bid
+		if err := tx.RollbackTo(bidSavepoint).Error; err != nil {
+			return err
+		}
+		return s.recordRejectedBid(queueEntry, bidErr, tx)
+	})
+	if bidErr != nil {
+		if err != nil {
+			fmt.Printf("Warning: failed to record rejected bid: %v\n", err)
+		}
+		return nil, bidErr
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	// Answer later requests at this price from the queue
+	if err := markBidAccepted(s.ctx, s.redisClient, bid); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	finishTurn()
+
+	// Refresh the live-state snapshot so it reflects the new winning bid
+	if s.auctionRepo != nil && item.AuctionID != nil {
+		refreshLiveState(s.ctx, s.redisClient, s.auctionRepo, s.bidRepo, item.AuctionID.String())
+	}
+
 	// Step 5: Publish bid event to Redis Pub/Sub
 	if err := s.publishBidEvent(bid, item); err != nil {
 		// Log error but don't fail the bid
 		fmt.Printf("Warning: failed to publish bid event: %v\n", err)
 	}
 
+	// Step 6: Notify affected bidders
+	s.publishBidNotifications(bid, item, winningBid, updatedPoints, previousUpdatedPoints)
+
 	// Return response
 	return &PlaceBidResponse{
 		Bid:    bid,
@@ -266,32 +379,87 @@ func (s *BidService) PlaceBid(req *PlaceBidRequest) (*PlaceBidResponse, error) {
 	}, nil
 }
 
+// recordRejectedBid records the outcome of a bid request that was rejected with bidErr
+func (s *BidService) recordRejectedBid(entry *domain.BidQueueEntry, bidErr error, tx *gorm.DB) error {
+	var outbid *OutbidAtPriceError
+	if errors.As(bidErr, &outbid) {
+		entry.Outcome = domain.BidQueueOutcomeOutbidAtPrice
+		entry.BidID = &outbid.Result.AcceptedBidID
+	} else {
+		reason := bidRejectReason(bidErr)
+		entry.Outcome = domain.BidQueueOutcomeRejected
+		entry.Reason = &reason
+	}
+	entry.ProcessedAt = time.Now()
+
+	// Requests for unknown items cannot be recorded against the item
+	if errors.Is(bidErr, ErrItemNotFound) {
+		return nil
+	}
+
+	if err := s.bidRepo.CreateQueueEntry(entry, tx); err != nil {
+		return fmt.Errorf("failed to record bid queue entry: %w", err)
+	}
+	return nil
+}
+
 // publishBidEvent publishes a bid event to Redis Pub/Sub
+// The event carries full bidder identity; the WebSocket server projects it per viewer role.
 func (s *BidService) publishBidEvent(bid *domain.Bid, item *domain.Item) error {
-	event := map[string]interface{}{
-		"type":       "bid:placed",
-		"auction_id": item.AuctionID.String(),
-		"item_id":    bid.ItemID.String(),
-		"bid": map[string]interface{}{
-			"id":         bid.ID,
-			"bidder_id":  bid.BidderID.String(),
-			"price":      bid.Price,
-			"is_winning": bid.IsWinning,
-			"bid_at":     bid.BidAt.Format(time.RFC3339),
-		},
-	}
+	auctionID := item.AuctionID.String()
 
-	eventJSON, err := json.Marshal(event)
+	paddleNumber, err := AssignPaddleNumber(s.ctx, s.redisClient, auctionID, bid.BidderID.String())
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+		return err
 	}
 
-	channel := "auction:bid"
-	if err := s.redisClient.Publish(s.ctx, channel, eventJSON).Err(); err != nil {
-		return fmt.Errorf("failed to publish to Redis: %w", err)
+	var bidderName string
+	if s.auctionRepo != nil {
+		if info, err := s.auctionRepo.GetBidderInfo(bid.BidderID, auctionID); err == nil && info != nil {
+			bidderName = info.DisplayName
+		}
 	}
 
-	return nil
+	return events.Publish(s.ctx, s.redisClient, events.BidPlaced{
+		AuctionID: auctionID,
+		ItemID:    bid.ItemID.String(),
+		Bid: events.BidInfo{
+			ID:           bid.ID,
+			BidderID:     bid.BidderID.String(),
+			BidderName:   bidderName,
+			PaddleNumber: paddleNumber,
+			Price:        bid.Price,
+			IsWinning:    bid.IsWinning,
+			BidAt:        bid.BidAt,
+		},
+	})
+}
+
+// publishBidNotifications sends targeted notifications to the new and previous winning bidders
+func (s *BidService) publishBidNotifications(bid *domain.Bid, item *domain.Item, previousBid *domain.Bid, points, previousPoints *domain.BidderPoints) {
+	notify := func(bidderID string, payload events.Payload) {
+		if err := publishBidderNotification(s.ctx, s.redisClient, bidderID, payload); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+
+	if points != nil {
+		notify(bid.BidderID.String(), pointsUpdatedEvent(points))
+	}
+
+	if previousBid != nil {
+		previousBidderID := previousBid.BidderID.String()
+		notify(previousBidderID, events.BidOutbid{
+			AuctionID:    item.AuctionID.String(),
+			ItemID:       bid.ItemID.String(),
+			OutbidBidID:  previousBid.ID,
+			OutbidPrice:  previousBid.Price,
+			CurrentPrice: bid.Price,
+		})
+		if previousPoints != nil {
+			notify(previousBidderID, pointsUpdatedEvent(previousPoints))
+		}
+	}
 }
 
 // GetBidHistory retrieves the bid history for an item with bidder info
@@ -322,3 +490,14 @@ func (s *BidService) GetBidHistory(itemID string, bidderID string, limit, offset
 		Bids:  bids,
 	}, nil
 }
+
+// setLockTimeout limits how long the transaction waits for row locks
+func setLockTimeout(tx *gorm.DB, timeout time.Duration) error {
+	return tx.Exec(fmt.Sprintf("SET LOCAL lock_timeout = '%dms'", timeout.Milliseconds())).Error
+}
+
+// isLockTimeout reports whether err was caused by lock_timeout expiring
+func isLockTimeout(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgLockNotAvailable
+}
@@ -4,7 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
 	"github.com/tsutsumi389/real-time-auction/internal/repository"
 	"golang.org/x/crypto/bcrypt"
@@ -16,15 +20,29 @@ var (
 	ErrInvalidPoints         = errors.New("points must be greater than 0")
 	ErrPointsExceedMaximum   = errors.New("points exceed maximum limit")
 	ErrInvalidBidderSortMode = errors.New("invalid sort mode for bidders")
+
+	ErrInvalidAdjustmentAmount    = errors.New("adjustment amount must not be 0")
+	ErrInvalidAdjustmentReason    = errors.New("adjustment reason is required")
+	ErrAdjustmentExceedsAvailable = errors.New("adjustment would make available points negative")
+	ErrAdjustmentRequiresApproval = errors.New("positive adjustment exceeds the approval threshold")
+
+	ErrInvalidPointExpiry = errors.New("point expiry must be in the future")
+
+	ErrInvalidPointHistoryFilter = errors.New("invalid point history filter")
 )
 
 const (
 	MaxPointsPerGrant = 1000000 // Maximum points that can be granted at once
+
+	MaxAdjustmentReasonLength = 500 // Maximum length of a manual adjustment reason
 )
 
 // BidderService handles bidder-related business logic
 type BidderService struct {
 	bidderRepo repository.BidderRepositoryInterface
+
+	// Grants above this many points need the approval of another system admin (0 disables)
+	grantApprovalThreshold int64
 }
 
 // NewBidderService creates a new BidderService instance
@@ -34,6 +52,12 @@ func NewBidderService(bidderRepo repository.BidderRepositoryInterface) *BidderSe
 	}
 }
 
+// SetGrantApprovalThreshold sets the number of points above which a grant becomes a pending
+// request that another system admin must approve. 0 disables approvals.
+func (s *BidderService) SetGrantApprovalThreshold(threshold int64) {
+	s.grantApprovalThreshold = threshold
+}
+
 // RegisterBidder creates a new bidder with initial points
 func (s *BidderService) RegisterBidder(req *domain.BidderCreateRequest, adminID int64) (*domain.BidderResponse, error) {
 	// Check if email already exists
@@ -124,8 +148,9 @@ func (s *BidderService) GetBidderList(req *domain.BidderListRequest) (*domain.Bi
 	return response, nil
 }
 
-// GrantPoints grants points to a bidder
-func (s *BidderService) GrantPoints(bidderID string, points int64, adminID int64) (*domain.GrantPointsResponse, error) {
+// GrantPoints grants points to a bidder, optionally expiring at expiresAt.
+// Grants above the approval threshold are recorded as a pending request instead.
+func (s *BidderService) GrantPoints(bidderID string, points int64, adminID int64, expiresAt *time.Time) (*domain.GrantPointsResponse, error) {
 	// Validate points
 	if points <= 0 {
 		return nil, ErrInvalidPoints
@@ -135,6 +160,10 @@ func (s *BidderService) GrantPoints(bidderID string, points int64, adminID int64
 		return nil, ErrPointsExceedMaximum
 	}
 
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, ErrInvalidPointExpiry
+	}
+
 	// Check if bidder exists
 	bidder, err := s.bidderRepo.FindByID(bidderID)
 	if err != nil {
@@ -150,23 +179,91 @@ func (s *BidderService) GrantPoints(bidderID string, points int64, adminID int64
 		return nil, errors.New("cannot grant points to deleted bidder")
 	}
 
+	if s.grantApprovalThreshold > 0 && points > s.grantApprovalThreshold {
+		request := &domain.PointGrantRequest{
+			BidderID:    bidderID,
+			Points:      points,
+			ExpiresAt:   expiresAt,
+			Status:      domain.PointGrantRequestStatusPending,
+			RequestedBy: adminID,
+		}
+		if err := s.bidderRepo.CreatePointGrantRequest(request); err != nil {
+			return nil, fmt.Errorf("failed to create point grant request: %w", err)
+		}
+
+		return &domain.GrantPointsResponse{PendingRequest: request}, nil
+	}
+
 	// Grant points (within a transaction)
-	updatedBidder, history, err := s.bidderRepo.GrantPoints(bidderID, points, adminID)
+	updatedBidder, history, err := s.bidderRepo.GrantPoints(bidderID, points, adminID, expiresAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to grant points: %w", err)
 	}
 
 	// Build response
 	response := &domain.GrantPointsResponse{
-		Bidder:  *updatedBidder,
-		History: *history,
+		Bidder:  updatedBidder,
+		History: history,
 	}
 
 	return response, nil
 }
 
-// GetPointHistory retrieves the point history for a bidder
-func (s *BidderService) GetPointHistory(bidderID string, page int, limit int) (*domain.PointHistoryListResponse, error) {
+// AdjustPoints applies a signed manual adjustment to a bidder's points with a mandatory reason.
+// Deductions are limited to the bidder's available points; reserved points are never touched.
+// Positive adjustments above the approval threshold are rejected: they must be granted so that
+// another system admin approves them.
+func (s *BidderService) AdjustPoints(bidderID string, amount int64, reason string, adminID int64) (*domain.AdjustPointsResponse, error) {
+	if amount == 0 {
+		return nil, ErrInvalidAdjustmentAmount
+	}
+
+	if amount > MaxPointsPerGrant || amount < -MaxPointsPerGrant {
+		return nil, ErrPointsExceedMaximum
+	}
+
+	if s.grantApprovalThreshold > 0 && amount > s.grantApprovalThreshold {
+		return nil, ErrAdjustmentRequiresApproval
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > MaxAdjustmentReasonLength {
+		return nil, ErrInvalidAdjustmentReason
+	}
+
+	bidder, err := s.bidderRepo.FindByID(bidderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bidder: %w", err)
+	}
+
+	if bidder == nil {
+		return nil, ErrBidderNotFound
+	}
+
+	if bidder.IsDeleted() {
+		return nil, errors.New("cannot adjust points of deleted bidder")
+	}
+
+	updatedBidder, history, err := s.bidderRepo.AdjustPoints(bidderID, amount, reason, adminID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNegativeAvailablePoints) {
+			return nil, ErrAdjustmentExceedsAvailable
+		}
+		return nil, fmt.Errorf("failed to adjust points: %w", err)
+	}
+
+	return &domain.AdjustPointsResponse{
+		Bidder:  *updatedBidder,
+		History: *history,
+	}, nil
+}
+
+// GetPointHistory retrieves the point history for a bidder, optionally filtered by auction, item and type
+func (s *BidderService) GetPointHistory(bidderID string, filter *domain.PointHistoryFilter, page int, limit int) (*domain.PointHistoryListResponse, error) {
+	if !isValidPointHistoryFilter(filter) {
+		return nil, ErrInvalidPointHistoryFilter
+	}
+
 	// Validate and set defaults
 	if page <= 0 {
 		page = 1
@@ -191,13 +288,13 @@ func (s *BidderService) GetPointHistory(bidderID string, page int, limit int) (*
 	}
 
 	// Get total count
-	total, err := s.bidderRepo.CountPointHistory(bidderID)
+	total, err := s.bidderRepo.CountPointHistory(bidderID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count point history: %w", err)
 	}
 
 	// Get point history
-	history, err := s.bidderRepo.GetPointHistory(bidderID, page, limit)
+	history, err := s.bidderRepo.GetPointHistory(bidderID, filter, page, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get point history: %w", err)
 	}
@@ -290,6 +387,36 @@ func isValidBidderStatus(status domain.BidderStatus) bool {
 		status == domain.BidderStatusDeleted
 }
 
+// isValidPointHistoryFilter checks that the auction and item filters are UUIDs and the type is known
+func isValidPointHistoryFilter(filter *domain.PointHistoryFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	for _, id := range []string{filter.AuctionID, filter.ItemID} {
+		if id == "" {
+			continue
+		}
+		if _, err := uuid.Parse(id); err != nil {
+			return false
+		}
+	}
+
+	switch filter.Type {
+	case "",
+		domain.PointHistoryTypeGrant,
+		domain.PointHistoryTypeReserve,
+		domain.PointHistoryTypeRelease,
+		domain.PointHistoryTypeConsume,
+		domain.PointHistoryTypeRefund,
+		domain.PointHistoryTypeAdjust,
+		domain.PointHistoryTypeExpire:
+		return true
+	default:
+		return false
+	}
+}
+
 // isValidBidderSortMode checks if the sort mode is valid for bidders
 func isValidBidderSortMode(sort string) bool {
 	validSorts := []string{
@@ -2,11 +2,14 @@ package service
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
 )
 
 // MockBidderRepository is a mock implementation of BidderRepository
@@ -51,24 +54,32 @@ func (m *MockBidderRepository) GetBidderPoints(bidderID string) (*domain.BidderP
 	return args.Get(0).(*domain.BidderPoints), args.Error(1)
 }
 
-func (m *MockBidderRepository) GrantPoints(bidderID string, points int64, adminID int64) (*domain.BidderWithPoints, *domain.PointHistory, error) {
-	args := m.Called(bidderID, points, adminID)
+func (m *MockBidderRepository) GrantPoints(bidderID string, points int64, adminID int64, expiresAt *time.Time) (*domain.BidderWithPoints, *domain.PointHistory, error) {
+	args := m.Called(bidderID, points, adminID, expiresAt)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
 	return args.Get(0).(*domain.BidderWithPoints), args.Get(1).(*domain.PointHistory), args.Error(2)
 }
 
-func (m *MockBidderRepository) GetPointHistory(bidderID string, page int, limit int) ([]domain.PointHistoryWithAuction, error) {
-	args := m.Called(bidderID, page, limit)
+func (m *MockBidderRepository) AdjustPoints(bidderID string, amount int64, reason string, adminID int64) (*domain.BidderWithPoints, *domain.PointHistory, error) {
+	args := m.Called(bidderID, amount, reason, adminID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.BidderWithPoints), args.Get(1).(*domain.PointHistory), args.Error(2)
+}
+
+func (m *MockBidderRepository) GetPointHistory(bidderID string, filter *domain.PointHistoryFilter, page int, limit int) ([]domain.PointHistoryWithAuction, error) {
+	args := m.Called(bidderID, filter, page, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.PointHistoryWithAuction), args.Error(1)
 }
 
-func (m *MockBidderRepository) CountPointHistory(bidderID string) (int64, error) {
-	args := m.Called(bidderID)
+func (m *MockBidderRepository) CountPointHistory(bidderID string, filter *domain.PointHistoryFilter) (int64, error) {
+	args := m.Called(bidderID, filter)
 	return args.Get(0).(int64), args.Error(1)
 }
 
@@ -98,6 +109,35 @@ func (m *MockBidderRepository) UpdateBidder(id string, req *domain.BidderUpdateR
 	return args.Error(0)
 }
 
+func (m *MockBidderRepository) CreatePointGrantRequest(request *domain.PointGrantRequest) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+func (m *MockBidderRepository) FindPointGrantRequests(status domain.PointGrantRequestStatus) ([]domain.PointGrantRequest, error) {
+	args := m.Called(status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PointGrantRequest), args.Error(1)
+}
+
+func (m *MockBidderRepository) ApprovePointGrantRequest(requestID int64, approverID int64) (*domain.PointGrantRequest, *domain.PointHistory, error) {
+	args := m.Called(requestID, approverID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.PointGrantRequest), args.Get(1).(*domain.PointHistory), args.Error(2)
+}
+
+func (m *MockBidderRepository) RejectPointGrantRequest(requestID int64, approverID int64, note *string) (*domain.PointGrantRequest, error) {
+	args := m.Called(requestID, approverID, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PointGrantRequest), args.Error(1)
+}
+
 func TestBidderService_GetBidderByID(t *testing.T) {
 	t.Run("Success - Bidder found", func(t *testing.T) {
 		mockBidderRepo := new(MockBidderRepository)
@@ -373,9 +413,9 @@ func TestBidderService_GrantPoints(t *testing.T) {
 		}
 
 		mockBidderRepo.On("FindByID", bidderID).Return(existingBidder, nil)
-		mockBidderRepo.On("GrantPoints", bidderID, points, adminID).Return(updatedBidder, history, nil)
+		mockBidderRepo.On("GrantPoints", bidderID, points, adminID, (*time.Time)(nil)).Return(updatedBidder, history, nil)
 
-		result, err := bidderService.GrantPoints(bidderID, points, adminID)
+		result, err := bidderService.GrantPoints(bidderID, points, adminID, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -387,6 +427,32 @@ func TestBidderService_GrantPoints(t *testing.T) {
 		mockBidderRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success - Points above approval threshold create a pending request", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		bidderService := NewBidderService(mockBidderRepo)
+		bidderService.SetGrantApprovalThreshold(1000)
+
+		bidderID := "test-bidder-id"
+		adminID := int64(1)
+
+		mockBidderRepo.On("FindByID", bidderID).Return(&domain.Bidder{ID: bidderID, Status: domain.BidderStatusActive}, nil)
+		mockBidderRepo.On("CreatePointGrantRequest", mock.MatchedBy(func(request *domain.PointGrantRequest) bool {
+			return request.BidderID == bidderID &&
+				request.Points == 1001 &&
+				request.RequestedBy == adminID &&
+				request.Status == domain.PointGrantRequestStatusPending
+		})).Return(nil)
+
+		result, err := bidderService.GrantPoints(bidderID, 1001, adminID, nil)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result.PendingRequest)
+		assert.Nil(t, result.Bidder)
+		assert.Nil(t, result.History)
+		mockBidderRepo.AssertNotCalled(t, "GrantPoints", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockBidderRepo.AssertExpectations(t)
+	})
+
 	t.Run("Error - Invalid points (zero)", func(t *testing.T) {
 		mockBidderRepo := new(MockBidderRepository)
 		bidderService := NewBidderService(mockBidderRepo)
@@ -395,7 +461,7 @@ func TestBidderService_GrantPoints(t *testing.T) {
 		points := int64(0)
 		adminID := int64(1)
 
-		result, err := bidderService.GrantPoints(bidderID, points, adminID)
+		result, err := bidderService.GrantPoints(bidderID, points, adminID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -410,7 +476,7 @@ func TestBidderService_GrantPoints(t *testing.T) {
 		points := int64(-100)
 		adminID := int64(1)
 
-		result, err := bidderService.GrantPoints(bidderID, points, adminID)
+		result, err := bidderService.GrantPoints(bidderID, points, adminID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -425,7 +491,7 @@ func TestBidderService_GrantPoints(t *testing.T) {
 		points := int64(2000000) // Exceeds MaxPointsPerGrant (1000000)
 		adminID := int64(1)
 
-		result, err := bidderService.GrantPoints(bidderID, points, adminID)
+		result, err := bidderService.GrantPoints(bidderID, points, adminID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -442,7 +508,7 @@ func TestBidderService_GrantPoints(t *testing.T) {
 
 		mockBidderRepo.On("FindByID", bidderID).Return(nil, nil)
 
-		result, err := bidderService.GrantPoints(bidderID, points, adminID)
+		result, err := bidderService.GrantPoints(bidderID, points, adminID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -469,7 +535,7 @@ func TestBidderService_GrantPoints(t *testing.T) {
 
 		mockBidderRepo.On("FindByID", bidderID).Return(deletedBidder, nil)
 
-		result, err := bidderService.GrantPoints(bidderID, points, adminID)
+		result, err := bidderService.GrantPoints(bidderID, points, adminID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -479,6 +545,119 @@ func TestBidderService_GrantPoints(t *testing.T) {
 	})
 }
 
+func TestBidderService_AdjustPoints(t *testing.T) {
+	bidderID := "test-bidder-id"
+	adminID := int64(1)
+	existingBidder := &domain.Bidder{
+		ID:     bidderID,
+		Email:  "bidder@example.com",
+		Status: domain.BidderStatusActive,
+	}
+
+	t.Run("Success - Deduction with reason", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		bidderService := NewBidderService(mockBidderRepo)
+
+		reason := "Duplicate grant"
+		updatedBidder := &domain.BidderWithPoints{Bidder: *existingBidder, Points: 700}
+		history := &domain.PointHistory{
+			BidderID:      bidderID,
+			Amount:        -300,
+			Type:          domain.PointHistoryTypeAdjust,
+			Reason:        &reason,
+			AdminID:       &adminID,
+			BalanceBefore: 1000,
+			BalanceAfter:  700,
+			TotalBefore:   1000,
+			TotalAfter:    700,
+		}
+
+		mockBidderRepo.On("FindByID", bidderID).Return(existingBidder, nil)
+		mockBidderRepo.On("AdjustPoints", bidderID, int64(-300), reason, adminID).Return(updatedBidder, history, nil)
+
+		result, err := bidderService.AdjustPoints(bidderID, -300, "  Duplicate grant ", adminID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(700), result.Bidder.Points)
+		assert.Equal(t, domain.PointHistoryTypeAdjust, result.History.Type)
+		assert.Equal(t, reason, *result.History.Reason)
+		mockBidderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Validation", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		bidderService := NewBidderService(mockBidderRepo)
+
+		_, err := bidderService.AdjustPoints(bidderID, 0, "reason", adminID)
+		assert.Equal(t, ErrInvalidAdjustmentAmount, err)
+
+		_, err = bidderService.AdjustPoints(bidderID, -(MaxPointsPerGrant + 1), "reason", adminID)
+		assert.Equal(t, ErrPointsExceedMaximum, err)
+
+		_, err = bidderService.AdjustPoints(bidderID, 100, "   ", adminID)
+		assert.Equal(t, ErrInvalidAdjustmentReason, err)
+
+		_, err = bidderService.AdjustPoints(bidderID, 100, strings.Repeat("あ", MaxAdjustmentReasonLength+1), adminID)
+		assert.Equal(t, ErrInvalidAdjustmentReason, err)
+
+		mockBidderRepo.AssertNotCalled(t, "AdjustPoints", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Exceeds available points", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		bidderService := NewBidderService(mockBidderRepo)
+
+		mockBidderRepo.On("FindByID", bidderID).Return(existingBidder, nil)
+		mockBidderRepo.On("AdjustPoints", bidderID, int64(-5000), "Penalty", adminID).
+			Return(nil, nil, repository.ErrNegativeAvailablePoints)
+
+		result, err := bidderService.AdjustPoints(bidderID, -5000, "Penalty", adminID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrAdjustmentExceedsAvailable, err)
+		mockBidderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Positive adjustment above the approval threshold", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		bidderService := NewBidderService(mockBidderRepo)
+		bidderService.SetGrantApprovalThreshold(10000)
+
+		_, err := bidderService.AdjustPoints(bidderID, 10001, "Bonus", adminID)
+
+		assert.Equal(t, ErrAdjustmentRequiresApproval, err)
+		mockBidderRepo.AssertNotCalled(t, "AdjustPoints", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockBidderRepo.AssertNotCalled(t, "CreatePointGrantRequest", mock.Anything)
+	})
+
+	t.Run("Success - Deduction above the approval threshold", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		bidderService := NewBidderService(mockBidderRepo)
+		bidderService.SetGrantApprovalThreshold(10000)
+
+		updatedBidder := &domain.BidderWithPoints{Bidder: *existingBidder}
+		history := &domain.PointHistory{BidderID: bidderID, Amount: -20000, Type: domain.PointHistoryTypeAdjust}
+		mockBidderRepo.On("FindByID", bidderID).Return(existingBidder, nil)
+		mockBidderRepo.On("AdjustPoints", bidderID, int64(-20000), "Penalty", adminID).Return(updatedBidder, history, nil)
+
+		_, err := bidderService.AdjustPoints(bidderID, -20000, "Penalty", adminID)
+
+		assert.NoError(t, err)
+		mockBidderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Bidder not found", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		bidderService := NewBidderService(mockBidderRepo)
+
+		mockBidderRepo.On("FindByID", bidderID).Return(nil, nil)
+
+		_, err := bidderService.AdjustPoints(bidderID, 100, "Bonus", adminID)
+
+		assert.Equal(t, ErrBidderNotFound, err)
+	})
+}
+
 func TestBidderService_GetPointHistory(t *testing.T) {
 	t.Run("Success - Valid request", func(t *testing.T) {
 		mockBidderRepo := new(MockBidderRepository)
@@ -516,10 +695,10 @@ func TestBidderService_GetPointHistory(t *testing.T) {
 		}
 
 		mockBidderRepo.On("FindByID", bidderID).Return(existingBidder, nil)
-		mockBidderRepo.On("CountPointHistory", bidderID).Return(int64(1), nil)
-		mockBidderRepo.On("GetPointHistory", bidderID, page, limit).Return(history, nil)
+		mockBidderRepo.On("CountPointHistory", bidderID, (*domain.PointHistoryFilter)(nil)).Return(int64(1), nil)
+		mockBidderRepo.On("GetPointHistory", bidderID, (*domain.PointHistoryFilter)(nil), page, limit).Return(history, nil)
 
-		result, err := bidderService.GetPointHistory(bidderID, page, limit)
+		result, err := bidderService.GetPointHistory(bidderID, nil, page, limit)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -552,10 +731,10 @@ func TestBidderService_GetPointHistory(t *testing.T) {
 		history := []domain.PointHistoryWithAuction{}
 
 		mockBidderRepo.On("FindByID", bidderID).Return(existingBidder, nil)
-		mockBidderRepo.On("CountPointHistory", bidderID).Return(int64(0), nil)
-		mockBidderRepo.On("GetPointHistory", bidderID, 1, 50).Return(history, nil)
+		mockBidderRepo.On("CountPointHistory", bidderID, (*domain.PointHistoryFilter)(nil)).Return(int64(0), nil)
+		mockBidderRepo.On("GetPointHistory", bidderID, (*domain.PointHistoryFilter)(nil), 1, 50).Return(history, nil)
 
-		result, err := bidderService.GetPointHistory(bidderID, page, limit)
+		result, err := bidderService.GetPointHistory(bidderID, nil, page, limit)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -575,7 +754,7 @@ func TestBidderService_GetPointHistory(t *testing.T) {
 
 		mockBidderRepo.On("FindByID", bidderID).Return(nil, nil)
 
-		result, err := bidderService.GetPointHistory(bidderID, page, limit)
+		result, err := bidderService.GetPointHistory(bidderID, nil, page, limit)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -583,6 +762,47 @@ func TestBidderService_GetPointHistory(t *testing.T) {
 
 		mockBidderRepo.AssertExpectations(t)
 	})
+
+	t.Run("Success - Filter by auction, item and type", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		bidderService := NewBidderService(mockBidderRepo)
+
+		bidderID := "test-bidder-id"
+		filter := &domain.PointHistoryFilter{
+			AuctionID: "11111111-1111-1111-1111-111111111111",
+			ItemID:    "22222222-2222-2222-2222-222222222222",
+			Type:      domain.PointHistoryTypeReserve,
+		}
+
+		mockBidderRepo.On("FindByID", bidderID).Return(&domain.Bidder{ID: bidderID}, nil)
+		mockBidderRepo.On("CountPointHistory", bidderID, filter).Return(int64(0), nil)
+		mockBidderRepo.On("GetPointHistory", bidderID, filter, 1, 10).Return([]domain.PointHistoryWithAuction{}, nil)
+
+		result, err := bidderService.GetPointHistory(bidderID, filter, 1, 10)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockBidderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Invalid filter", func(t *testing.T) {
+		filters := []*domain.PointHistoryFilter{
+			{AuctionID: "not-a-uuid"},
+			{ItemID: "123"},
+			{Type: "unknown"},
+		}
+
+		for _, filter := range filters {
+			mockBidderRepo := new(MockBidderRepository)
+			bidderService := NewBidderService(mockBidderRepo)
+
+			result, err := bidderService.GetPointHistory("test-bidder-id", filter, 1, 10)
+
+			assert.Nil(t, result)
+			assert.Equal(t, ErrInvalidPointHistoryFilter, err)
+			mockBidderRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+		}
+	})
 }
 
 func TestBidderService_UpdateBidderStatus(t *testing.T) {
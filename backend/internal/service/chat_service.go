@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/events"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+)
+
+// MaxChatMessageLength is the maximum length of an announcement or question in characters
+const MaxChatMessageLength = 500
+
+// ChatService handles auctioneer announcements and moderated bidder questions
+type ChatService struct {
+	messageRepo *repository.AuctionMessageRepository
+	auctionRepo repository.AuctionRepositoryInterface
+	bidderRepo  repository.BidderRepositoryInterface
+	redisClient *redis.Client
+	ctx         context.Context
+}
+
+// NewChatService creates a new ChatService instance
+func NewChatService(
+	messageRepo *repository.AuctionMessageRepository,
+	auctionRepo repository.AuctionRepositoryInterface,
+	bidderRepo repository.BidderRepositoryInterface,
+	redisClient *redis.Client,
+) *ChatService {
+	return &ChatService{
+		messageRepo: messageRepo,
+		auctionRepo: auctionRepo,
+		bidderRepo:  bidderRepo,
+		redisClient: redisClient,
+		ctx:         context.Background(),
+	}
+}
+
+// Announce records an announcement from an auctioneer and broadcasts it to the auction room
+func (s *ChatService) Announce(auctionID string, adminID int64, senderName string, body string) (*domain.AuctionMessage, error) {
+	body, err := normalizeMessage(body)
+	if err != nil {
+		return nil, err
+	}
+
+	auction, err := s.findAuction(auctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &domain.AuctionMessage{
+		AuctionID:  auction.ID,
+		Kind:       domain.AuctionMessageKindAnnouncement,
+		AdminID:    &adminID,
+		SenderName: senderName,
+		Body:       body,
+	}
+	if err := s.messageRepo.Create(message); err != nil {
+		return nil, fmt.Errorf("failed to create announcement: %w", err)
+	}
+
+	s.publishEvent(events.AuctionAnnouncement{
+		AuctionID:   auctionID,
+		MessageID:   message.ID,
+		Message:     message.Body,
+		AnnouncedBy: message.SenderName,
+		AnnouncedAt: message.CreatedAt,
+	})
+
+	return message, nil
+}
+
+// SendQuestion records a question from a bidder to the auctioneers.
+// Questions are delivered only to admins and the sending bidder.
+func (s *ChatService) SendQuestion(auctionID string, bidderID string, body string) (*domain.AuctionMessage, error) {
+	body, err := normalizeMessage(body)
+	if err != nil {
+		return nil, err
+	}
+
+	bidderUUID, err := uuid.Parse(bidderID)
+	if err != nil {
+		return nil, ErrBidderNotFound
+	}
+
+	auction, err := s.findAuction(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if !auction.ChatEnabled {
+		return nil, ErrChatDisabled
+	}
+	if auction.Status != domain.AuctionStatusActive {
+		return nil, ErrAuctionNotActive
+	}
+
+	muted, err := s.messageRepo.IsMuted(auction.ID, bidderUUID)
+	if err != nil {
+		return nil, err
+	}
+	if muted {
+		return nil, ErrChatMuted
+	}
+
+	bidder, err := s.bidderRepo.FindByID(bidderID)
+	if err != nil {
+		return nil, err
+	}
+	if bidder == nil {
+		return nil, ErrBidderNotFound
+	}
+
+	message := &domain.AuctionMessage{
+		AuctionID:  auction.ID,
+		Kind:       domain.AuctionMessageKindQuestion,
+		BidderID:   &bidderUUID,
+		SenderName: displayNameOf(bidder),
+		Body:       body,
+	}
+	if err := s.messageRepo.Create(message); err != nil {
+		return nil, fmt.Errorf("failed to create question: %w", err)
+	}
+
+	s.publishEvent(events.ChatMessage{
+		AuctionID:  auctionID,
+		MessageID:  message.ID,
+		BidderID:   bidderID,
+		BidderName: message.SenderName,
+		Message:    message.Body,
+		SentAt:     message.CreatedAt,
+	})
+
+	return message, nil
+}
+
+// DeleteMessage removes an announcement or question from the auction record on behalf of a moderator.
+// Deleted messages are kept for admins and hidden from bidders.
+func (s *ChatService) DeleteMessage(auctionID string, messageID int64, adminID int64) error {
+	message, err := s.messageRepo.FindByID(messageID)
+	if err != nil {
+		return err
+	}
+	if message == nil || message.AuctionID.String() != auctionID {
+		return ErrMessageNotFound
+	}
+
+	deletedAt := time.Now()
+	deleted, err := s.messageRepo.MarkDeleted(messageID, adminID, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+	if !deleted {
+		// Already deleted by another moderator
+		return nil
+	}
+
+	s.publishEvent(events.ChatMessageDeleted{
+		AuctionID: auctionID,
+		MessageID: messageID,
+		DeletedAt: deletedAt,
+	})
+
+	return nil
+}
+
+// MuteBidder prevents a bidder from sending questions in an auction
+func (s *ChatService) MuteBidder(auctionID string, bidderID string, adminID int64, reason string) error {
+	auctionUUID, bidderUUID, err := s.parseMuteTarget(auctionID, bidderID)
+	if err != nil {
+		return err
+	}
+
+	mute := &domain.AuctionChatMute{
+		AuctionID: auctionUUID,
+		BidderID:  bidderUUID,
+		MutedBy:   adminID,
+	}
+	if reason = strings.TrimSpace(reason); reason != "" {
+		mute.Reason = &reason
+	}
+	if err := s.messageRepo.Mute(mute); err != nil {
+		return fmt.Errorf("failed to mute bidder: %w", err)
+	}
+
+	s.notifyBidder(bidderID, events.ChatMuted{AuctionID: auctionID, Muted: true})
+	return nil
+}
+
+// UnmuteBidder allows a muted bidder to send questions again
+func (s *ChatService) UnmuteBidder(auctionID string, bidderID string) error {
+	auctionUUID, bidderUUID, err := s.parseMuteTarget(auctionID, bidderID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.messageRepo.Unmute(auctionUUID, bidderUUID); err != nil {
+		return fmt.Errorf("failed to unmute bidder: %w", err)
+	}
+
+	s.notifyBidder(bidderID, events.ChatMuted{AuctionID: auctionID, Muted: false})
+	return nil
+}
+
+// SetChatEnabled enables or disables bidder questions for an auction
+func (s *ChatService) SetChatEnabled(auctionID string, enabled bool) error {
+	auctionUUID, err := uuid.Parse(auctionID)
+	if err != nil {
+		return ErrAuctionNotFound
+	}
+
+	updated, err := s.messageRepo.SetChatEnabled(auctionUUID, enabled)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrAuctionNotFound
+	}
+	return nil
+}
+
+// GetMessages returns the auction record of announcements and questions.
+// Admins see every message including deleted ones; bidders see announcements and their own
+// questions; anonymous viewers (bidderID nil) see announcements only.
+func (s *ChatService) GetMessages(auctionID string, bidderID *string, isAdmin bool) (*domain.AuctionMessageListResponse, error) {
+	auction, err := s.findAuction(auctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []domain.AuctionMessage
+	if isAdmin {
+		messages, err = s.messageRepo.FindByAuctionID(auction.ID)
+	} else {
+		var bidderUUID *uuid.UUID
+		if bidderID != nil {
+			id, err := uuid.Parse(*bidderID)
+			if err != nil {
+				return nil, ErrBidderNotFound
+			}
+			bidderUUID = &id
+		}
+		messages, err = s.messageRepo.FindVisible(auction.ID, bidderUUID)
+		for i := range messages {
+			messages[i] = messages[i].ForBidder()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if messages == nil {
+		messages = []domain.AuctionMessage{}
+	}
+
+	return &domain.AuctionMessageListResponse{
+		AuctionID:   auction.ID,
+		ChatEnabled: auction.ChatEnabled,
+		Messages:    messages,
+	}, nil
+}
+
+// findAuction returns the auction or ErrAuctionNotFound
+func (s *ChatService) findAuction(auctionID string) (*domain.Auction, error) {
+	if _, err := uuid.Parse(auctionID); err != nil {
+		return nil, ErrAuctionNotFound
+	}
+
+	auction, err := s.auctionRepo.FindByID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if auction == nil {
+		return nil, ErrAuctionNotFound
+	}
+	return auction, nil
+}
+
+// parseMuteTarget validates the auction and bidder of a mute operation
+func (s *ChatService) parseMuteTarget(auctionID string, bidderID string) (uuid.UUID, uuid.UUID, error) {
+	auction, err := s.findAuction(auctionID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	bidderUUID, err := uuid.Parse(bidderID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrBidderNotFound
+	}
+	bidder, err := s.bidderRepo.FindByID(bidderID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if bidder == nil {
+		return uuid.Nil, uuid.Nil, ErrBidderNotFound
+	}
+
+	return auction.ID, bidderUUID, nil
+}
+
+// publishEvent publishes an auction room event, logging failures without failing the operation
+func (s *ChatService) publishEvent(payload events.Payload) {
+	if err := events.Publish(s.ctx, s.redisClient, payload); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// notifyBidder publishes a targeted notification, logging failures without failing the operation
+func (s *ChatService) notifyBidder(bidderID string, payload events.Payload) {
+	if err := publishBidderNotification(s.ctx, s.redisClient, bidderID, payload); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// normalizeMessage trims a message and checks its length
+func normalizeMessage(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > MaxChatMessageLength {
+		return "", ErrInvalidMessage
+	}
+	return body, nil
+}
+
+// displayNameOf returns the name shown for a bidder's messages
+func displayNameOf(bidder *domain.Bidder) string {
+	if bidder.DisplayName != nil && *bidder.DisplayName != "" {
+		return *bidder.DisplayName
+	}
+	return "Bidder"
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
+
+func TestChatService_Announce_InvalidMessage(t *testing.T) {
+	service := NewChatService(nil, new(MockAuctionRepository), nil, nil)
+	auctionID := uuid.New().String()
+
+	_, err := service.Announce(auctionID, 1, "Auctioneer", "   ")
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+
+	_, err = service.Announce(auctionID, 1, "Auctioneer", strings.Repeat("あ", MaxChatMessageLength+1))
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+}
+
+func TestChatService_SendQuestion_RejectsClosedChat(t *testing.T) {
+	bidderID := uuid.New().String()
+
+	t.Run("Chat disabled", func(t *testing.T) {
+		mockRepo := new(MockAuctionRepository)
+		service := NewChatService(nil, mockRepo, nil, nil)
+
+		auction := &domain.Auction{ID: uuid.New(), Status: domain.AuctionStatusActive}
+		mockRepo.On("FindByID", auction.ID.String()).Return(auction, nil)
+
+		_, err := service.SendQuestion(auction.ID.String(), bidderID, "Is lot 12 still available?")
+		assert.ErrorIs(t, err, ErrChatDisabled)
+	})
+
+	t.Run("Auction not active", func(t *testing.T) {
+		mockRepo := new(MockAuctionRepository)
+		service := NewChatService(nil, mockRepo, nil, nil)
+
+		auction := &domain.Auction{ID: uuid.New(), Status: domain.AuctionStatusEnded, ChatEnabled: true}
+		mockRepo.On("FindByID", auction.ID.String()).Return(auction, nil)
+
+		_, err := service.SendQuestion(auction.ID.String(), bidderID, "Is lot 12 still available?")
+		assert.ErrorIs(t, err, ErrAuctionNotActive)
+	})
+
+	t.Run("Auction not found", func(t *testing.T) {
+		mockRepo := new(MockAuctionRepository)
+		service := NewChatService(nil, mockRepo, nil, nil)
+
+		auctionID := uuid.New().String()
+		mockRepo.On("FindByID", auctionID).Return(nil, nil)
+
+		_, err := service.SendQuestion(auctionID, bidderID, "hello")
+		assert.ErrorIs(t, err, ErrAuctionNotFound)
+	})
+}
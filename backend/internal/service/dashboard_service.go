@@ -54,6 +54,13 @@ func (s *DashboardService) GetActivities(role domain.AdminRole) (*domain.Dashboa
 			return nil, fmt.Errorf("failed to get new bidders: %w", err)
 		}
 		activities.NewBidders = newBidders
+
+		// Get point grants awaiting a second admin's approval (only system_admin can see)
+		pendingGrants, err := s.dashboardRepo.GetPendingPointGrants(5)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pending point grants: %w", err)
+		}
+		activities.PendingPointGrants = pendingGrants
 	}
 
 	return activities, nil
@@ -41,4 +41,40 @@ var (
 	ErrItemNotAssigned        = errors.New("item is not assigned to any auction")
 	ErrItemNotInAuction       = errors.New("item is not in this auction")
 	ErrAuctionAlreadyStarted  = errors.New("auction has already started")
+	ErrStaleFenceToken        = errors.New("item was modified by another operation, please try again")
+)
+
+// WebSocket ticket errors
+var (
+	ErrInvalidTicket = errors.New("invalid or expired ticket")
+)
+
+// Auction chat errors
+var (
+	ErrInvalidMessage  = errors.New("message is empty or too long")
+	ErrChatDisabled    = errors.New("bidder messages are disabled for this auction")
+	ErrChatMuted       = errors.New("bidder is muted in this auction")
+	ErrMessageNotFound = errors.New("message not found")
+)
+
+// Point grant batch errors
+var (
+	ErrInvalidPointGrantCSV           = errors.New("invalid point grant CSV")
+	ErrEmptyPointGrantBatch           = errors.New("point grant batch has no rows")
+	ErrTooManyPointGrantRows          = errors.New("point grant batch has too many rows")
+	ErrPointGrantBatchInvalid         = errors.New("point grant batch has invalid rows")
+	ErrPointGrantBatchNotFound        = errors.New("point grant batch not found")
+	ErrPointGrantBatchAlreadyReversed = errors.New("point grant batch is already reversed")
+	ErrPointGrantReversalInsufficient = errors.New("bidder does not have enough available points to reverse the grant")
+)
+
+// Point grant request errors
+var (
+	ErrPointGrantRequestNotFound      = errors.New("point grant request not found")
+	ErrPointGrantRequestNotPending    = errors.New("point grant request is not pending")
+	ErrSelfApproval                   = errors.New("point grant request must be decided by another admin")
+	ErrPointGrantRequestExpired       = errors.New("point grant request expiry has passed")
+	ErrPointGrantBidderNotActive      = errors.New("bidder of the point grant request is not active")
+	ErrInvalidPointGrantRequestStatus = errors.New("invalid point grant request status")
+	ErrInvalidDecisionNote            = errors.New("decision note is too long")
 )
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"gorm.io/gorm"
+)
+
+// checkFenceToken advances the item's fence token from token, the value read under the item lock,
+// before the operation writes. Fails with ErrStaleFenceToken when another operation has written
+// to the item since; the caller's writes are then rolled back.
+func checkFenceToken(auctionRepo repository.AuctionRepositoryInterface, itemID uuid.UUID, token int64, tx *gorm.DB) error {
+	advanced, err := auctionRepo.AdvanceFenceToken(itemID, token, tx)
+	if err != nil {
+		return fmt.Errorf("failed to advance fence token: %w", err)
+	}
+	if !advanced {
+		return ErrStaleFenceToken
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCheckFenceToken(t *testing.T) {
+	itemID := uuid.New()
+
+	t.Run("Token still current", func(t *testing.T) {
+		repo := new(MockAuctionRepository)
+		repo.On("AdvanceFenceToken", itemID, int64(3), (*gorm.DB)(nil)).Return(true, nil)
+
+		assert.NoError(t, checkFenceToken(repo, itemID, 3, nil))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Stale writer is rejected", func(t *testing.T) {
+		repo := new(MockAuctionRepository)
+		repo.On("AdvanceFenceToken", itemID, int64(3), (*gorm.DB)(nil)).Return(false, nil)
+
+		err := checkFenceToken(repo, itemID, 3, nil)
+		assert.ErrorIs(t, err, ErrStaleFenceToken)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo := new(MockAuctionRepository)
+		repo.On("AdvanceFenceToken", itemID, int64(3), (*gorm.DB)(nil)).Return(false, errors.New("db down"))
+
+		err := checkFenceToken(repo, itemID, 3, nil)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrStaleFenceToken)
+	})
+}
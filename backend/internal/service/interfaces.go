@@ -1,6 +1,10 @@
 package service
 
-import "github.com/tsutsumi389/real-time-auction/internal/domain"
+import (
+	"time"
+
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
 
 // AuthServiceInterface defines the interface for authentication service operations
 type AuthServiceInterface interface {
@@ -30,8 +34,9 @@ type BidderServiceInterface interface {
 	GetBidderByID(id string) (*domain.Bidder, error)
 	GetBidderDetail(id string) (*domain.BidderDetailResponse, error)
 	GetBidderList(req *domain.BidderListRequest) (*domain.BidderListResponse, error)
-	GrantPoints(bidderID string, points int64, adminID int64) (*domain.GrantPointsResponse, error)
-	GetPointHistory(bidderID string, page int, limit int) (*domain.PointHistoryListResponse, error)
+	GrantPoints(bidderID string, points int64, adminID int64, expiresAt *time.Time) (*domain.GrantPointsResponse, error)
+	AdjustPoints(bidderID string, amount int64, reason string, adminID int64) (*domain.AdjustPointsResponse, error)
+	GetPointHistory(bidderID string, filter *domain.PointHistoryFilter, page int, limit int) (*domain.PointHistoryListResponse, error)
 	UpdateBidderStatus(id string, status domain.BidderStatus) (*domain.Bidder, error)
 	UpdateBidder(id string, req *domain.BidderUpdateRequest) (*domain.BidderDetailResponse, error)
 }
@@ -57,6 +62,9 @@ type AuctionServiceInterface interface {
 	GetBidHistory(itemID string, limit int, offset int) (*domain.BidHistoryResponse, error)
 	GetPriceHistory(itemID string) (*domain.PriceHistoryResponse, error)
 	GetParticipants(auctionID string) (*domain.ParticipantsResponse, error)
+	GetBidLatency(auctionID string) (*domain.AuctionBidLatencyResponse, error)
+	GetBidQueue(itemID string) (*domain.BidQueueResponse, error)
+	GetLiveState(auctionID string) (*domain.AuctionLiveState, error)
 
 	// Edit operations
 	GetAuctionForEdit(id string) (*domain.AuctionEditResponse, error)
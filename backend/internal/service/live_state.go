@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+)
+
+// liveStateTTL bounds how long a cached snapshot survives without being refreshed
+const liveStateTTL = 6 * time.Hour
+
+// liveStateKey returns the Redis key holding an auction's live-state snapshot
+func liveStateKey(auctionID string) string {
+	return fmt.Sprintf("auction:live:%s", auctionID)
+}
+
+// buildLiveState assembles the live-state snapshot of an auction from the database.
+// Returns nil when the auction does not exist.
+func buildLiveState(ctx context.Context, redisClient *redis.Client, auctionRepo repository.AuctionRepositoryInterface, bidRepo *repository.BidRepository, auctionID string) (*domain.AuctionLiveState, error) {
+	auction, err := auctionRepo.FindAuctionWithItems(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if auction == nil {
+		return nil, nil
+	}
+
+	state := &domain.AuctionLiveState{
+		AuctionID: auction.ID,
+		Title:     auction.Title,
+		Status:    auction.Status,
+		Progress:  domain.LotProgress{TotalLots: len(auction.Items)},
+		UpdatedAt: time.Now(),
+	}
+
+	// The current item is the active one, or the next pending lot when between items
+	var current *domain.ItemWithStatus
+	for i := range auction.Items {
+		item := &auction.Items[i]
+		switch item.Status {
+		case domain.ItemStatusEnded:
+			state.Progress.CompletedLots++
+		case domain.ItemStatusActive:
+			current = item
+		case domain.ItemStatusPending:
+			if current == nil || (current.Status == domain.ItemStatusPending && item.LotNumber < current.LotNumber) {
+				current = item
+			}
+		}
+	}
+	state.Progress.RemainingLots = state.Progress.TotalLots - state.Progress.CompletedLots
+
+	if current == nil {
+		return state, nil
+	}
+
+	state.CurrentItem = &domain.LiveItemState{
+		ID:            current.ID,
+		Name:          current.Name,
+		LotNumber:     current.LotNumber,
+		Status:        current.Status,
+		StartingPrice: current.StartingPrice,
+		CurrentPrice:  current.CurrentPrice,
+		StartedAt:     current.StartedAt,
+	}
+	state.Progress.CurrentLot = current.LotNumber
+
+	if current.Status != domain.ItemStatusActive || bidRepo == nil {
+		return state, nil
+	}
+
+	winningBid, err := bidRepo.FindWinningBidByItemID(current.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if winningBid != nil {
+		bidderID := winningBid.BidderID
+		state.WinningBid = &domain.LiveWinningBid{
+			BidderID: &bidderID,
+			Price:    winningBid.Price,
+			BidAt:    winningBid.BidAt,
+			// Points stay reserved for the winning bid until the item ends
+			PointsReserved: true,
+		}
+		if paddleNumber, err := AssignPaddleNumber(ctx, redisClient, auctionID, bidderID.String()); err == nil {
+			state.WinningBid.PaddleNumber = paddleNumber
+		}
+	}
+
+	return state, nil
+}
+
+// refreshLiveState rebuilds an auction's live-state snapshot and stores it in Redis.
+// Called after every state change so readers never see a stale snapshot; failures only
+// drop the cached copy and the next read rebuilds it.
+func refreshLiveState(ctx context.Context, redisClient *redis.Client, auctionRepo repository.AuctionRepositoryInterface, bidRepo *repository.BidRepository, auctionID string) {
+	if redisClient == nil {
+		return
+	}
+
+	state, err := buildLiveState(ctx, redisClient, auctionRepo, bidRepo, auctionID)
+	if err == nil && state != nil {
+		err = cacheLiveState(ctx, redisClient, state)
+	}
+	if err != nil {
+		log.Printf("Failed to refresh live state: auctionID=%s, err=%v", auctionID, err)
+		_ = redisClient.Del(ctx, liveStateKey(auctionID)).Err()
+	}
+}
+
+// cacheLiveState stores a live-state snapshot in Redis
+func cacheLiveState(ctx context.Context, redisClient *redis.Client, state *domain.AuctionLiveState) error {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal live state: %w", err)
+	}
+	if err := redisClient.Set(ctx, liveStateKey(state.AuctionID.String()), payload, liveStateTTL).Err(); err != nil {
+		return fmt.Errorf("failed to cache live state: %w", err)
+	}
+	return nil
+}
+
+// GetLiveState returns the live-state snapshot of an auction, served from Redis when cached
+func (s *AuctionService) GetLiveState(auctionID string) (*domain.AuctionLiveState, error) {
+	if _, err := uuid.Parse(auctionID); err != nil {
+		return nil, ErrAuctionNotFound
+	}
+
+	if s.redisClient != nil {
+		payload, err := s.redisClient.Get(s.ctx, liveStateKey(auctionID)).Bytes()
+		if err == nil {
+			var state domain.AuctionLiveState
+			if err := json.Unmarshal(payload, &state); err == nil {
+				return &state, nil
+			}
+		} else if !errors.Is(err, redis.Nil) {
+			log.Printf("Failed to read live state cache: auctionID=%s, err=%v", auctionID, err)
+		}
+	}
+
+	state, err := buildLiveState(s.ctx, s.redisClient, s.auctionRepo, s.bidRepo, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, ErrAuctionNotFound
+	}
+
+	if s.redisClient != nil {
+		if err := cacheLiveState(s.ctx, s.redisClient, state); err != nil {
+			log.Printf("Failed to cache live state: auctionID=%s, err=%v", auctionID, err)
+		}
+	}
+
+	return state, nil
+}
+
+// refreshLiveState rebuilds the cached live-state snapshot after a state change
+func (s *AuctionService) refreshLiveState(auctionID string) {
+	refreshLiveState(s.ctx, s.redisClient, s.auctionRepo, s.bidRepo, auctionID)
+}
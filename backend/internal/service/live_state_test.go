@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
+
+func TestGetLiveState_BuildsSnapshot(t *testing.T) {
+	mockRepo := new(MockAuctionRepository)
+	service := NewAuctionService(nil, mockRepo, nil, nil, nil)
+
+	auctionID := uuid.New()
+	startingPrice := int64(1000)
+	currentPrice := int64(1500)
+	startedAt := time.Now()
+	endedAt := startedAt.Add(-time.Minute)
+
+	item := func(lot int, started, ended *time.Time) domain.ItemWithStatus {
+		i := domain.Item{ID: uuid.New(), Name: "Item", LotNumber: lot, StartingPrice: &startingPrice, StartedAt: started, EndedAt: ended}
+		return i.ToItemWithStatus()
+	}
+	active := item(2, &startedAt, nil)
+	active.CurrentPrice = &currentPrice
+
+	mockRepo.On("FindAuctionWithItems", auctionID.String()).Return(&domain.GetAuctionDetailResponse{
+		ID:     auctionID,
+		Title:  "Spring Auction",
+		Status: domain.AuctionStatusActive,
+		Items:  []domain.ItemWithStatus{item(1, &endedAt, &endedAt), active, item(3, nil, nil)},
+	}, nil)
+
+	state, err := service.GetLiveState(auctionID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, auctionID, state.AuctionID)
+	require.NotNil(t, state.CurrentItem)
+	assert.Equal(t, active.ID, state.CurrentItem.ID)
+	assert.Equal(t, domain.ItemStatusActive, state.CurrentItem.Status)
+	assert.Equal(t, &currentPrice, state.CurrentItem.CurrentPrice)
+	assert.Equal(t, domain.LotProgress{TotalLots: 3, CompletedLots: 1, RemainingLots: 2, CurrentLot: 2}, state.Progress)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetLiveState_SelectsNextPendingLot(t *testing.T) {
+	mockRepo := new(MockAuctionRepository)
+	service := NewAuctionService(nil, mockRepo, nil, nil, nil)
+
+	auctionID := uuid.New()
+	items := []domain.ItemWithStatus{
+		(&domain.Item{ID: uuid.New(), LotNumber: 2}).ToItemWithStatus(),
+		(&domain.Item{ID: uuid.New(), LotNumber: 1}).ToItemWithStatus(),
+	}
+	mockRepo.On("FindAuctionWithItems", auctionID.String()).Return(&domain.GetAuctionDetailResponse{
+		ID:     auctionID,
+		Status: domain.AuctionStatusActive,
+		Items:  items,
+	}, nil)
+
+	state, err := service.GetLiveState(auctionID.String())
+
+	require.NoError(t, err)
+	require.NotNil(t, state.CurrentItem)
+	assert.Equal(t, items[1].ID, state.CurrentItem.ID)
+	assert.Nil(t, state.WinningBid)
+}
+
+func TestGetLiveState_NotFound(t *testing.T) {
+	mockRepo := new(MockAuctionRepository)
+	service := NewAuctionService(nil, mockRepo, nil, nil, nil)
+
+	auctionID := uuid.New()
+	mockRepo.On("FindAuctionWithItems", auctionID.String()).Return(nil, nil)
+
+	_, err := service.GetLiveState(auctionID.String())
+	assert.ErrorIs(t, err, ErrAuctionNotFound)
+
+	_, err = service.GetLiveState("not-a-uuid")
+	assert.ErrorIs(t, err, ErrAuctionNotFound)
+}
+
+func TestAuctionLiveState_ForViewer(t *testing.T) {
+	winner := uuid.New()
+	other := uuid.New()
+	state := &domain.AuctionLiveState{
+		WinningBid: &domain.LiveWinningBid{BidderID: &winner, PaddleNumber: 7, Price: 1500},
+	}
+
+	admin := state.ForViewer(nil, true)
+	assert.Equal(t, &winner, admin.WinningBid.BidderID)
+	assert.Equal(t, int64(7), admin.WinningBid.PaddleNumber)
+
+	mine := state.ForViewer(&winner, false)
+	assert.Nil(t, mine.WinningBid.BidderID)
+	assert.True(t, mine.WinningBid.IsMine)
+	assert.Equal(t, int64(7), mine.WinningBid.PaddleNumber)
+
+	public := state.ForViewer(&other, false)
+	assert.Nil(t, public.WinningBid.BidderID)
+	assert.False(t, public.WinningBid.IsMine)
+	assert.Equal(t, int64(7), public.WinningBid.PaddleNumber)
+
+	// Spectators and the unauthenticated live endpoint see neither the bidder nor the paddle number
+	spectator := state.ForViewer(nil, false)
+	assert.Nil(t, spectator.WinningBid.BidderID)
+	assert.False(t, spectator.WinningBid.IsMine)
+	assert.Zero(t, spectator.WinningBid.PaddleNumber)
+	assert.Equal(t, int64(1500), spectator.WinningBid.Price)
+
+	// The cached state is not modified
+	assert.Equal(t, &winner, state.WinningBid.BidderID)
+	assert.Equal(t, int64(7), state.WinningBid.PaddleNumber)
+}
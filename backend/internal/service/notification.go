@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/events"
+)
+
+// publishBidderNotification publishes a notification addressed to a single bidder
+func publishBidderNotification(ctx context.Context, redisClient *redis.Client, bidderID string, payload events.Payload) error {
+	return events.PublishToBidder(ctx, redisClient, bidderID, payload)
+}
+
+// pointsUpdatedEvent builds the points:updated notification for a bidder's balance
+func pointsUpdatedEvent(points *domain.BidderPoints) events.PointsUpdated {
+	return events.PointsUpdated{
+		Points: events.PointsBalance{
+			BidderID:        points.BidderID,
+			TotalPoints:     points.TotalPoints,
+			AvailablePoints: points.AvailablePoints,
+			ReservedPoints:  points.ReservedPoints,
+			UpdatedAt:       points.UpdatedAt,
+		},
+	}
+}
+
+// timeOrNow dereferences an optional timestamp, falling back to the current time
+func timeOrNow(t *time.Time) time.Time {
+	if t == nil {
+		return time.Now()
+	}
+	return *t
+}
+
+// int64OrZero dereferences an optional integer
+func int64OrZero(n *int64) int64 {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AssignPaddleNumber returns the bidder's paddle number within an auction, assigning the next
+// sequential number on first use. Paddle numbers let other bidders follow the bidding without
+// seeing bidder identities, and are stable across WebSocket server instances.
+func AssignPaddleNumber(ctx context.Context, redisClient *redis.Client, auctionID, bidderID string) (int64, error) {
+	if redisClient == nil {
+		return 0, nil
+	}
+
+	paddlesKey := fmt.Sprintf("auction:paddles:%s", auctionID)
+
+	paddle, err := redisClient.HGet(ctx, paddlesKey, bidderID).Int64()
+	if err == nil {
+		return paddle, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return 0, fmt.Errorf("failed to get paddle number: %w", err)
+	}
+
+	next, err := redisClient.Incr(ctx, fmt.Sprintf("auction:paddle_seq:%s", auctionID)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate paddle number: %w", err)
+	}
+
+	assigned, err := redisClient.HSetNX(ctx, paddlesKey, bidderID, next).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to assign paddle number: %w", err)
+	}
+	if assigned {
+		return next, nil
+	}
+
+	// Another request assigned a number concurrently; use that one
+	paddle, err = redisClient.HGet(ctx, paddlesKey, bidderID).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get paddle number: %w", err)
+	}
+	return paddle, nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"gorm.io/gorm"
+)
+
+// PointExpiryService expires the unused points of grant lots past their expiry
+type PointExpiryService struct {
+	db        *gorm.DB
+	pointRepo *repository.PointRepository
+}
+
+// NewPointExpiryService creates a new PointExpiryService instance
+func NewPointExpiryService(db *gorm.DB, pointRepo *repository.PointRepository) *PointExpiryService {
+	return &PointExpiryService{
+		db:        db,
+		pointRepo: pointRepo,
+	}
+}
+
+// ExpirePoints expires the unused points of every lot that expired at or before now and returns
+// the expire history entries it recorded. Each bidder is processed in its own transaction; a bidder
+// that fails is logged and skipped so that the others still expire.
+func (s *PointExpiryService) ExpirePoints(now time.Time) ([]domain.PointHistory, error) {
+	bidderIDs, err := s.pointRepo.FindBiddersWithDueLots(now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due point lots: %w", err)
+	}
+
+	histories := []domain.PointHistory{}
+	for _, bidderID := range bidderIDs {
+		history, err := s.expireBidder(bidderID, now)
+		if err != nil {
+			log.Printf("Failed to expire points: bidderID=%s, err=%v", bidderID, err)
+			continue
+		}
+		if history != nil {
+			histories = append(histories, *history)
+		}
+	}
+
+	return histories, nil
+}
+
+// expireBidder closes a bidder's due lots under the points row lock and deducts their unused points
+// from the available points. Returns nil when none of the due points were unused.
+func (s *PointExpiryService) expireBidder(bidderID string, now time.Time) (*domain.PointHistory, error) {
+	var history *domain.PointHistory
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		locked, err := s.pointRepo.GetPointsForUpdate([]string{bidderID}, tx)
+		if err != nil {
+			return err
+		}
+		current := locked[bidderID]
+		if current == nil {
+			return ErrPointsNotFound
+		}
+
+		lots, err := s.pointRepo.FindOpenLots(bidderID, tx)
+		if err != nil {
+			return err
+		}
+
+		expired, total := planLotExpiry(lots, *current, now)
+		for i, lot := range lots {
+			if lot.ExpiresAt != nil && !lot.ExpiresAt.After(now) {
+				if err := s.pointRepo.CloseLot(lot.ID, expired[i], now, tx); err != nil {
+					return err
+				}
+			}
+		}
+
+		if total == 0 {
+			return nil
+		}
+
+		if err := s.pointRepo.UpdatePoints(bidderID, -total, 0, tx); err != nil {
+			return err
+		}
+
+		reason := "Points expired"
+		history = &domain.PointHistory{
+			BidderID:       bidderID,
+			Amount:         -total,
+			Type:           domain.PointHistoryTypeExpire,
+			Reason:         &reason,
+			BalanceBefore:  current.AvailablePoints,
+			BalanceAfter:   current.AvailablePoints - total,
+			ReservedBefore: current.ReservedPoints,
+			ReservedAfter:  current.ReservedPoints,
+			TotalBefore:    current.TotalPoints,
+			TotalAfter:     current.TotalPoints - total,
+		}
+		return s.pointRepo.CreatePointHistory(history, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// unusedLotPoints returns the unused points of each lot (ordered oldest first): its amount minus
+// the points spent from it. Reserved points are still unused. The total never exceeds the bidder's
+// balance; should it, the balance is attributed to the newest lots first.
+func unusedLotPoints(lots []domain.PointLot, points domain.BidderPoints) []int64 {
+	unused := make([]int64, len(lots))
+	balance := points.AvailablePoints + points.ReservedPoints
+
+	for i := len(lots) - 1; i >= 0 && balance > 0; i-- {
+		unused[i] = min(lots[i].Amount-lots[i].SpentAmount, balance)
+		balance -= unused[i]
+	}
+	return unused
+}
+
+// planLotExpiry returns the points to expire from each lot and their total. Reserved points are
+// attributed to the unused points of the oldest lots first and never expire; only lots that
+// expired at or before now lose the rest of their unused points. The total never exceeds the
+// available points.
+func planLotExpiry(lots []domain.PointLot, points domain.BidderPoints, now time.Time) ([]int64, int64) {
+	unused := unusedLotPoints(lots, points)
+	reserved := points.ReservedPoints
+	for i := range unused {
+		held := min(unused[i], reserved)
+		unused[i] -= held
+		reserved -= held
+	}
+
+	expired := make([]int64, len(lots))
+	remaining := points.AvailablePoints
+	var total int64
+
+	for i, lot := range lots {
+		if lot.ExpiresAt == nil || lot.ExpiresAt.After(now) {
+			continue
+		}
+		expired[i] = min(unused[i], remaining)
+		remaining -= expired[i]
+		total += expired[i]
+	}
+	return expired, total
+}
+
+// upcomingPointExpiries returns the unused points of the lots that expire after now, grouped by
+// expiry time, soonest first
+func upcomingPointExpiries(lots []domain.PointLot, points domain.BidderPoints, now time.Time) []domain.PointExpiry {
+	unused := unusedLotPoints(lots, points)
+	byTime := map[time.Time]int64{}
+
+	for i, lot := range lots {
+		if lot.ExpiresAt == nil || !lot.ExpiresAt.After(now) || unused[i] == 0 {
+			continue
+		}
+		byTime[lot.ExpiresAt.UTC()] += unused[i]
+	}
+
+	expiries := make([]domain.PointExpiry, 0, len(byTime))
+	for expiresAt, points := range byTime {
+		expiries = append(expiries, domain.PointExpiry{ExpiresAt: expiresAt, Points: points})
+	}
+	sort.Slice(expiries, func(i, j int) bool {
+		return expiries[i].ExpiresAt.Before(expiries[j].ExpiresAt)
+	})
+	return expiries
+}
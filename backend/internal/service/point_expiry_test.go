@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
+
+func TestPlanLotExpiry(t *testing.T) {
+	now := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	t.Run("Spent points are taken from the oldest lots first", func(t *testing.T) {
+		lots := []domain.PointLot{
+			{ID: 1, Amount: 1000, SpentAmount: 1000, ExpiresAt: &past},
+			{ID: 2, Amount: 1000, SpentAmount: 500, ExpiresAt: &past},
+			{ID: 3, Amount: 1000},
+		}
+		// 1500 of the 3000 granted points were spent: lot 1 fully, lot 2 half
+		points := domain.BidderPoints{AvailablePoints: 1500, TotalPoints: 1500}
+
+		expired, total := planLotExpiry(lots, points, now)
+
+		assert.Equal(t, []int64{0, 500, 0}, expired)
+		assert.Equal(t, int64(500), total)
+	})
+
+	t.Run("Credits without a lot are not unused points of a lot", func(t *testing.T) {
+		lots := []domain.PointLot{
+			{ID: 1, Amount: 1000, SpentAmount: 400, ExpiresAt: &past},
+		}
+		// 400 spent, then 300 refunded and 500 credited by a positive adjustment
+		points := domain.BidderPoints{AvailablePoints: 1400, TotalPoints: 1400}
+
+		expired, total := planLotExpiry(lots, points, now)
+
+		assert.Equal(t, []int64{600}, expired)
+		assert.Equal(t, int64(600), total)
+	})
+
+	t.Run("Reserved points are never expired", func(t *testing.T) {
+		lots := []domain.PointLot{
+			{ID: 1, Amount: 1000, ExpiresAt: &past},
+		}
+		points := domain.BidderPoints{AvailablePoints: 200, ReservedPoints: 800, TotalPoints: 1000}
+
+		expired, total := planLotExpiry(lots, points, now)
+
+		assert.Equal(t, []int64{200}, expired)
+		assert.Equal(t, int64(200), total)
+	})
+
+	t.Run("Reserved points held by an expiring lot do not expire newer lots", func(t *testing.T) {
+		lots := []domain.PointLot{
+			{ID: 1, Amount: 1000, ExpiresAt: &past},
+			{ID: 2, Amount: 1000, ExpiresAt: &future},
+		}
+		// The 1000 reserved points are held by the oldest lot, which has expired
+		points := domain.BidderPoints{AvailablePoints: 1000, ReservedPoints: 1000, TotalPoints: 2000}
+
+		expired, total := planLotExpiry(lots, points, now)
+
+		assert.Equal(t, []int64{0, 0}, expired)
+		assert.Equal(t, int64(0), total)
+	})
+
+	t.Run("Reserved points are attributed to the oldest lots first", func(t *testing.T) {
+		lots := []domain.PointLot{
+			{ID: 1, Amount: 500},
+			{ID: 2, Amount: 1000, ExpiresAt: &past},
+			{ID: 3, Amount: 1000, ExpiresAt: &future},
+		}
+		// 800 reserved: all 500 of lot 1, then 300 of lot 2
+		points := domain.BidderPoints{AvailablePoints: 1700, ReservedPoints: 800, TotalPoints: 2500}
+
+		expired, total := planLotExpiry(lots, points, now)
+
+		assert.Equal(t, []int64{0, 700, 0}, expired)
+		assert.Equal(t, int64(700), total)
+	})
+
+	t.Run("Lots that have not expired are kept", func(t *testing.T) {
+		lots := []domain.PointLot{
+			{ID: 1, Amount: 1000, ExpiresAt: &future},
+		}
+		points := domain.BidderPoints{AvailablePoints: 1000, TotalPoints: 1000}
+
+		_, total := planLotExpiry(lots, points, now)
+
+		assert.Equal(t, int64(0), total)
+	})
+}
+
+func TestUpcomingPointExpiries(t *testing.T) {
+	now := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	soon := now.Add(24 * time.Hour)
+	later := now.Add(30 * 24 * time.Hour)
+
+	lots := []domain.PointLot{
+		{ID: 1, Amount: 1000, SpentAmount: 800, ExpiresAt: &soon},
+		{ID: 2, Amount: 500, ExpiresAt: &later},
+		{ID: 3, Amount: 300, ExpiresAt: &soon},
+		{ID: 4, Amount: 200},
+	}
+	// 800 of the 2000 granted points were spent, all from lot 1
+	points := domain.BidderPoints{AvailablePoints: 1000, ReservedPoints: 200, TotalPoints: 1200}
+
+	expiries := upcomingPointExpiries(lots, points, now)
+
+	assert.Equal(t, []domain.PointExpiry{
+		{ExpiresAt: soon, Points: 500},
+		{ExpiresAt: later, Points: 500},
+	}, expiries)
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	MaxPointGrantBatchRows = 1000 // Maximum number of rows in a bulk point grant
+)
+
+// PointGrantBatchService handles bulk point grants and their reversal
+type PointGrantBatchService struct {
+	db        *gorm.DB
+	batchRepo *repository.PointGrantBatchRepository
+	pointRepo *repository.PointRepository
+
+	grantApprovalThreshold int64
+}
+
+// NewPointGrantBatchService creates a new PointGrantBatchService instance
+func NewPointGrantBatchService(db *gorm.DB, batchRepo *repository.PointGrantBatchRepository, pointRepo *repository.PointRepository) *PointGrantBatchService {
+	return &PointGrantBatchService{
+		db:        db,
+		batchRepo: batchRepo,
+		pointRepo: pointRepo,
+	}
+}
+
+// SetGrantApprovalThreshold sets the per-row points above which a grant requires a second admin's
+// approval. Such rows are rejected here and must be granted individually. Zero disables the check.
+func (s *PointGrantBatchService) SetGrantApprovalThreshold(threshold int64) {
+	s.grantApprovalThreshold = threshold
+}
+
+// ParsePointGrantCSV parses bulk point grant rows from CSV.
+// The header row must name the bidder, points and reason columns, in any order. An optional
+// expires_at column holds an RFC 3339 time or a date (expiring at its start in UTC); empty means
+// the points never expire.
+func ParsePointGrantCSV(r io.Reader) ([]domain.PointGrantRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %v", ErrInvalidPointGrantCSV, err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"bidder", "points", "reason"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", ErrInvalidPointGrantCSV, name)
+		}
+	}
+
+	var rows []domain.PointGrantRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPointGrantCSV, err)
+		}
+
+		pointsValue := strings.TrimSpace(record[columns["points"]])
+		points, err := strconv.ParseInt(pointsValue, 10, 64)
+		if err != nil {
+			line, _ := reader.FieldPos(columns["points"])
+			return nil, fmt.Errorf("%w: line %d: invalid points %q", ErrInvalidPointGrantCSV, line, pointsValue)
+		}
+
+		row := domain.PointGrantRow{
+			Bidder: record[columns["bidder"]],
+			Points: points,
+			Reason: record[columns["reason"]],
+		}
+
+		if i, ok := columns["expires_at"]; ok {
+			if expiresValue := strings.TrimSpace(record[i]); expiresValue != "" {
+				expiresAt, err := parsePointExpiry(expiresValue)
+				if err != nil {
+					line, _ := reader.FieldPos(i)
+					return nil, fmt.Errorf("%w: line %d: invalid expires_at %q", ErrInvalidPointGrantCSV, line, expiresValue)
+				}
+				row.ExpiresAt = &expiresAt
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// Preview validates a bulk point grant without applying it
+func (s *PointGrantBatchService) Preview(rows []domain.PointGrantRow) (*domain.PointGrantBatchResult, error) {
+	if err := checkPointGrantRowCount(rows); err != nil {
+		return nil, err
+	}
+
+	bidders, err := s.batchRepo.FindBiddersByIdentifiers(pointGrantIdentifiers(rows), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bidders: %w", err)
+	}
+
+	result := validatePointGrantRows(rows, bidders, time.Now(), s.grantApprovalThreshold)
+	result.DryRun = true
+	return result, nil
+}
+
+// Apply validates a bulk point grant and applies every row in one transaction under a new batch ID.
+// When a row is invalid nothing is applied and the validation result is returned with
+// ErrPointGrantBatchInvalid.
+func (s *PointGrantBatchService) Apply(rows []domain.PointGrantRow, adminID int64) (*domain.PointGrantBatchResult, error) {
+	if err := checkPointGrantRowCount(rows); err != nil {
+		return nil, err
+	}
+
+	var result *domain.PointGrantBatchResult
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		bidders, err := s.batchRepo.FindBiddersByIdentifiers(pointGrantIdentifiers(rows), tx)
+		if err != nil {
+			return fmt.Errorf("failed to find bidders: %w", err)
+		}
+
+		result = validatePointGrantRows(rows, bidders, time.Now(), s.grantApprovalThreshold)
+		if !result.Valid {
+			return ErrPointGrantBatchInvalid
+		}
+
+		bidderIDs := make([]string, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			bidderIDs = append(bidderIDs, *row.BidderID)
+		}
+		locked, err := s.pointRepo.GetPointsForUpdate(bidderIDs, tx)
+		if err != nil {
+			return fmt.Errorf("failed to lock points: %w", err)
+		}
+
+		batch := &domain.PointGrantBatch{
+			AdminID:     &adminID,
+			RowCount:    result.RowCount,
+			TotalPoints: result.TotalPoints,
+			Status:      domain.PointGrantBatchStatusApplied,
+		}
+		if err := s.batchRepo.CreateBatch(batch, tx); err != nil {
+			return fmt.Errorf("failed to create batch: %w", err)
+		}
+
+		for _, row := range result.Rows {
+			current := locked[*row.BidderID]
+			if current == nil {
+				return ErrPointsNotFound
+			}
+
+			reason := row.Reason
+			history := &domain.PointHistory{
+				BidderID:       current.BidderID,
+				Amount:         row.Points,
+				Type:           domain.PointHistoryTypeGrant,
+				Reason:         &reason,
+				AdminID:        &adminID,
+				BatchID:        &batch.ID,
+				BalanceBefore:  current.AvailablePoints,
+				BalanceAfter:   current.AvailablePoints + row.Points,
+				ReservedBefore: current.ReservedPoints,
+				ReservedAfter:  current.ReservedPoints,
+				TotalBefore:    current.TotalPoints,
+				TotalAfter:     current.TotalPoints + row.Points,
+			}
+			if err := s.applyHistory(history, tx); err != nil {
+				return err
+			}
+			current.AvailablePoints = history.BalanceAfter
+			current.TotalPoints = history.TotalAfter
+
+			lot := &domain.PointLot{
+				BidderID:  current.BidderID,
+				HistoryID: &history.ID,
+				Amount:    row.Points,
+				ExpiresAt: row.ExpiresAt,
+			}
+			if err := s.pointRepo.CreateLot(lot, tx); err != nil {
+				return fmt.Errorf("failed to create point lot: %w", err)
+			}
+		}
+
+		result.Batch = batch
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrPointGrantBatchInvalid) {
+			return result, err
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetBatch retrieves a bulk point grant with the point history entries it created
+func (s *PointGrantBatchService) GetBatch(batchID string) (*domain.PointGrantBatchDetail, error) {
+	batch, err := s.batchRepo.FindBatchByID(batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find batch: %w", err)
+	}
+	if batch == nil {
+		return nil, ErrPointGrantBatchNotFound
+	}
+
+	histories, err := s.batchRepo.FindHistoriesByBatchID(batchID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch histories: %w", err)
+	}
+
+	return &domain.PointGrantBatchDetail{
+		Batch:     *batch,
+		Histories: histories,
+	}, nil
+}
+
+// Reverse deducts every grant of a bulk point grant in one transaction, recording adjust entries
+// under the same batch ID. Fails without changes when a bidder no longer has enough available
+// points to return the grant.
+func (s *PointGrantBatchService) Reverse(batchID string, adminID int64) (*domain.PointGrantBatchDetail, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		batch, err := s.batchRepo.FindBatchForUpdate(batchID, tx)
+		if err != nil {
+			return fmt.Errorf("failed to find batch: %w", err)
+		}
+		if batch == nil {
+			return ErrPointGrantBatchNotFound
+		}
+		if batch.Status == domain.PointGrantBatchStatusReversed {
+			return ErrPointGrantBatchAlreadyReversed
+		}
+
+		histories, err := s.batchRepo.FindHistoriesByBatchID(batchID, tx)
+		if err != nil {
+			return fmt.Errorf("failed to get batch histories: %w", err)
+		}
+
+		var grants []domain.PointHistory
+		bidderIDs := make([]string, 0, len(histories))
+		grantIDs := make([]int64, 0, len(histories))
+		for _, h := range histories {
+			if h.Type == domain.PointHistoryTypeGrant {
+				grants = append(grants, h)
+				bidderIDs = append(bidderIDs, h.BidderID)
+				grantIDs = append(grantIDs, h.ID)
+			}
+		}
+
+		locked, err := s.pointRepo.GetPointsForUpdate(bidderIDs, tx)
+		if err != nil {
+			return fmt.Errorf("failed to lock points: %w", err)
+		}
+		lots, err := s.pointRepo.FindLotsByHistoryIDs(grantIDs, tx)
+		if err != nil {
+			return fmt.Errorf("failed to get point lots: %w", err)
+		}
+
+		reason := fmt.Sprintf("Reversal of point grant batch %s", batchID)
+		for _, grant := range grants {
+			current := locked[grant.BidderID]
+			if current == nil {
+				return ErrPointsNotFound
+			}
+			amount := grantReversalAmount(grant, lots)
+			if amount == 0 {
+				continue
+			}
+			if current.AvailablePoints < amount {
+				return fmt.Errorf("%w: bidder %s", ErrPointGrantReversalInsufficient, grant.BidderID)
+			}
+
+			history := &domain.PointHistory{
+				BidderID:       current.BidderID,
+				Amount:         -amount,
+				Type:           domain.PointHistoryTypeAdjust,
+				Reason:         &reason,
+				AdminID:        &adminID,
+				BatchID:        &batch.ID,
+				BalanceBefore:  current.AvailablePoints,
+				BalanceAfter:   current.AvailablePoints - amount,
+				ReservedBefore: current.ReservedPoints,
+				ReservedAfter:  current.ReservedPoints,
+				TotalBefore:    current.TotalPoints,
+				TotalAfter:     current.TotalPoints - amount,
+			}
+			if err := s.applyHistory(history, tx); err != nil {
+				return err
+			}
+			current.AvailablePoints = history.BalanceAfter
+			current.TotalPoints = history.TotalAfter
+		}
+
+		// The reversed points no longer belong to the grant lots
+		if err := s.pointRepo.CloseLotsByHistoryIDs(grantIDs, time.Now(), tx); err != nil {
+			return fmt.Errorf("failed to close point lots: %w", err)
+		}
+
+		return s.batchRepo.MarkBatchReversed(batch, adminID, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetBatch(batchID)
+}
+
+// grantReversalAmount returns the points a reversal deducts for a grant: the granted points minus
+// the points of its lot that have already expired
+func grantReversalAmount(grant domain.PointHistory, lots map[int64]domain.PointLot) int64 {
+	lot, ok := lots[grant.ID]
+	if !ok {
+		return grant.Amount
+	}
+	return lot.Amount - lot.ExpiredAmount
+}
+
+// applyHistory moves a bidder's available and total points by the history amount and records the entry
+func (s *PointGrantBatchService) applyHistory(history *domain.PointHistory, tx *gorm.DB) error {
+	if err := s.pointRepo.UpdatePoints(history.BidderID, history.Amount, 0, tx); err != nil {
+		return fmt.Errorf("failed to update points: %w", err)
+	}
+	if err := s.pointRepo.CreatePointHistory(history, tx); err != nil {
+		return fmt.Errorf("failed to create point history: %w", err)
+	}
+	return nil
+}
+
+// parsePointExpiry parses an RFC 3339 time or a YYYY-MM-DD date
+func parsePointExpiry(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// checkPointGrantRowCount checks that a bulk point grant has between 1 and MaxPointGrantBatchRows rows
+func checkPointGrantRowCount(rows []domain.PointGrantRow) error {
+	if len(rows) == 0 {
+		return ErrEmptyPointGrantBatch
+	}
+	if len(rows) > MaxPointGrantBatchRows {
+		return ErrTooManyPointGrantRows
+	}
+	return nil
+}
+
+// pointGrantIdentifiers returns the trimmed bidder identifiers of the rows
+func pointGrantIdentifiers(rows []domain.PointGrantRow) []string {
+	identifiers := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if identifier := strings.TrimSpace(row.Bidder); identifier != "" {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+	return identifiers
+}
+
+// validatePointGrantRows resolves each row's bidder by ID or email and validates the row.
+// Rows above a positive approvalThreshold are invalid since they require a second admin's approval.
+// A bidder may appear only once per batch so that a pasted duplicate is not granted twice.
+func validatePointGrantRows(rows []domain.PointGrantRow, bidders []domain.Bidder, now time.Time, approvalThreshold int64) *domain.PointGrantBatchResult {
+	byIdentifier := make(map[string]*domain.Bidder, len(bidders)*2)
+	for i := range bidders {
+		byIdentifier[strings.ToLower(bidders[i].ID)] = &bidders[i]
+		byIdentifier[strings.ToLower(bidders[i].Email)] = &bidders[i]
+	}
+
+	result := &domain.PointGrantBatchResult{
+		Valid:    true,
+		RowCount: len(rows),
+		Rows:     make([]domain.PointGrantRowResult, 0, len(rows)),
+	}
+	seen := make(map[string]int)
+
+	for i, row := range rows {
+		identifier := strings.TrimSpace(row.Bidder)
+		rowResult := domain.PointGrantRowResult{
+			Row:       i + 1,
+			Bidder:    identifier,
+			Points:    row.Points,
+			Reason:    strings.TrimSpace(row.Reason),
+			ExpiresAt: row.ExpiresAt,
+			Errors:    []string{},
+		}
+
+		bidder := byIdentifier[strings.ToLower(identifier)]
+		switch {
+		case identifier == "":
+			rowResult.Errors = append(rowResult.Errors, "bidder is required")
+		case bidder == nil:
+			rowResult.Errors = append(rowResult.Errors, "bidder not found")
+		default:
+			rowResult.BidderID = &bidder.ID
+			if bidder.IsDeleted() {
+				rowResult.Errors = append(rowResult.Errors, "bidder is deleted")
+			}
+			if first, ok := seen[bidder.ID]; ok {
+				rowResult.Errors = append(rowResult.Errors, fmt.Sprintf("duplicate bidder (same as row %d)", first))
+			} else {
+				seen[bidder.ID] = rowResult.Row
+			}
+		}
+
+		if row.Points <= 0 {
+			rowResult.Errors = append(rowResult.Errors, "points must be positive")
+		} else if row.Points > MaxPointsPerGrant {
+			rowResult.Errors = append(rowResult.Errors, "points exceed maximum limit")
+		} else if approvalThreshold > 0 && row.Points > approvalThreshold {
+			rowResult.Errors = append(rowResult.Errors, "points exceed approval threshold")
+		}
+
+		if rowResult.Reason == "" {
+			rowResult.Errors = append(rowResult.Errors, "reason is required")
+		} else if utf8.RuneCountInString(rowResult.Reason) > MaxAdjustmentReasonLength {
+			rowResult.Errors = append(rowResult.Errors, "reason is too long")
+		}
+
+		if row.ExpiresAt != nil && !row.ExpiresAt.After(now) {
+			rowResult.Errors = append(rowResult.Errors, "expiry must be in the future")
+		}
+
+		if len(rowResult.Errors) > 0 {
+			result.Valid = false
+		} else {
+			result.TotalPoints += row.Points
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+
+	return result
+}
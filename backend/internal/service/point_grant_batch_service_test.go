@@ -0,0 +1,124 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
+
+func TestParsePointGrantCSV(t *testing.T) {
+	t.Run("Success - Columns in any order", func(t *testing.T) {
+		csv := "reason,Bidder,points\nSpring sale,bidder@example.com,1000\n\"Top up, VIP\",b2@example.com,500\n"
+
+		rows, err := ParsePointGrantCSV(strings.NewReader(csv))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.PointGrantRow{
+			{Bidder: "bidder@example.com", Points: 1000, Reason: "Spring sale"},
+			{Bidder: "b2@example.com", Points: 500, Reason: "Top up, VIP"},
+		}, rows)
+	})
+
+	t.Run("Success - Optional expiry", func(t *testing.T) {
+		csv := "bidder,points,reason,expires_at\na@example.com,1000,Season,2026-12-31\nb@example.com,500,Bonus,\n"
+
+		rows, err := ParsePointGrantCSV(strings.NewReader(csv))
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), *rows[0].ExpiresAt)
+		assert.Nil(t, rows[1].ExpiresAt)
+	})
+
+	t.Run("Error - Missing column", func(t *testing.T) {
+		_, err := ParsePointGrantCSV(strings.NewReader("bidder,points\nbidder@example.com,1000\n"))
+
+		assert.ErrorIs(t, err, ErrInvalidPointGrantCSV)
+	})
+
+	t.Run("Error - Invalid points", func(t *testing.T) {
+		_, err := ParsePointGrantCSV(strings.NewReader("bidder,points,reason\nbidder@example.com,abc,Sale\n"))
+
+		assert.ErrorIs(t, err, ErrInvalidPointGrantCSV)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+}
+
+func TestValidatePointGrantRows(t *testing.T) {
+	bidders := []domain.Bidder{
+		{ID: "11111111-1111-1111-1111-111111111111", Email: "active@example.com", Status: domain.BidderStatusActive},
+		{ID: "22222222-2222-2222-2222-222222222222", Email: "deleted@example.com", Status: domain.BidderStatusDeleted},
+	}
+
+	t.Run("Valid rows", func(t *testing.T) {
+		rows := []domain.PointGrantRow{
+			{Bidder: " Active@Example.com ", Points: 1000, Reason: "Sale"},
+		}
+
+		result := validatePointGrantRows(rows, bidders, time.Now(), 0)
+
+		assert.True(t, result.Valid)
+		assert.Equal(t, int64(1000), result.TotalPoints)
+		assert.Equal(t, bidders[0].ID, *result.Rows[0].BidderID)
+		assert.Empty(t, result.Rows[0].Errors)
+	})
+
+	t.Run("Invalid rows", func(t *testing.T) {
+		rows := []domain.PointGrantRow{
+			{Bidder: "11111111-1111-1111-1111-111111111111", Points: 1000, Reason: "Sale"},
+			{Bidder: "active@example.com", Points: 500, Reason: "Sale"},
+			{Bidder: "deleted@example.com", Points: 100, Reason: "Sale"},
+			{Bidder: "unknown@example.com", Points: 0, Reason: " "},
+			{Bidder: "", Points: MaxPointsPerGrant + 1, Reason: "Sale"},
+		}
+
+		result := validatePointGrantRows(rows, bidders, time.Now(), 0)
+
+		assert.False(t, result.Valid)
+		assert.Equal(t, int64(1000), result.TotalPoints)
+		assert.Empty(t, result.Rows[0].Errors)
+		assert.Equal(t, []string{"duplicate bidder (same as row 1)"}, result.Rows[1].Errors)
+		assert.Equal(t, []string{"bidder is deleted"}, result.Rows[2].Errors)
+		assert.Equal(t, []string{"bidder not found", "points must be positive", "reason is required"}, result.Rows[3].Errors)
+		assert.Nil(t, result.Rows[3].BidderID)
+		assert.Equal(t, []string{"bidder is required", "points exceed maximum limit"}, result.Rows[4].Errors)
+	})
+}
+
+func TestGrantReversalAmount(t *testing.T) {
+	now := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	grant := domain.PointHistory{ID: 10, Amount: 1000, Type: domain.PointHistoryTypeGrant}
+
+	t.Run("Lot expired before the reversal", func(t *testing.T) {
+		historyID := grant.ID
+		lot := domain.PointLot{ID: 1, HistoryID: &historyID, Amount: 1000, SpentAmount: 300, ExpiresAt: &past}
+		points := domain.BidderPoints{AvailablePoints: 1000, TotalPoints: 1000}
+
+		// 300 of the lot were spent and 300 credited without a lot, so 700 expire
+		expired, _ := planLotExpiry([]domain.PointLot{lot}, points, now)
+		lot.ExpiredAmount = expired[0]
+		assert.Equal(t, int64(700), lot.ExpiredAmount)
+
+		amount := grantReversalAmount(grant, map[int64]domain.PointLot{grant.ID: lot})
+
+		assert.Equal(t, int64(300), amount)
+	})
+
+	t.Run("Fully expired lot deducts nothing", func(t *testing.T) {
+		historyID := grant.ID
+		lot := domain.PointLot{ID: 1, HistoryID: &historyID, Amount: 1000, ExpiredAmount: 1000, ClosedAt: &past}
+
+		amount := grantReversalAmount(grant, map[int64]domain.PointLot{grant.ID: lot})
+
+		assert.Equal(t, int64(0), amount)
+	})
+
+	t.Run("Grant without a lot deducts the granted points", func(t *testing.T) {
+		amount := grantReversalAmount(grant, map[int64]domain.PointLot{})
+
+		assert.Equal(t, int64(1000), amount)
+	})
+}
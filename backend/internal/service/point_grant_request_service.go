@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/events"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+)
+
+// PointGrantRequestService handles the approval of point grants above the approval threshold
+type PointGrantRequestService struct {
+	bidderRepo  repository.BidderRepositoryInterface
+	redisClient *redis.Client
+}
+
+// NewPointGrantRequestService creates a new PointGrantRequestService instance
+func NewPointGrantRequestService(bidderRepo repository.BidderRepositoryInterface, redisClient *redis.Client) *PointGrantRequestService {
+	return &PointGrantRequestService{
+		bidderRepo:  bidderRepo,
+		redisClient: redisClient,
+	}
+}
+
+// ListRequests retrieves the point grant requests with the given status (all when empty)
+func (s *PointGrantRequestService) ListRequests(status string) (*domain.PointGrantRequestListResponse, error) {
+	requestStatus := domain.PointGrantRequestStatus(status)
+	switch requestStatus {
+	case "", domain.PointGrantRequestStatusPending, domain.PointGrantRequestStatusApproved, domain.PointGrantRequestStatusRejected:
+	default:
+		return nil, ErrInvalidPointGrantRequestStatus
+	}
+
+	requests, err := s.bidderRepo.FindPointGrantRequests(requestStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get point grant requests: %w", err)
+	}
+	if requests == nil {
+		requests = []domain.PointGrantRequest{}
+	}
+
+	return &domain.PointGrantRequestListResponse{Requests: requests}, nil
+}
+
+// Approve applies a pending point grant request and notifies the requester.
+// The approving admin must not be the requester.
+func (s *PointGrantRequestService) Approve(requestID int64, adminID int64) (*domain.PointGrantDecision, error) {
+	request, history, err := s.bidderRepo.ApprovePointGrantRequest(requestID, adminID)
+	if err != nil {
+		return nil, grantRequestError(err)
+	}
+
+	s.notifyRequester(request)
+
+	return &domain.PointGrantDecision{
+		Request: *request,
+		History: history,
+	}, nil
+}
+
+// Reject rejects a pending point grant request with an optional note and notifies the requester.
+// The rejecting admin must not be the requester.
+func (s *PointGrantRequestService) Reject(requestID int64, adminID int64, note string) (*domain.PointGrantDecision, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > MaxAdjustmentReasonLength {
+		return nil, ErrInvalidDecisionNote
+	}
+
+	var decisionNote *string
+	if note != "" {
+		decisionNote = &note
+	}
+
+	request, err := s.bidderRepo.RejectPointGrantRequest(requestID, adminID, decisionNote)
+	if err != nil {
+		return nil, grantRequestError(err)
+	}
+
+	s.notifyRequester(request)
+
+	return &domain.PointGrantDecision{Request: *request}, nil
+}
+
+// notifyRequester publishes the decision to the admin who requested the grant
+func (s *PointGrantRequestService) notifyRequester(request *domain.PointGrantRequest) {
+	notification := events.PointGrantDecided{
+		RequestID: request.ID,
+		BidderID:  request.BidderID,
+		Points:    request.Points,
+		Status:    string(request.Status),
+		DecidedBy: int64OrZero(request.DecidedBy),
+		DecidedAt: timeOrNow(request.DecidedAt),
+	}
+	if request.DecisionNote != nil {
+		notification.DecisionNote = *request.DecisionNote
+	}
+
+	if err := events.PublishToAdmin(context.Background(), s.redisClient, request.RequestedBy, notification); err != nil {
+		log.Printf("Failed to notify admin %d of point grant request %d: %v", request.RequestedBy, request.ID, err)
+	}
+}
+
+// grantRequestError maps the repository errors of a point grant decision to service errors
+func grantRequestError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrPointGrantRequestNotFound):
+		return ErrPointGrantRequestNotFound
+	case errors.Is(err, repository.ErrPointGrantRequestNotPending):
+		return ErrPointGrantRequestNotPending
+	case errors.Is(err, repository.ErrSelfApproval):
+		return ErrSelfApproval
+	case errors.Is(err, repository.ErrPointGrantRequestExpired):
+		return ErrPointGrantRequestExpired
+	case errors.Is(err, repository.ErrPointGrantBidderNotActive):
+		return ErrPointGrantBidderNotActive
+	default:
+		return fmt.Errorf("failed to decide point grant request: %w", err)
+	}
+}
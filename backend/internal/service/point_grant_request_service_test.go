@@ -0,0 +1,100 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+)
+
+func TestPointGrantRequestService_Approve(t *testing.T) {
+	t.Run("Success - Another admin approves", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		requestService := NewPointGrantRequestService(mockBidderRepo, nil)
+
+		approverID := int64(2)
+		historyID := int64(10)
+		decidedAt := time.Now()
+		request := &domain.PointGrantRequest{
+			ID:          1,
+			BidderID:    "test-bidder-id",
+			Points:      200000,
+			Status:      domain.PointGrantRequestStatusApproved,
+			RequestedBy: 1,
+			DecidedBy:   &approverID,
+			HistoryID:   &historyID,
+			DecidedAt:   &decidedAt,
+		}
+		history := &domain.PointHistory{ID: historyID, BidderID: "test-bidder-id", Amount: 200000, Type: domain.PointHistoryTypeGrant}
+
+		mockBidderRepo.On("ApprovePointGrantRequest", int64(1), approverID).Return(request, history, nil)
+
+		result, err := requestService.Approve(1, approverID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PointGrantRequestStatusApproved, result.Request.Status)
+		assert.Equal(t, history, result.History)
+		mockBidderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Requester approves their own request", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		requestService := NewPointGrantRequestService(mockBidderRepo, nil)
+
+		mockBidderRepo.On("ApprovePointGrantRequest", int64(1), int64(1)).Return(nil, nil, repository.ErrSelfApproval)
+
+		result, err := requestService.Approve(1, 1)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrSelfApproval, err)
+	})
+
+	t.Run("Error - Request already decided", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		requestService := NewPointGrantRequestService(mockBidderRepo, nil)
+
+		mockBidderRepo.On("ApprovePointGrantRequest", int64(1), int64(2)).Return(nil, nil, repository.ErrPointGrantRequestNotPending)
+
+		result, err := requestService.Approve(1, 2)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrPointGrantRequestNotPending, err)
+	})
+
+	t.Run("Error - Expiry passed while awaiting approval", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		requestService := NewPointGrantRequestService(mockBidderRepo, nil)
+
+		mockBidderRepo.On("ApprovePointGrantRequest", int64(1), int64(2)).Return(nil, nil, repository.ErrPointGrantRequestExpired)
+
+		result, err := requestService.Approve(1, 2)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrPointGrantRequestExpired, err)
+	})
+
+	t.Run("Error - Bidder no longer active", func(t *testing.T) {
+		mockBidderRepo := new(MockBidderRepository)
+		requestService := NewPointGrantRequestService(mockBidderRepo, nil)
+
+		mockBidderRepo.On("ApprovePointGrantRequest", int64(1), int64(2)).Return(nil, nil, repository.ErrPointGrantBidderNotActive)
+
+		result, err := requestService.Approve(1, 2)
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrPointGrantBidderNotActive, err)
+	})
+}
+
+func TestPointGrantRequestService_ListRequests(t *testing.T) {
+	t.Run("Error - Invalid status", func(t *testing.T) {
+		requestService := NewPointGrantRequestService(new(MockBidderRepository), nil)
+
+		result, err := requestService.ListRequests("unknown")
+
+		assert.Nil(t, result)
+		assert.Equal(t, ErrInvalidPointGrantRequestStatus, err)
+	})
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"gorm.io/gorm"
+)
+
+// errPointsUncorrectable is returned by correctBidder when the balances cannot be corrected
+var errPointsUncorrectable = errors.New("points cannot be corrected automatically")
+
+// PointReconciliationService checks the point ledger invariants and corrects balance discrepancies
+type PointReconciliationService struct {
+	db        *gorm.DB
+	pointRepo *repository.PointRepository
+}
+
+// NewPointReconciliationService creates a new PointReconciliationService instance
+func NewPointReconciliationService(db *gorm.DB, pointRepo *repository.PointRepository) *PointReconciliationService {
+	return &PointReconciliationService{
+		db:        db,
+		pointRepo: pointRepo,
+	}
+}
+
+// Reconcile checks every bidder's points:
+//   - total_points equals available_points + reserved_points
+//   - reserved_points equals the sum of the bidder's winning bids on items that have not ended
+//   - each history entry starts from the balances the previous entry ended with, and the latest
+//     entry ends with the current balances
+//
+// The checks read a single consistent snapshot. When applyCorrections is set, the balance and
+// reserved discrepancies are corrected with an adjust history entry per bidder, attributed to
+// adminID (nil for the scheduled job). Bidders whose open winning bids exceed their points are
+// listed for manual review instead. History discrepancies are reported only.
+func (s *PointReconciliationService) Reconcile(applyCorrections bool, adminID *int64) (*domain.PointReconciliationReport, error) {
+	report := &domain.PointReconciliationReport{
+		CheckedAt:     time.Now(),
+		Discrepancies: []domain.PointDiscrepancy{},
+		Corrections:   []domain.PointHistory{},
+		ManualReview:  []string{},
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		points, err := s.pointRepo.FindAllPoints(tx)
+		if err != nil {
+			return fmt.Errorf("failed to get points: %w", err)
+		}
+
+		reservedSums, err := s.pointRepo.SumOpenWinningBids(nil, tx)
+		if err != nil {
+			return fmt.Errorf("failed to sum winning bids: %w", err)
+		}
+
+		links, err := s.pointRepo.FindBrokenHistoryLinks(tx)
+		if err != nil {
+			return fmt.Errorf("failed to check history chains: %w", err)
+		}
+
+		latest, err := s.pointRepo.FindLatestHistories(tx)
+		if err != nil {
+			return fmt.Errorf("failed to get latest histories: %w", err)
+		}
+
+		report.BiddersChecked = len(points)
+		report.Discrepancies = append(report.Discrepancies, checkBalances(points, reservedSums)...)
+		report.Discrepancies = append(report.Discrepancies, checkHistoryChains(points, links, latest)...)
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if !applyCorrections {
+		return report, nil
+	}
+
+	for _, bidderID := range correctableBidders(report.Discrepancies) {
+		correction, err := s.correctBidder(bidderID, adminID)
+		if errors.Is(err, errPointsUncorrectable) {
+			report.ManualReview = append(report.ManualReview, bidderID)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to correct points of bidder %s: %w", bidderID, err)
+		}
+		if correction != nil {
+			report.Corrections = append(report.Corrections, *correction)
+		}
+	}
+
+	return report, nil
+}
+
+// correctBidder re-checks a bidder's balances under the points row lock and records an adjust entry
+// bringing them back in line. Returns nil when the balances are already consistent, and
+// errPointsUncorrectable when they cannot be corrected without creating points.
+func (s *PointReconciliationService) correctBidder(bidderID string, adminID *int64) (*domain.PointHistory, error) {
+	var history *domain.PointHistory
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Every change to a bidder's winning bids takes this lock, so the sum below is stable
+		locked, err := s.pointRepo.GetPointsForUpdate([]string{bidderID}, tx)
+		if err != nil {
+			return err
+		}
+		current := locked[bidderID]
+		if current == nil {
+			return ErrPointsNotFound
+		}
+
+		reservedSums, err := s.pointRepo.SumOpenWinningBids([]string{bidderID}, tx)
+		if err != nil {
+			return err
+		}
+
+		corrected, ok := correctedPoints(*current, reservedSums[bidderID])
+		if !ok {
+			return errPointsUncorrectable
+		}
+		if corrected.TotalPoints == current.TotalPoints &&
+			corrected.AvailablePoints == current.AvailablePoints &&
+			corrected.ReservedPoints == current.ReservedPoints {
+			return nil
+		}
+
+		if err := s.pointRepo.SetPoints(bidderID, corrected.TotalPoints, corrected.AvailablePoints, corrected.ReservedPoints, tx); err != nil {
+			return err
+		}
+
+		// Amount is the change in total points, or in available points when the total is unchanged
+		amount := corrected.TotalPoints - current.TotalPoints
+		if amount == 0 {
+			amount = corrected.AvailablePoints - current.AvailablePoints
+		}
+		reason := "Ledger reconciliation"
+
+		history = &domain.PointHistory{
+			BidderID:       bidderID,
+			Amount:         amount,
+			Type:           domain.PointHistoryTypeAdjust,
+			Reason:         &reason,
+			AdminID:        adminID,
+			BalanceBefore:  current.AvailablePoints,
+			BalanceAfter:   corrected.AvailablePoints,
+			ReservedBefore: current.ReservedPoints,
+			ReservedAfter:  corrected.ReservedPoints,
+			TotalBefore:    current.TotalPoints,
+			TotalAfter:     corrected.TotalPoints,
+		}
+		return s.pointRepo.CreatePointHistory(history, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// correctedPoints returns the balances satisfying the invariants. The reserved points are set to
+// the sum of the open winning bids, moving the difference from or to the available points, and the
+// total is recomputed from the available and reserved points. Returns false when the open winning
+// bids need more points than the bidder's available points can provide: correcting the reservation
+// would create points, so the bidder needs manual review.
+func correctedPoints(points domain.BidderPoints, expectedReserved int64) (domain.BidderPoints, bool) {
+	available := points.AvailablePoints - (expectedReserved - points.ReservedPoints)
+	if available < 0 {
+		return points, false
+	}
+
+	points.AvailablePoints = available
+	points.ReservedPoints = expectedReserved
+	points.TotalPoints = available + expectedReserved
+	return points, true
+}
+
+// checkBalances checks the balance and reserved invariants of every bidder
+func checkBalances(points []domain.BidderPoints, reservedSums map[string]int64) []domain.PointDiscrepancy {
+	var discrepancies []domain.PointDiscrepancy
+
+	for _, p := range points {
+		if sum := p.AvailablePoints + p.ReservedPoints; p.TotalPoints != sum {
+			discrepancies = append(discrepancies, domain.PointDiscrepancy{
+				BidderID: p.BidderID,
+				Type:     domain.PointDiscrepancyBalance,
+				Field:    "total",
+				Expected: sum,
+				Actual:   p.TotalPoints,
+			})
+		}
+
+		if expected := reservedSums[p.BidderID]; p.ReservedPoints != expected {
+			discrepancies = append(discrepancies, domain.PointDiscrepancy{
+				BidderID: p.BidderID,
+				Type:     domain.PointDiscrepancyReserved,
+				Field:    "reserved",
+				Expected: expected,
+				Actual:   p.ReservedPoints,
+			})
+		}
+	}
+
+	return discrepancies
+}
+
+// checkHistoryChains reports the broken links of the history chains and the bidders whose latest
+// history entry does not end with their current balances
+func checkHistoryChains(points []domain.BidderPoints, links []domain.PointHistoryLink, latest []domain.PointHistory) []domain.PointDiscrepancy {
+	var discrepancies []domain.PointDiscrepancy
+
+	for _, link := range links {
+		id := link.ID
+		for _, field := range []struct {
+			name             string
+			expected, actual int64
+		}{
+			{"available", link.PreviousBalanceAfter, link.BalanceBefore},
+			{"reserved", link.PreviousReserved, link.ReservedBefore},
+			{"total", link.PreviousTotalAfter, link.TotalBefore},
+		} {
+			if field.expected != field.actual {
+				discrepancies = append(discrepancies, domain.PointDiscrepancy{
+					BidderID:  link.BidderID,
+					Type:      domain.PointDiscrepancyHistoryGap,
+					Field:     field.name,
+					Expected:  field.expected,
+					Actual:    field.actual,
+					HistoryID: &id,
+				})
+			}
+		}
+	}
+
+	latestByBidder := make(map[string]domain.PointHistory, len(latest))
+	for _, h := range latest {
+		latestByBidder[h.BidderID] = h
+	}
+
+	for _, p := range points {
+		h, ok := latestByBidder[p.BidderID]
+		if !ok {
+			continue
+		}
+		id := h.ID
+		for _, field := range []struct {
+			name             string
+			expected, actual int64
+		}{
+			{"available", h.BalanceAfter, p.AvailablePoints},
+			{"reserved", h.ReservedAfter, p.ReservedPoints},
+			{"total", h.TotalAfter, p.TotalPoints},
+		} {
+			if field.expected != field.actual {
+				discrepancies = append(discrepancies, domain.PointDiscrepancy{
+					BidderID:  p.BidderID,
+					Type:      domain.PointDiscrepancyHistoryTail,
+					Field:     field.name,
+					Expected:  field.expected,
+					Actual:    field.actual,
+					HistoryID: &id,
+				})
+			}
+		}
+	}
+
+	return discrepancies
+}
+
+// correctableBidders returns the bidders with balance or reserved discrepancies, in ID order
+func correctableBidders(discrepancies []domain.PointDiscrepancy) []string {
+	seen := make(map[string]bool)
+	var bidderIDs []string
+
+	for _, d := range discrepancies {
+		if d.Type != domain.PointDiscrepancyBalance && d.Type != domain.PointDiscrepancyReserved {
+			continue
+		}
+		if !seen[d.BidderID] {
+			seen[d.BidderID] = true
+			bidderIDs = append(bidderIDs, d.BidderID)
+		}
+	}
+
+	sort.Strings(bidderIDs)
+	return bidderIDs
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
+
+func TestCheckBalances(t *testing.T) {
+	points := []domain.BidderPoints{
+		{BidderID: "a", TotalPoints: 1000, AvailablePoints: 700, ReservedPoints: 300},
+		{BidderID: "b", TotalPoints: 1000, AvailablePoints: 600, ReservedPoints: 300},
+		{BidderID: "c", TotalPoints: 500, AvailablePoints: 300, ReservedPoints: 200},
+	}
+	reservedSums := map[string]int64{"a": 300, "b": 300}
+
+	discrepancies := checkBalances(points, reservedSums)
+
+	assert.Equal(t, []domain.PointDiscrepancy{
+		{BidderID: "b", Type: domain.PointDiscrepancyBalance, Field: "total", Expected: 900, Actual: 1000},
+		{BidderID: "c", Type: domain.PointDiscrepancyReserved, Field: "reserved", Expected: 0, Actual: 200},
+	}, discrepancies)
+}
+
+func TestCheckHistoryChains(t *testing.T) {
+	points := []domain.BidderPoints{
+		{BidderID: "a", TotalPoints: 1000, AvailablePoints: 700, ReservedPoints: 300},
+		{BidderID: "b", TotalPoints: 500, AvailablePoints: 500},
+	}
+	links := []domain.PointHistoryLink{
+		{ID: 5, BidderID: "a", BalanceBefore: 800, ReservedBefore: 0, TotalBefore: 1000, PreviousBalanceAfter: 1000, PreviousTotalAfter: 1000},
+	}
+	latest := []domain.PointHistory{
+		{ID: 6, BidderID: "a", BalanceAfter: 700, ReservedAfter: 300, TotalAfter: 1000},
+		{ID: 9, BidderID: "b", BalanceAfter: 400, TotalAfter: 400},
+	}
+
+	discrepancies := checkHistoryChains(points, links, latest)
+
+	assert.Len(t, discrepancies, 3)
+	assert.Equal(t, domain.PointDiscrepancyHistoryGap, discrepancies[0].Type)
+	assert.Equal(t, "available", discrepancies[0].Field)
+	assert.Equal(t, int64(5), *discrepancies[0].HistoryID)
+	assert.Equal(t, domain.PointDiscrepancyHistoryTail, discrepancies[1].Type)
+	assert.Equal(t, "b", discrepancies[1].BidderID)
+	assert.Equal(t, int64(400), discrepancies[1].Expected)
+	assert.Equal(t, int64(500), discrepancies[1].Actual)
+}
+
+func TestCorrectedPoints(t *testing.T) {
+	tests := []struct {
+		name             string
+		points           domain.BidderPoints
+		expectedReserved int64
+		want             domain.BidderPoints
+		wantOK           bool
+	}{
+		{
+			name:             "Unreleased reservation moves back to available",
+			points:           domain.BidderPoints{TotalPoints: 1000, AvailablePoints: 700, ReservedPoints: 300},
+			expectedReserved: 0,
+			want:             domain.BidderPoints{TotalPoints: 1000, AvailablePoints: 1000, ReservedPoints: 0},
+			wantOK:           true,
+		},
+		{
+			name:             "Total recomputed from available and reserved",
+			points:           domain.BidderPoints{TotalPoints: 1200, AvailablePoints: 700, ReservedPoints: 300},
+			expectedReserved: 300,
+			want:             domain.BidderPoints{TotalPoints: 1000, AvailablePoints: 700, ReservedPoints: 300},
+			wantOK:           true,
+		},
+		{
+			name:             "Missing reservation taken from available",
+			points:           domain.BidderPoints{TotalPoints: 500, AvailablePoints: 500, ReservedPoints: 0},
+			expectedReserved: 300,
+			want:             domain.BidderPoints{TotalPoints: 500, AvailablePoints: 200, ReservedPoints: 300},
+			wantOK:           true,
+		},
+		{
+			name:             "Reservation exceeding available needs manual review",
+			points:           domain.BidderPoints{TotalPoints: 100, AvailablePoints: 100, ReservedPoints: 0},
+			expectedReserved: 300,
+			want:             domain.BidderPoints{TotalPoints: 100, AvailablePoints: 100, ReservedPoints: 0},
+			wantOK:           false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := correctedPoints(tt.points, tt.expectedReserved)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCorrectableBidders(t *testing.T) {
+	discrepancies := []domain.PointDiscrepancy{
+		{BidderID: "b", Type: domain.PointDiscrepancyReserved},
+		{BidderID: "c", Type: domain.PointDiscrepancyHistoryGap},
+		{BidderID: "a", Type: domain.PointDiscrepancyBalance},
+		{BidderID: "b", Type: domain.PointDiscrepancyBalance},
+	}
+
+	assert.Equal(t, []string{"a", "b"}, correctableBidders(discrepancies))
+}
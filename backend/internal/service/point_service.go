@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
 	"github.com/tsutsumi389/real-time-auction/internal/repository"
@@ -36,13 +37,19 @@ func (s *PointService) GetPoints(bidderID string) (*domain.GetPointsResponse, er
 		return nil, ErrPointsNotFound
 	}
 
+	lots, err := s.pointRepo.FindOpenLots(bidderID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get point lots: %w", err)
+	}
+
 	// Build response
 	response := &domain.GetPointsResponse{
-		BidderID:        points.BidderID,
-		TotalPoints:     points.TotalPoints,
-		AvailablePoints: points.AvailablePoints,
-		ReservedPoints:  points.ReservedPoints,
-		UpdatedAt:       points.UpdatedAt,
+		BidderID:         points.BidderID,
+		TotalPoints:      points.TotalPoints,
+		AvailablePoints:  points.AvailablePoints,
+		ReservedPoints:   points.ReservedPoints,
+		UpdatedAt:        points.UpdatedAt,
+		UpcomingExpiries: upcomingPointExpiries(lots, *points, time.Now()),
 	}
 
 	return response, nil
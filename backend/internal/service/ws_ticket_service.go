@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+)
+
+// WSTicketTTL is how long a WebSocket ticket remains redeemable
+const WSTicketTTL = 30 * time.Second
+
+// WSTicketService issues and redeems single-use WebSocket connection tickets.
+// Tickets keep the long-lived JWT out of WebSocket URLs (and therefore out of proxy logs).
+type WSTicketService struct {
+	redisClient *redis.Client
+	ctx         context.Context
+}
+
+// NewWSTicketService creates a new WSTicketService instance
+func NewWSTicketService(redisClient *redis.Client) *WSTicketService {
+	return &WSTicketService{
+		redisClient: redisClient,
+		ctx:         context.Background(),
+	}
+}
+
+// ticketKey returns the Redis key for a ticket
+func ticketKey(ticket string) string {
+	return fmt.Sprintf("ws:ticket:%s", ticket)
+}
+
+// IssueTicket creates a ticket bound to the authenticated user in the given claims
+func (s *WSTicketService) IssueTicket(claims *domain.JWTClaims) (*domain.WSTicketResponse, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate ticket: %w", err)
+	}
+	ticket := hex.EncodeToString(buf)
+
+	// Store only the user identity; registered claims (expiry etc.) belong to the JWT
+	payload, err := json.Marshal(&domain.JWTClaims{
+		UserID:      claims.UserID,
+		Email:       claims.Email,
+		DisplayName: claims.DisplayName,
+		Role:        claims.Role,
+		UserType:    claims.UserType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ticket: %w", err)
+	}
+
+	if err := s.redisClient.Set(s.ctx, ticketKey(ticket), payload, WSTicketTTL).Err(); err != nil {
+		return nil, fmt.Errorf("failed to store ticket: %w", err)
+	}
+
+	return &domain.WSTicketResponse{
+		Ticket:    ticket,
+		ExpiresAt: time.Now().Add(WSTicketTTL),
+	}, nil
+}
+
+// RedeemTicket consumes a ticket and returns the claims it was issued for.
+// A ticket can be redeemed only once.
+func (s *WSTicketService) RedeemTicket(ticket string) (*domain.JWTClaims, error) {
+	if ticket == "" {
+		return nil, ErrInvalidTicket
+	}
+
+	payload, err := s.redisClient.GetDel(s.ctx, ticketKey(ticket)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidTicket
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem ticket: %w", err)
+	}
+
+	var claims domain.JWTClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidTicket
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,53 @@
+package ws
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// errInvalidAuthorizationHeader はAuthorizationヘッダーの形式が不正な場合のエラー
+var errInvalidAuthorizationHeader = errors.New("invalid authorization header")
+
+// Authenticator はWebSocket接続の認証を行う
+type Authenticator struct {
+	jwtService    *service.JWTService
+	ticketService *service.WSTicketService
+}
+
+// NewAuthenticator は新しいAuthenticatorを作成する
+func NewAuthenticator(jwtService *service.JWTService, ticketService *service.WSTicketService) *Authenticator {
+	return &Authenticator{
+		jwtService:    jwtService,
+		ticketService: ticketService,
+	}
+}
+
+// authenticate はリクエストの認証情報を検証する
+// 認証情報がない場合は (nil, nil) を返す（観覧者として扱う）
+//
+// 認証方法:
+//   - ?ticket= : REST API（POST /api/ws/ticket）で発行された使い捨てチケット（ブラウザ向け）
+//   - Authorization: Bearer <JWT> : ヘッダーを設定できるクライアント向け
+//
+// JWTはURLに含めるとプロキシのアクセスログに残るため、クエリパラメータでは受け付けない
+func (a *Authenticator) authenticate(c *gin.Context) (*domain.JWTClaims, error) {
+	if ticket := c.Query("ticket"); ticket != "" {
+		return a.ticketService.RedeemTicket(ticket)
+	}
+
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, errInvalidAuthorizationHeader
+	}
+
+	return a.jwtService.ValidateToken(parts[1])
+}
@@ -0,0 +1,66 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+func newAuthTestContext(target string, header string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	if header != "" {
+		c.Request.Header.Set("Authorization", header)
+	}
+	return c
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	jwtService := service.NewJWTService("test-secret")
+	auth := NewAuthenticator(jwtService, nil)
+
+	displayName := "Taro"
+	token, err := jwtService.GenerateTokenForBidder(&domain.Bidder{
+		ID:          "6f1c2d3e-0000-0000-0000-00000000000a",
+		Email:       "taro@example.com",
+		DisplayName: &displayName,
+	})
+	require.NoError(t, err)
+
+	t.Run("No credentials is a spectator", func(t *testing.T) {
+		claims, err := auth.authenticate(newAuthTestContext("/ws", ""))
+		assert.NoError(t, err)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("Bearer header is accepted", func(t *testing.T) {
+		claims, err := auth.authenticate(newAuthTestContext("/ws", "Bearer "+token))
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserTypeBidder, claims.UserType)
+		assert.Equal(t, "Taro", claims.DisplayName)
+	})
+
+	t.Run("Token in query string is ignored", func(t *testing.T) {
+		claims, err := auth.authenticate(newAuthTestContext("/ws?token="+token, ""))
+		assert.NoError(t, err)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("Malformed header is rejected", func(t *testing.T) {
+		_, err := auth.authenticate(newAuthTestContext("/ws", "Token "+token))
+		assert.ErrorIs(t, err, errInvalidAuthorizationHeader)
+	})
+
+	t.Run("Token signed with another secret is rejected", func(t *testing.T) {
+		other := NewAuthenticator(service.NewJWTService("other-secret"), nil)
+		_, err := other.authenticate(newAuthTestContext("/ws", "Bearer "+token))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,232 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+const (
+	// 入札のレート制限（接続単位）
+	bidRateLimit = 5 // 1秒あたりの入札数
+	bidRateBurst = 5 // バースト許容数
+
+	// 入札リクエストの冪等性設定
+	bidRequestTTL      = 5 * time.Minute // リクエストIDの保持期間
+	maxRequestIDLength = 64              // リクエストIDの最大長
+)
+
+// bidRequestRecord はリクエストIDごとに保存する入札内容と応答
+// REST APIのIdempotency-Keyと同様に、同じリクエストIDを異なる入札で再利用した場合は拒否する
+type bidRequestRecord struct {
+	Fingerprint string          `json:"fingerprint"`     // 入札対象の商品と価格
+	Reply       json.RawMessage `json:"reply,omitempty"` // 保存済みの応答（空の場合は処理中）
+}
+
+// eventError はクライアントに返すエラーコードとメッセージ
+type eventError struct {
+	code    string
+	message string
+}
+
+// retryableBidErrors は再試行で成功しうるエラーコード
+// これらの応答は保存せずリクエストIDを解放し、同じリクエストIDでの再送を受け付ける
+var retryableBidErrors = map[string]bool{
+	"INTERNAL_ERROR":    true,
+	"BID_LOCK_FAILED":   true,
+	"STALE_FENCE_TOKEN": true,
+}
+
+// bidErrorFor はBidServiceのエラーをWebSocketエラーコードに変換する
+// REST APIのPlaceBidハンドラーと同じエラー分類を使用する
+func bidErrorFor(err error) eventError {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		return eventError{"ITEM_NOT_FOUND", "Item not found"}
+	case errors.Is(err, service.ErrItemNotStarted):
+		return eventError{"ITEM_NOT_STARTED", "Item has not started yet"}
+	case errors.Is(err, service.ErrItemAlreadyEnded):
+		return eventError{"ITEM_ALREADY_ENDED", "Item has already ended"}
+	case errors.Is(err, service.ErrInsufficientPoints):
+		return eventError{"INSUFFICIENT_POINTS", "Insufficient points"}
+	case errors.Is(err, service.ErrPriceMismatch):
+		return eventError{"PRICE_MISMATCH", "Price has changed. Please check the latest price"}
+	case errors.Is(err, service.ErrOutbidAtPrice):
+		return eventError{"OUTBID_AT_PRICE", "Another bid was accepted first at this price"}
+	case errors.Is(err, service.ErrStaleFenceToken):
+		return eventError{"STALE_FENCE_TOKEN", "Item was modified by another operation. Please try again"}
+	case errors.Is(err, service.ErrBidLockFailed):
+		return eventError{"BID_LOCK_FAILED", "The item is being updated. Please try again"}
+	case errors.Is(err, service.ErrAlreadyWinningBidder):
+		return eventError{"ALREADY_WINNING_BIDDER", "You are already the winning bidder"}
+	case errors.Is(err, service.ErrPointsNotFound):
+		return eventError{"POINTS_NOT_FOUND", "Points not found"}
+	default:
+		return eventError{"INTERNAL_ERROR", "Internal server error"}
+	}
+}
+
+// handleBidPlace は入札リクエストを処理する
+// 同じリクエストIDの再送には最初の応答をそのまま返す
+func (h *EventHandler) handleBidPlace(client *Client, event *Event) {
+	requestID := event.RequestID
+
+	// 入札者のみ入札可能
+	if client.userRole != "bidder" || client.bidderID == nil {
+		client.sendEvent(NewRequestErrorEvent(requestID, "FORBIDDEN", "Only bidders can place bids"))
+		return
+	}
+
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		client.sendEvent(NewRequestErrorEvent(requestID, "INVALID_REQUEST_ID", "Invalid request ID"))
+		return
+	}
+
+	if h.bidService == nil {
+		client.sendEvent(NewRequestErrorEvent(requestID, "BIDDING_UNAVAILABLE", "Bidding is not available on this connection"))
+		return
+	}
+
+	// レート制限チェック（冪等性キーを確保する前に行う）
+	if !client.bidLimiter.Allow() {
+		client.sendEvent(NewRequestErrorEvent(requestID, "RATE_LIMITED", "Too many bid requests"))
+		return
+	}
+
+	var data BidPlaceData
+	if err := h.parseEventData(event, &data); err != nil {
+		client.sendEvent(NewRequestErrorEvent(requestID, "INVALID_DATA", "Invalid bid data"))
+		return
+	}
+	if !isValidUUID(data.ItemID) || data.Price < 1 {
+		client.sendEvent(NewRequestErrorEvent(requestID, "INVALID_DATA", "Invalid bid data"))
+		return
+	}
+
+	// リクエストIDを確保（既に処理済みなら保存済みの応答を再送）
+	key := bidRequestKey(*client.bidderID, requestID)
+	fingerprint := bidRequestFingerprint(data)
+	pending, err := json.Marshal(bidRequestRecord{Fingerprint: fingerprint})
+	if err != nil {
+		log.Printf("[Bid] Failed to marshal request record: %v", err)
+		client.sendEvent(NewRequestErrorEvent(requestID, "INTERNAL_ERROR", "Internal server error"))
+		return
+	}
+	acquired, err := h.hub.redisClient.SetNX(h.hub.ctx, key, pending, bidRequestTTL).Result()
+	if err != nil {
+		log.Printf("[Bid] Failed to claim request ID: %v", err)
+		client.sendEvent(NewRequestErrorEvent(requestID, "INTERNAL_ERROR", "Internal server error"))
+		return
+	}
+	if !acquired {
+		h.replayBidResponse(client, requestID, key, fingerprint)
+		return
+	}
+
+	// REST APIと同じ入札ロジックを実行（受信時刻と接続の遅延を証跡として記録する）
+	rttMs, clockOffsetMs := client.latency.bidTiming()
+	response, err := h.bidService.PlaceBid(&service.PlaceBidRequest{
+		ItemID:        data.ItemID,
+		BidderID:      *client.bidderID,
+		Price:         data.Price,
+		ReceivedAt:    event.receivedAt,
+		ClientRTTMs:   rttMs,
+		ClockOffsetMs: clockOffsetMs,
+	})
+
+	var reply *Event
+	if err != nil {
+		bidErr := bidErrorFor(err)
+		if retryableBidErrors[bidErr.code] {
+			if bidErr.code == "INTERNAL_ERROR" {
+				log.Printf("[Bid] Failed to place bid: bidderID=%s, itemID=%s, err=%v", *client.bidderID, data.ItemID, err)
+			}
+			// 一時的なエラーは再試行できるようにリクエストIDを解放する
+			h.hub.redisClient.Del(h.hub.ctx, key)
+			client.sendEvent(NewRequestErrorEvent(requestID, bidErr.code, bidErr.message))
+			return
+		}
+		reply = NewRequestErrorEvent(requestID, bidErr.code, bidErr.message)
+
+		// 同じ価格で先着の入札が受理済みの場合は受理された入札を示す
+		var outbid *service.OutbidAtPriceError
+		if errors.As(err, &outbid) {
+			reply.Data = ErrorData{Code: bidErr.code, Message: bidErr.message, Details: outbid.Result}
+		}
+	} else {
+		reply = NewAckEvent(requestID, BidAckData{
+			Bid:    response.Bid,
+			Points: response.Points,
+		})
+	}
+
+	message, err := json.Marshal(reply)
+	if err != nil {
+		log.Printf("[Bid] Failed to marshal reply: %v", err)
+		return
+	}
+
+	// 応答を入札内容とともに保存して再送時に同じ結果を返せるようにする
+	record, err := json.Marshal(bidRequestRecord{Fingerprint: fingerprint, Reply: message})
+	if err != nil {
+		log.Printf("[Bid] Failed to marshal request record: %v", err)
+	} else if err := h.hub.redisClient.Set(h.hub.ctx, key, record, bidRequestTTL).Err(); err != nil {
+		log.Printf("[Bid] Failed to store reply: %v", err)
+	}
+
+	client.sendRaw(message)
+}
+
+// replayBidResponse は処理済みリクエストの応答を再送する
+func (h *EventHandler) replayBidResponse(client *Client, requestID, key, fingerprint string) {
+	stored, err := h.hub.redisClient.Get(h.hub.ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Printf("[Bid] Failed to load stored reply: %v", err)
+		}
+		client.sendEvent(NewRequestErrorEvent(requestID, "INTERNAL_ERROR", "Internal server error"))
+		return
+	}
+
+	reply, replyErr := storedBidReply(stored, fingerprint)
+	if replyErr != nil {
+		client.sendEvent(NewRequestErrorEvent(requestID, replyErr.code, replyErr.message))
+		return
+	}
+
+	client.sendRaw(reply)
+}
+
+// storedBidReply は保存済みの記録から再送する応答を返す
+// 異なる入札でリクエストIDを再利用した場合と、最初のリクエストが処理中の場合はエラーを返す
+func storedBidReply(stored []byte, fingerprint string) ([]byte, *eventError) {
+	var record bidRequestRecord
+	if err := json.Unmarshal(stored, &record); err != nil {
+		log.Printf("[Bid] Failed to decode stored reply: %v", err)
+		return nil, &eventError{"INTERNAL_ERROR", "Internal server error"}
+	}
+
+	switch {
+	case record.Fingerprint != fingerprint:
+		return nil, &eventError{"REQUEST_ID_REUSED", "Request ID was already used for a different bid"}
+	case len(record.Reply) == 0:
+		return nil, &eventError{"REQUEST_IN_PROGRESS", "Request is already being processed"}
+	default:
+		return record.Reply, nil
+	}
+}
+
+// bidRequestFingerprint は入札リクエストの内容（商品と価格）を表す文字列を返す
+func bidRequestFingerprint(data BidPlaceData) string {
+	return fmt.Sprintf("%s:%d", data.ItemID, data.Price)
+}
+
+// bidRequestKey は入札リクエストIDのRedisキーを返す
+func bidRequestKey(bidderID, requestID string) string {
+	return fmt.Sprintf("ws:bid:request:%s:%s", bidderID, requestID)
+}
@@ -0,0 +1,68 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+func TestBidErrorFor_Retryable(t *testing.T) {
+	t.Run("Transient errors release the request ID", func(t *testing.T) {
+		for _, err := range []error{service.ErrBidLockFailed, service.ErrStaleFenceToken, errors.New("db down")} {
+			assert.True(t, retryableBidErrors[bidErrorFor(err).code], err.Error())
+		}
+	})
+
+	t.Run("Final outcomes are stored for replay", func(t *testing.T) {
+		for _, err := range []error{service.ErrPriceMismatch, service.ErrOutbidAtPrice, service.ErrInsufficientPoints, service.ErrItemAlreadyEnded} {
+			assert.False(t, retryableBidErrors[bidErrorFor(err).code], err.Error())
+		}
+	})
+}
+
+func TestStoredBidReply(t *testing.T) {
+	bid := BidPlaceData{ItemID: "6f1c2d3e-0000-0000-0000-0000000000aa", Price: 1000}
+	fingerprint := bidRequestFingerprint(bid)
+	reply := json.RawMessage(`{"type":"ack","request_id":"req-1"}`)
+
+	record := func(r bidRequestRecord) []byte {
+		data, err := json.Marshal(r)
+		assert.NoError(t, err)
+		return data
+	}
+
+	t.Run("Replays the stored reply for the same bid", func(t *testing.T) {
+		got, replyErr := storedBidReply(record(bidRequestRecord{Fingerprint: fingerprint, Reply: reply}), fingerprint)
+		assert.Nil(t, replyErr)
+		assert.JSONEq(t, string(reply), string(got))
+	})
+
+	t.Run("Rejects the request ID reused for a different bid", func(t *testing.T) {
+		stored := record(bidRequestRecord{Fingerprint: fingerprint, Reply: reply})
+		for _, other := range []BidPlaceData{
+			{ItemID: bid.ItemID, Price: 2000},
+			{ItemID: "6f1c2d3e-0000-0000-0000-0000000000bb", Price: 1000},
+		} {
+			_, replyErr := storedBidReply(stored, bidRequestFingerprint(other))
+			if assert.NotNil(t, replyErr) {
+				assert.Equal(t, "REQUEST_ID_REUSED", replyErr.code)
+			}
+		}
+
+		// 処理中のリクエストIDも異なる入札では再利用できない
+		_, replyErr := storedBidReply(record(bidRequestRecord{Fingerprint: fingerprint}), bidRequestFingerprint(BidPlaceData{ItemID: bid.ItemID, Price: 2000}))
+		if assert.NotNil(t, replyErr) {
+			assert.Equal(t, "REQUEST_ID_REUSED", replyErr.code)
+		}
+	})
+
+	t.Run("Reports the same bid still in progress", func(t *testing.T) {
+		_, replyErr := storedBidReply(record(bidRequestRecord{Fingerprint: fingerprint}), fingerprint)
+		if assert.NotNil(t, replyErr) {
+			assert.Equal(t, "REQUEST_IN_PROGRESS", replyErr.code)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package ws
+
+import (
+	"strconv"
+)
+
+const (
+	// アナウンス・チャット送信のレート制限（入札者の質問はより厳しく制限する）
+	adminChatRateLimit  = 1.0 // 主催者: 1秒あたりのアナウンス数
+	adminChatRateBurst  = 5
+	bidderChatRateLimit = 0.2 // 入札者: 1秒あたりの質問数（5秒に1件）
+	bidderChatRateBurst = 3
+
+	maxMuteReasonLength = 200 // ミュート理由の最大文字数
+)
+
+// ChatSendData は入札者の質問送信のデータ
+type ChatSendData struct {
+	AuctionID string `json:"auction_id"`
+	Message   string `json:"message"`
+}
+
+// ChatDeleteCommandData はメッセージ削除コマンドのデータ
+type ChatDeleteCommandData struct {
+	AuctionID string `json:"auction_id"`
+	MessageID int64  `json:"message_id"`
+}
+
+// ChatMuteCommandData はミュート・ミュート解除コマンドのデータ
+type ChatMuteCommandData struct {
+	AuctionID string `json:"auction_id"`
+	BidderID  string `json:"bidder_id"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// newChatLimiter はロールに応じたアナウンス・チャット送信のレートリミッターを作成する
+func newChatLimiter(userRole string) *rateLimiter {
+	if userRole == "system_admin" || userRole == "auctioneer" {
+		return newRateLimiter(adminChatRateLimit, adminChatRateBurst)
+	}
+	return newRateLimiter(bidderChatRateLimit, bidderChatRateBurst)
+}
+
+// authorizeModeration はアナウンス・モデレーションコマンドの共通チェックを行う
+// 失敗時はエラー応答を送信してfalseを返す
+func (h *EventHandler) authorizeModeration(client *Client, event *Event) (int64, bool) {
+	if !h.authorizeAuctioneer(client, event) {
+		return 0, false
+	}
+
+	if h.chatService == nil {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "COMMAND_UNAVAILABLE", "Commands are not available on this connection"))
+		return 0, false
+	}
+
+	adminID, err := strconv.ParseInt(client.userID, 10, 64)
+	if err != nil {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_USER", "Invalid admin ID"))
+		return 0, false
+	}
+
+	return adminID, true
+}
+
+// handleChatSend は入札者から主催者への質問を処理する
+// 質問は永続化され、Redis Pub/Sub経由で管理者と送信者本人にのみ配信される
+func (h *EventHandler) handleChatSend(client *Client, event *Event) {
+	if client.userRole != "bidder" || client.bidderID == nil {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "FORBIDDEN", "Only bidders can send messages"))
+		return
+	}
+
+	if event.RequestID == "" || len(event.RequestID) > maxRequestIDLength {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_REQUEST_ID", "Invalid request ID"))
+		return
+	}
+
+	if h.chatService == nil {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "COMMAND_UNAVAILABLE", "Commands are not available on this connection"))
+		return
+	}
+
+	var data ChatSendData
+	if err := h.parseEventData(event, &data); err != nil || !isValidUUID(data.AuctionID) {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_DATA", "Invalid message data"))
+		return
+	}
+
+	// 参加中のオークションルームにのみ送信できる
+	if !client.isSubscribed(data.AuctionID) {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "NOT_SUBSCRIBED", "Subscribe to the auction before sending messages"))
+		return
+	}
+
+	if !client.chatLimiter.Allow() {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "RATE_LIMITED", "Too many messages"))
+		return
+	}
+
+	message, err := h.chatService.SendQuestion(data.AuctionID, *client.bidderID, data.Message)
+	if err != nil {
+		h.replyCommand(client, event, nil, err)
+		return
+	}
+
+	h.replyCommand(client, event, map[string]interface{}{
+		"auction_id": data.AuctionID,
+		"message_id": message.ID,
+		"sent_at":    message.CreatedAt,
+	}, nil)
+}
+
+// handleChatDelete はメッセージ削除コマンドを処理する
+func (h *EventHandler) handleChatDelete(client *Client, event *Event) {
+	adminID, ok := h.authorizeModeration(client, event)
+	if !ok {
+		return
+	}
+
+	var data ChatDeleteCommandData
+	if err := h.parseEventData(event, &data); err != nil || !isValidUUID(data.AuctionID) || data.MessageID < 1 {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_DATA", "Invalid message data"))
+		return
+	}
+
+	err := h.chatService.DeleteMessage(data.AuctionID, data.MessageID, adminID)
+	h.replyCommand(client, event, map[string]interface{}{
+		"auction_id": data.AuctionID,
+		"message_id": data.MessageID,
+	}, err)
+}
+
+// handleChatMute はミュートコマンドを処理する
+func (h *EventHandler) handleChatMute(client *Client, event *Event) {
+	adminID, ok := h.authorizeModeration(client, event)
+	if !ok {
+		return
+	}
+
+	var data ChatMuteCommandData
+	if err := h.parseEventData(event, &data); err != nil || !isValidUUID(data.AuctionID) || !isValidUUID(data.BidderID) ||
+		len([]rune(data.Reason)) > maxMuteReasonLength {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_DATA", "Invalid mute data"))
+		return
+	}
+
+	err := h.chatService.MuteBidder(data.AuctionID, data.BidderID, adminID, data.Reason)
+	h.replyCommand(client, event, map[string]interface{}{
+		"auction_id": data.AuctionID,
+		"bidder_id":  data.BidderID,
+		"muted":      true,
+	}, err)
+}
+
+// handleChatUnmute はミュート解除コマンドを処理する
+func (h *EventHandler) handleChatUnmute(client *Client, event *Event) {
+	if _, ok := h.authorizeModeration(client, event); !ok {
+		return
+	}
+
+	var data ChatMuteCommandData
+	if err := h.parseEventData(event, &data); err != nil || !isValidUUID(data.AuctionID) || !isValidUUID(data.BidderID) {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_DATA", "Invalid mute data"))
+		return
+	}
+
+	err := h.chatService.UnmuteBidder(data.AuctionID, data.BidderID)
+	h.replyCommand(client, event, map[string]interface{}{
+		"auction_id": data.AuctionID,
+		"bidder_id":  data.BidderID,
+		"muted":      false,
+	}, err)
+}
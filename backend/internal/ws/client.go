@@ -3,6 +3,7 @@ package ws
 import (
 	"encoding/json"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -16,29 +17,65 @@ const (
 	maxMessageSize = 512 * 1024          // 最大メッセージサイズ (512KB)
 )
 
+const (
+	// 受信メッセージのレート制限（観覧者はより厳しく制限する）
+	messageRateLimit          = 20 // 認証済みクライアント: 1秒あたりのメッセージ数
+	messageRateBurst          = 40
+	spectatorMessageRateLimit = 1 // 観覧者: 1秒あたりのメッセージ数
+	spectatorMessageRateBurst = 5
+)
+
+// roleSpectator は未認証の観覧者（読み取り専用）のロール
+const roleSpectator = "spectator"
+
 // Client はWebSocket接続を表す
 type Client struct {
 	hub         *Hub            // Hubへの参照
 	conn        *websocket.Conn // WebSocket接続
-	send        chan []byte     // 送信チャネル
+	send        *outbox         // 送信キュー
 	userID      string          // ユーザーID (bidder UUID or admin ID)
 	userRole    string          // ユーザーロール (bidder, auctioneer, system_admin)
 	bidderID    *string         // 入札者ID (bidderの場合のみ、UUID文字列)
 	displayName string          // 表示名
 	auctionIDs  map[string]bool // 購読中のオークションID
+	bidLimiter  *rateLimiter    // 入札のレートリミッター
+	chatLimiter *rateLimiter    // アナウンス・チャット送信のレートリミッター
+	msgLimiter  *rateLimiter    // 受信メッセージ全体のレートリミッター
+	format      wireFormat      // 送受信形式（サブプロトコルで選択）
+	latency     latencyStats    // 接続の遅延統計（時刻同期と入札の証跡に使用）
+
+	// 複数のシャードから同時に参照されるため、購読状態はロックで保護する
+	roomsMu sync.Mutex
 }
 
 // NewClient は新しいクライアントを作成する
 func NewClient(hub *Hub, conn *websocket.Conn, userID, userRole string, bidderID *string, displayName string) *Client {
+	msgLimiter := newRateLimiter(messageRateLimit, messageRateBurst)
+	queueSize := sendQueueSize
+	if userRole == roleSpectator {
+		msgLimiter = newRateLimiter(spectatorMessageRateLimit, spectatorMessageRateBurst)
+		queueSize = spectatorSendQueueSize
+	}
+
+	// 送受信形式はアップグレード時にネゴシエートしたサブプロトコルで決まる
+	format := wireFormatJSON
+	if conn != nil {
+		format = wireFormatFor(conn.Subprotocol())
+	}
+
 	return &Client{
 		hub:         hub,
 		conn:        conn,
-		send:        make(chan []byte, 256),
+		send:        newOutbox(queueSize),
 		userID:      userID,
 		userRole:    userRole,
 		bidderID:    bidderID,
 		displayName: displayName,
 		auctionIDs:  make(map[string]bool),
+		bidLimiter:  newRateLimiter(bidRateLimit, bidRateBurst),
+		chatLimiter: newChatLimiter(userRole),
+		msgLimiter:  msgLimiter,
+		format:      format,
 	}
 }
 
@@ -46,19 +83,23 @@ func NewClient(hub *Hub, conn *websocket.Conn, userID, userRole string, bidderID
 // クライアントごとに1つのgoroutineで実行される
 func (c *Client) readPump() {
 	defer func() {
-		c.hub.unregister <- c
+		c.hub.unregisterClient(c)
 		c.conn.Close()
 	}()
 
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetReadLimit(maxMessageSize)
-	c.conn.SetPongHandler(func(string) error {
-		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(payload string) error {
+		now := time.Now()
+		c.conn.SetReadDeadline(now.Add(pongWait))
+		// Pingフレームに含めた送信時刻から往復遅延を計測する
+		c.latency.recordPong(payload, now)
 		return nil
 	})
 
 	for {
-		_, message, err := c.conn.ReadMessage()
+		messageType, message, err := c.conn.ReadMessage()
+		receivedAt := time.Now()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
@@ -66,6 +107,20 @@ func (c *Client) readPump() {
 			break
 		}
 
+		// レート制限を超えたメッセージは処理しない
+		if !c.msgLimiter.Allow() {
+			c.sendError("RATE_LIMITED", "Too many messages")
+			continue
+		}
+
+		// MessagePackのバイナリフレームはJSONに変換してから処理する
+		message, err = decodeIncoming(messageType, message)
+		if err != nil {
+			log.Printf("Failed to decode message: %v", err)
+			c.sendError("INVALID_EVENT", "Invalid event format")
+			continue
+		}
+
 		// イベントをパース
 		var event Event
 		if err := json.Unmarshal(message, &event); err != nil {
@@ -74,11 +129,11 @@ func (c *Client) readPump() {
 			continue
 		}
 
-		// イベントをハンドラーに渡す
-		c.hub.handleEvent <- &ClientEvent{
-			client: c,
-			event:  &event,
-		}
+		event.receivedAt = receivedAt
+
+		// イベントをこのクライアントのgoroutineで処理する
+		// （入札などDBアクセスを伴う処理が他のクライアントの配信を妨げないようにする）
+		c.hub.eventHandler.Handle(c, &event)
 	}
 }
 
@@ -91,42 +146,74 @@ func (c *Client) writePump() {
 		c.conn.Close()
 	}()
 
+	// MessagePackは圧縮の効果が小さいため、permessage-deflateはJSONクライアントのみ使用する
+	c.conn.EnableWriteCompression(c.format == wireFormatJSON)
+
+	// 接続直後に往復遅延を計測する（以降はpingPeriodごとに計測）
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if err := c.conn.WriteMessage(websocket.PingMessage, pingPayload(time.Now())); err != nil {
+		return
+	}
+
 	for {
 		select {
-		case message, ok := <-c.send:
-			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if !ok {
-				// Hubがチャネルを閉じた
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
-
-			w, err := c.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
-			}
-			w.Write(message)
+		case <-c.send.notify:
+			messages, closed, closeCode := c.send.take()
 
-			// キューにある追加メッセージも送信
-			n := len(c.send)
-			for i := 0; i < n; i++ {
-				w.Write([]byte{'\n'})
-				w.Write(<-c.send)
+			if len(messages) > 0 {
+				c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+				if err := c.writeMessages(messages); err != nil {
+					return
+				}
 			}
 
-			if err := w.Close(); err != nil {
+			if closed {
+				// 送信キューが閉じられた（送信が追いつかない場合は再同期が必要であることを通知する）
+				c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+				c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, ""))
 				return
 			}
 
 		case <-ticker.C:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			if err := c.conn.WriteMessage(websocket.PingMessage, pingPayload(time.Now())); err != nil {
 				return
 			}
 		}
 	}
 }
 
+// writeMessages はキューから取り出したメッセージを送受信形式に応じて書き込む
+func (c *Client) writeMessages(messages []*wireMessage) error {
+	if c.format == wireFormatMsgpack {
+		for _, message := range messages {
+			data := message.encode(wireFormatMsgpack)
+			if data == nil {
+				continue
+			}
+			if err := c.conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	w, err := c.conn.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return err
+	}
+
+	// キューにあるメッセージをまとめて送信
+	for i, message := range messages {
+		if i > 0 {
+			w.Write([]byte{'\n'})
+		}
+		w.Write(message.text)
+	}
+
+	return w.Close()
+}
+
 // sendEvent はクライアントにイベントを送信する
 func (c *Client) sendEvent(event *Event) error {
 	message, err := json.Marshal(event)
@@ -134,14 +221,31 @@ func (c *Client) sendEvent(event *Event) error {
 		return err
 	}
 
-	select {
-	case c.send <- message:
-	default:
-		// 送信バッファがいっぱいの場合、クライアントを切断
-		close(c.send)
+	c.sendRaw(message)
+	return nil
+}
+
+// sendRaw はエンコード済みのメッセージをクライアントに送信する
+func (c *Client) sendRaw(message []byte) {
+	c.enqueue("", newWireMessage(message))
+}
+
+// enqueue は送信キューにメッセージを追加する
+// 既に切断されている場合はfalseを返す。送信が追いつかない場合は再同期要求を送って切断する
+func (c *Client) enqueue(key string, message *wireMessage) bool {
+	switch c.send.push(key, message) {
+	case pushClosed:
+		return false
+	case pushOverflowed:
+		log.Printf("Slow client disconnected: userID=%s, role=%s", c.userID, c.userRole)
 	}
+	return true
+}
 
-	return nil
+// closeSend は送信キューを閉じる（複数回呼び出しても安全）
+// writePumpが未送信のメッセージを送信して接続を閉じ、readPumpの終了時にHubから登録解除される
+func (c *Client) closeSend() {
+	c.send.close()
 }
 
 // sendError はクライアントにエラーイベントを送信する
@@ -150,17 +254,51 @@ func (c *Client) sendError(code, message string) {
 	c.sendEvent(event)
 }
 
-// subscribe はオークションルームに参加する
-func (c *Client) subscribe(auctionID string) {
+// isSpectator は未認証の観覧者かどうかを返す
+func (c *Client) isSpectator() bool {
+	return c.userRole == roleSpectator
+}
+
+// subscribe はオークションルームに参加する（既に参加している場合はfalse）
+func (c *Client) subscribe(auctionID string) bool {
+	c.roomsMu.Lock()
+	defer c.roomsMu.Unlock()
+
+	if c.auctionIDs[auctionID] {
+		return false
+	}
 	c.auctionIDs[auctionID] = true
+	return true
 }
 
-// unsubscribe はオークションルームから退出する
-func (c *Client) unsubscribe(auctionID string) {
+// unsubscribe はオークションルームから退出する（参加していない場合はfalse）
+func (c *Client) unsubscribe(auctionID string) bool {
+	c.roomsMu.Lock()
+	defer c.roomsMu.Unlock()
+
+	if !c.auctionIDs[auctionID] {
+		return false
+	}
 	delete(c.auctionIDs, auctionID)
+	return true
 }
 
 // isSubscribed はオークションルームに参加しているかチェック
 func (c *Client) isSubscribed(auctionID string) bool {
+	c.roomsMu.Lock()
+	defer c.roomsMu.Unlock()
+
 	return c.auctionIDs[auctionID]
 }
+
+// subscribedAuctionIDs は参加中のオークションID一覧を返す
+func (c *Client) subscribedAuctionIDs() []string {
+	c.roomsMu.Lock()
+	defer c.roomsMu.Unlock()
+
+	auctionIDs := make([]string, 0, len(c.auctionIDs))
+	for auctionID := range c.auctionIDs {
+		auctionIDs = append(auctionIDs, auctionID)
+	}
+	return auctionIDs
+}
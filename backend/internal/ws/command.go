@@ -0,0 +1,209 @@
+package ws
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// ItemCommandData は商品操作コマンドのデータ
+type ItemCommandData struct {
+	ItemID string `json:"item_id"`
+}
+
+// PriceOpenCommandData は価格開示コマンドのデータ
+type PriceOpenCommandData struct {
+	ItemID   string `json:"item_id"`
+	NewPrice int64  `json:"new_price"`
+}
+
+// AnnounceCommandData はアナウンスコマンドのデータ
+type AnnounceCommandData struct {
+	AuctionID string `json:"auction_id"`
+	Message   string `json:"message"`
+}
+
+// isAuctioneer は主催者操作が可能なロール（system_admin / auctioneer）かチェック
+func (c *Client) isAuctioneer() bool {
+	return c.userRole == "system_admin" || c.userRole == "auctioneer"
+}
+
+// commandErrorFor はAuctionServiceのエラーをWebSocketエラーコードに変換する
+// REST APIのAuctionHandlerと同じエラー分類を使用する
+func commandErrorFor(err error) eventError {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		return eventError{"ITEM_NOT_FOUND", "Item not found"}
+	case errors.Is(err, service.ErrItemAlreadyStarted):
+		return eventError{"ITEM_ALREADY_STARTED", "Item already started"}
+	case errors.Is(err, service.ErrStartingPriceNotSet):
+		return eventError{"STARTING_PRICE_NOT_SET", "Starting price not set"}
+	case errors.Is(err, service.ErrItemNotStarted):
+		return eventError{"ITEM_NOT_STARTED", "Item not started"}
+	case errors.Is(err, service.ErrItemAlreadyEnded):
+		return eventError{"ITEM_ALREADY_ENDED", "Item already ended"}
+	case errors.Is(err, service.ErrPriceTooLow):
+		return eventError{"PRICE_TOO_LOW", "New price must be higher than current price"}
+	case errors.Is(err, service.ErrNoBidsFound):
+		return eventError{"NO_BIDS_FOUND", "No bids found for this item"}
+	case errors.Is(err, service.ErrStaleFenceToken):
+		return eventError{"STALE_FENCE_TOKEN", "Item was modified by another operation. Please try again"}
+	case errors.Is(err, service.ErrAuctionNotFound):
+		return eventError{"AUCTION_NOT_FOUND", "Auction not found"}
+	case errors.Is(err, service.ErrAuctionNotActive):
+		return eventError{"AUCTION_NOT_ACTIVE", "Auction is not active"}
+	case errors.Is(err, service.ErrBidderNotFound):
+		return eventError{"BIDDER_NOT_FOUND", "Bidder not found"}
+	case errors.Is(err, service.ErrInvalidMessage):
+		return eventError{"INVALID_MESSAGE", fmt.Sprintf("Message must be 1-%d characters", service.MaxChatMessageLength)}
+	case errors.Is(err, service.ErrChatDisabled):
+		return eventError{"CHAT_DISABLED", "Messages are disabled for this auction"}
+	case errors.Is(err, service.ErrChatMuted):
+		return eventError{"CHAT_MUTED", "You are muted in this auction"}
+	case errors.Is(err, service.ErrMessageNotFound):
+		return eventError{"MESSAGE_NOT_FOUND", "Message not found"}
+	default:
+		return eventError{"INTERNAL_ERROR", "Internal server error"}
+	}
+}
+
+// authorizeCommand は主催者コマンドの共通チェックを行う
+// 失敗時はエラー応答を送信してfalseを返す
+func (h *EventHandler) authorizeCommand(client *Client, event *Event) bool {
+	if !h.authorizeAuctioneer(client, event) {
+		return false
+	}
+
+	if h.auctionService == nil {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "COMMAND_UNAVAILABLE", "Commands are not available on this connection"))
+		return false
+	}
+
+	return true
+}
+
+// authorizeAuctioneer は送信者のロールとリクエストIDをチェックする
+// 失敗時はエラー応答を送信してfalseを返す
+func (h *EventHandler) authorizeAuctioneer(client *Client, event *Event) bool {
+	if !client.isAuctioneer() {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "FORBIDDEN", "Only auctioneers can send this command"))
+		return false
+	}
+
+	if event.RequestID == "" || len(event.RequestID) > maxRequestIDLength {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_REQUEST_ID", "Invalid request ID"))
+		return false
+	}
+
+	return true
+}
+
+// replyCommand はコマンドの実行結果をack/errorとして返す
+func (h *EventHandler) replyCommand(client *Client, event *Event, result interface{}, err error) {
+	if err != nil {
+		cmdErr := commandErrorFor(err)
+		if cmdErr.code == "INTERNAL_ERROR" {
+			log.Printf("[Command] %s failed: userID=%s, err=%v", event.Type, client.userID, err)
+		}
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, cmdErr.code, cmdErr.message))
+		return
+	}
+
+	client.sendEvent(NewAckEvent(event.RequestID, result))
+}
+
+// handleItemStart は商品開始コマンドを処理する
+func (h *EventHandler) handleItemStart(client *Client, event *Event) {
+	if !h.authorizeCommand(client, event) {
+		return
+	}
+
+	var data ItemCommandData
+	if err := h.parseEventData(event, &data); err != nil || !isValidUUID(data.ItemID) {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_DATA", "Invalid item data"))
+		return
+	}
+
+	response, err := h.auctionService.StartItem(data.ItemID)
+	h.replyCommand(client, event, response, err)
+}
+
+// handlePriceOpen は価格開示コマンドを処理する
+func (h *EventHandler) handlePriceOpen(client *Client, event *Event) {
+	if !h.authorizeCommand(client, event) {
+		return
+	}
+
+	var data PriceOpenCommandData
+	if err := h.parseEventData(event, &data); err != nil || !isValidUUID(data.ItemID) || data.NewPrice < 1 {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_DATA", "Invalid price data"))
+		return
+	}
+
+	adminID, err := strconv.ParseInt(client.userID, 10, 64)
+	if err != nil {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_USER", "Invalid admin ID"))
+		return
+	}
+
+	response, err := h.auctionService.OpenPrice(data.ItemID, data.NewPrice, adminID)
+	h.replyCommand(client, event, response, err)
+}
+
+// handleItemEnd は商品終了コマンドを処理する
+func (h *EventHandler) handleItemEnd(client *Client, event *Event) {
+	if !h.authorizeCommand(client, event) {
+		return
+	}
+
+	var data ItemCommandData
+	if err := h.parseEventData(event, &data); err != nil || !isValidUUID(data.ItemID) {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_DATA", "Invalid item data"))
+		return
+	}
+
+	response, err := h.auctionService.EndItem(data.ItemID)
+	h.replyCommand(client, event, response, err)
+}
+
+// handleAuctionAnnounce はアナウンスコマンドを処理する
+// アナウンスはオークションの記録として永続化され、Redis Pub/Sub経由で全WebSocketサーバーに配信される
+func (h *EventHandler) handleAuctionAnnounce(client *Client, event *Event) {
+	adminID, ok := h.authorizeModeration(client, event)
+	if !ok {
+		return
+	}
+
+	var data AnnounceCommandData
+	if err := h.parseEventData(event, &data); err != nil || !isValidUUID(data.AuctionID) {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_DATA", "Invalid announcement data"))
+		return
+	}
+
+	if !client.chatLimiter.Allow() {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "RATE_LIMITED", "Too many announcements"))
+		return
+	}
+
+	message, err := h.chatService.Announce(data.AuctionID, adminID, client.displayName, data.Message)
+	if err != nil {
+		h.replyCommand(client, event, nil, err)
+		return
+	}
+
+	h.replyCommand(client, event, map[string]interface{}{
+		"auction_id":   data.AuctionID,
+		"message_id":   message.ID,
+		"announced_at": message.CreatedAt,
+	}, nil)
+}
+
+// isValidUUID はUUID文字列として有効かチェック
+func isValidUUID(s string) bool {
+	_, err := uuid.Parse(s)
+	return err == nil
+}
@@ -0,0 +1,131 @@
+package ws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// readEvent はクライアントの送信キューからイベントを1つ取り出す
+func readEvent(t *testing.T, client *Client) map[string]interface{} {
+	t.Helper()
+	client.send.mu.Lock()
+	defer client.send.mu.Unlock()
+
+	if len(client.send.queue) == 0 {
+		t.Fatal("expected an event to be sent")
+		return nil
+	}
+	message := client.send.queue[0].data.text
+	client.send.queue = client.send.queue[1:]
+
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal(message, &event))
+	return event
+}
+
+func TestEventHandler_AuthorizeCommand(t *testing.T) {
+	handler := NewEventHandler(nil, nil, &service.AuctionService{})
+
+	t.Run("Rejects bidder", func(t *testing.T) {
+		bidderID := "6f1c2d3e-0000-0000-0000-000000000001"
+		client := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+		event := &Event{Type: EventItemStart, RequestID: "req-1"}
+
+		assert.False(t, handler.authorizeCommand(client, event))
+
+		reply := readEvent(t, client)
+		assert.Equal(t, string(EventError), reply["type"])
+		assert.Equal(t, "req-1", reply["request_id"])
+		assert.Equal(t, "FORBIDDEN", reply["data"].(map[string]interface{})["code"])
+	})
+
+	t.Run("Rejects missing request ID", func(t *testing.T) {
+		client := NewClient(nil, nil, "1", "auctioneer", nil, "auctioneer")
+		event := &Event{Type: EventPriceOpen}
+
+		assert.False(t, handler.authorizeCommand(client, event))
+
+		reply := readEvent(t, client)
+		assert.Equal(t, "INVALID_REQUEST_ID", reply["data"].(map[string]interface{})["code"])
+	})
+
+	t.Run("Allows auctioneer and system admin", func(t *testing.T) {
+		for _, role := range []string{"auctioneer", "system_admin"} {
+			client := NewClient(nil, nil, "1", role, nil, role)
+			event := &Event{Type: EventItemEnd, RequestID: "req-2"}
+
+			assert.True(t, handler.authorizeCommand(client, event), role)
+			assert.Equal(t, 0, client.send.len())
+		}
+	})
+}
+
+func TestCommandErrorFor(t *testing.T) {
+	assert.Equal(t, "PRICE_TOO_LOW", commandErrorFor(service.ErrPriceTooLow).code)
+	assert.Equal(t, "ITEM_ALREADY_STARTED", commandErrorFor(service.ErrItemAlreadyStarted).code)
+	assert.Equal(t, "ITEM_ALREADY_ENDED", commandErrorFor(service.ErrItemAlreadyEnded).code)
+	assert.Equal(t, "CHAT_MUTED", commandErrorFor(service.ErrChatMuted).code)
+	assert.Equal(t, "INVALID_MESSAGE", commandErrorFor(service.ErrInvalidMessage).code)
+	assert.Equal(t, "INTERNAL_ERROR", commandErrorFor(assert.AnError).code)
+}
+
+func TestEventHandler_ChatCommands(t *testing.T) {
+	handler := NewEventHandler(nil, nil, nil)
+	handler.chatService = &service.ChatService{}
+	auctionID := "6f1c2d3e-0000-0000-0000-0000000000aa"
+
+	t.Run("Bidder cannot send moderation commands", func(t *testing.T) {
+		bidderID := "6f1c2d3e-0000-0000-0000-000000000001"
+		client := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+
+		for _, eventType := range []EventType{EventAuctionAnnounce, EventChatDelete, EventChatMute, EventChatUnmute} {
+			handler.Handle(client, &Event{Type: eventType, RequestID: "req-1"})
+
+			reply := readEvent(t, client)
+			assert.Equal(t, "FORBIDDEN", reply["data"].(map[string]interface{})["code"], eventType)
+		}
+	})
+
+	t.Run("Auctioneer cannot send bidder messages", func(t *testing.T) {
+		client := NewClient(nil, nil, "1", "auctioneer", nil, "auctioneer")
+		handler.Handle(client, &Event{Type: EventChatSend, RequestID: "req-2"})
+
+		reply := readEvent(t, client)
+		assert.Equal(t, "FORBIDDEN", reply["data"].(map[string]interface{})["code"])
+	})
+
+	t.Run("Bidder must be subscribed to the auction", func(t *testing.T) {
+		bidderID := "6f1c2d3e-0000-0000-0000-000000000002"
+		client := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+		handler.Handle(client, &Event{
+			Type:      EventChatSend,
+			RequestID: "req-3",
+			Data:      map[string]interface{}{"auction_id": auctionID, "message": "hello"},
+		})
+
+		reply := readEvent(t, client)
+		assert.Equal(t, "NOT_SUBSCRIBED", reply["data"].(map[string]interface{})["code"])
+	})
+
+	t.Run("Bidder messages are rate limited", func(t *testing.T) {
+		bidderID := "6f1c2d3e-0000-0000-0000-000000000003"
+		client := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+		client.subscribe(auctionID)
+
+		for i := 0; i < bidderChatRateBurst; i++ {
+			assert.True(t, client.chatLimiter.Allow())
+		}
+		handler.Handle(client, &Event{
+			Type:      EventChatSend,
+			RequestID: "req-4",
+			Data:      map[string]interface{}{"auction_id": auctionID, "message": "hello"},
+		})
+
+		reply := readEvent(t, client)
+		assert.Equal(t, "RATE_LIMITED", reply["data"].(map[string]interface{})["code"])
+	})
+}
@@ -1,17 +1,28 @@
 package ws
 
-import "time"
+import (
+	"time"
+
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/events"
+)
 
 // EventType はWebSocketイベントのタイプを表す
 type EventType string
 
+// サーバーから配信するイベントのうち、APIサーバーが発行するものはeventsパッケージのスキーマで定義する
 const (
 	// オークションイベント
-	EventAuctionStarted   EventType = "auction:started"
-	EventAuctionPriceOpen EventType = "auction:price_open"
-	EventAuctionBid       EventType = "auction:bid"
-	EventAuctionEnded     EventType = "auction:ended"
-	EventAuctionCancelled EventType = "auction:cancelled"
+	EventAuctionStarted   = EventType(events.TypeAuctionStarted)
+	EventAuctionEnded     = EventType(events.TypeAuctionEnded)
+	EventAuctionCancelled = EventType(events.TypeAuctionCancelled)
+	EventAuctionState     EventType = "auction:state" // 購読時に送信するライブ状態のスナップショット
+
+	// 商品イベント
+	EventItemStarted = EventType(events.TypeItemStarted)
+	EventPriceOpened = EventType(events.TypePriceOpened)
+	EventBidPlaced   = EventType(events.TypeBidPlaced)
+	EventItemEnded   = EventType(events.TypeItemEnded)
 
 	// 参加者イベント
 	EventParticipantJoined EventType = "participant:joined"
@@ -24,64 +35,69 @@ const (
 	EventPong        EventType = "pong"
 	EventSubscribe   EventType = "subscribe"
 	EventUnsubscribe EventType = "unsubscribe"
+	EventAck         EventType = "ack"
+
+	// 入札イベント（クライアント → サーバー）
+	EventBidPlace EventType = "bid:place"
+
+	// 主催者コマンド（クライアント → サーバー、system_admin / auctioneerのみ）
+	EventItemStart       EventType = "item:start"
+	EventPriceOpen       EventType = "price:open"
+	EventItemEnd         EventType = "item:end"
+	EventAuctionAnnounce EventType = "auction:announce"
+	EventChatDelete      EventType = "chat:delete"
+	EventChatMute        EventType = "chat:mute"
+	EventChatUnmute      EventType = "chat:unmute"
+	EventLatencyStats    EventType = "latency:stats"
+
+	// 入札者の質問（クライアント → サーバー、入札者のみ）
+	EventChatSend EventType = "chat:send"
+
+	// アナウンス・チャットイベント（サーバー → クライアント）
+	EventAuctionAnnouncement = EventType(events.TypeAuctionAnnouncement)
+	EventChatMessage         = EventType(events.TypeChatMessage) // 管理者と送信した入札者本人にのみ配信
+	EventChatMessageDeleted  = EventType(events.TypeChatMessageDeleted)
+
+	// 個別通知イベント（サーバー → 特定の入札者）
+	EventBidOutbid     = EventType(events.TypeBidOutbid)
+	EventPointsUpdated = EventType(events.TypePointsUpdated)
+	EventItemWon       = EventType(events.TypeItemWon)
+	EventItemLost      = EventType(events.TypeItemLost)
+	EventChatMuted     = EventType(events.TypeChatMuted)
+
+	// 個別通知イベント（サーバー → 特定の管理者）
+	EventPointGrantDecided = EventType(events.TypePointGrantDecided)
 )
 
 // Event はWebSocketイベントの基本構造
 type Event struct {
 	Type      EventType   `json:"type"`
+	Version   int         `json:"version"` // イベントスキーマのバージョン（events.SchemaVersion）
 	AuctionID string      `json:"auction_id,omitempty"`
+	RequestID string      `json:"request_id,omitempty"` // クライアント指定のリクエストID（ack/errorの相関用）
 	Data      interface{} `json:"data,omitempty"`
 	Timestamp time.Time   `json:"timestamp"`
-}
-
-// AuctionStartedData はオークション開始イベントのデータ
-type AuctionStartedData struct {
-	AuctionID     string `json:"auction_id"`
-	Title         string `json:"title"`
-	ItemName      string `json:"item_name"`
-	StartingPrice int    `json:"starting_price"`
-	CurrentPrice  int    `json:"current_price"`
-}
 
-// PriceOpenData は価格開示イベントのデータ
-type PriceOpenData struct {
-	AuctionID     string    `json:"auction_id"`
-	NewPrice      int       `json:"new_price"`
-	PreviousPrice int       `json:"previous_price,omitempty"`
-	OpenedAt      time.Time `json:"opened_at"`
+	receivedAt time.Time // クライアントから受信した時刻（受信したイベントのみ）
 }
 
-// BidData は入札イベントのデータ
-type BidData struct {
-	BidID      int64     `json:"bid_id"`
-	AuctionID  string    `json:"auction_id"`
-	BidderName string    `json:"bidder_name"` // display_name or anonymized
-	Price      int       `json:"price"`
-	BidAt      time.Time `json:"bid_at"`
-	IsWinning  bool      `json:"is_winning"`
+// ErrorData はエラーイベントのデータ
+type ErrorData struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"` // エラーの詳細（エラーコードごとに形式が異なる）
 }
 
-// AuctionEndedData はオークション終了イベントのデータ
-type AuctionEndedData struct {
-	AuctionID  string     `json:"auction_id"`
-	WinnerID   *string    `json:"winner_id,omitempty"` // UUID string
-	WinnerName *string    `json:"winner_name,omitempty"`
-	FinalPrice *int       `json:"final_price,omitempty"`
-	EndedAt    time.Time  `json:"ended_at"`
-	Reason     string     `json:"reason"` // "sold", "no_bids", "cancelled"
+// BidPlaceData は入札リクエストのデータ
+type BidPlaceData struct {
+	ItemID string `json:"item_id"`
+	Price  int64  `json:"price"`
 }
 
-// AuctionCancelledData はオークション中止イベントのデータ
-type AuctionCancelledData struct {
-	AuctionID   string    `json:"auction_id"`
-	CancelledAt time.Time `json:"cancelled_at"`
-	Reason      string    `json:"reason"`
-}
-
-// ErrorData はエラーイベントのデータ
-type ErrorData struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+// BidAckData は入札受付応答のデータ
+type BidAckData struct {
+	Bid    *domain.Bid          `json:"bid"`
+	Points *domain.BidderPoints `json:"points"`
 }
 
 // SubscribeData はサブスクライブリクエストのデータ
@@ -90,12 +106,15 @@ type SubscribeData struct {
 }
 
 // ParticipantData は参加者情報のデータ
+// 入札者には他の入札者の識別情報の代わりにパドル番号と本人フラグを送る
 type ParticipantData struct {
-	BidderID    string     `json:"bidder_id"`
-	DisplayName string     `json:"display_name"`
-	IsOnline    bool       `json:"is_online"`
-	BidCount    int64      `json:"bid_count"`
-	LastBidAt   *time.Time `json:"last_bid_at,omitempty"`
+	BidderID     string     `json:"bidder_id,omitempty"`
+	DisplayName  string     `json:"display_name,omitempty"`
+	PaddleNumber int64      `json:"paddle_number,omitempty"`
+	IsMine       bool       `json:"is_mine,omitempty"`
+	IsOnline     bool       `json:"is_online"`
+	BidCount     int64      `json:"bid_count"`
+	LastBidAt    *time.Time `json:"last_bid_at,omitempty"`
 }
 
 // ParticipantJoinedData は参加者参加イベントのデータ
@@ -106,20 +125,23 @@ type ParticipantJoinedData struct {
 
 // ParticipantLeftData は参加者退出イベントのデータ
 type ParticipantLeftData struct {
-	AuctionID string `json:"auction_id"`
-	BidderID  string `json:"bidder_id"`
+	AuctionID    string `json:"auction_id"`
+	BidderID     string `json:"bidder_id"`
+	PaddleNumber int64  `json:"paddle_number,omitempty"`
 }
 
 // ParticipantsListData は参加者一覧イベントのデータ
 type ParticipantsListData struct {
 	AuctionID    string            `json:"auction_id"`
 	Participants []ParticipantData `json:"participants"`
+	Count        int               `json:"count"` // 参加者数（観覧者は含まない）
 }
 
 // NewEvent は新しいイベントを作成する
 func NewEvent(eventType EventType, auctionID string, data interface{}) *Event {
 	return &Event{
 		Type:      eventType,
+		Version:   events.SchemaVersion,
 		AuctionID: auctionID,
 		Data:      data,
 		Timestamp: time.Now(),
@@ -129,7 +151,8 @@ func NewEvent(eventType EventType, auctionID string, data interface{}) *Event {
 // NewErrorEvent はエラーイベントを作成する
 func NewErrorEvent(code, message string) *Event {
 	return &Event{
-		Type: EventError,
+		Type:    EventError,
+		Version: events.SchemaVersion,
 		Data: ErrorData{
 			Code:    code,
 			Message: message,
@@ -137,3 +160,21 @@ func NewErrorEvent(code, message string) *Event {
 		Timestamp: time.Now(),
 	}
 }
+
+// NewAckEvent はリクエストに対する成功応答イベントを作成する
+func NewAckEvent(requestID string, data interface{}) *Event {
+	return &Event{
+		Type:      EventAck,
+		Version:   events.SchemaVersion,
+		RequestID: requestID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+}
+
+// NewRequestErrorEvent はリクエストに対するエラー応答イベントを作成する
+func NewRequestErrorEvent(requestID, code, message string) *Event {
+	event := NewErrorEvent(code, message)
+	event.RequestID = requestID
+	return event
+}
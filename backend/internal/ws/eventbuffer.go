@@ -0,0 +1,130 @@
+package ws
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	eventBufferSize        = 256              // オークションごとに保持するイベント数
+	eventSubscriberBuffer  = 64               // 購読者ごとの送信バッファ
+	eventBufferIdleTTL     = 30 * time.Minute // 購読者もイベントもないバッファを破棄するまでの時間
+	eventBufferSweepPeriod = 5 * time.Minute  // アイドルなバッファを確認する間隔
+)
+
+// bufferedEvent は再送用に保持するイベント
+type bufferedEvent struct {
+	ID      uint64
+	Type    string
+	Message []byte // クライアントに送信するJSON（公開用に射影済み）
+}
+
+// auctionEventBuffer はオークションごとのイベントを一定数保持するリングバッファ
+// SSEクライアントはLast-Event-IDを使って取りこぼしたイベントを再取得できる
+// イベントIDの連番はバッファごとのため、IDにはバッファのエポックを付与する
+// （サーバーの再起動・別インスタンスへの再接続・バッファの破棄後は別のエポックになる）
+type auctionEventBuffer struct {
+	mu          sync.Mutex
+	epoch       string
+	events      []bufferedEvent // 古い順
+	size        int
+	lastID      uint64
+	lastActive  time.Time // 最後にイベントを保持した、または購読が終了した時刻
+	subscribers map[chan bufferedEvent]struct{}
+}
+
+// newAuctionEventBuffer は新しいイベントバッファを作成する
+func newAuctionEventBuffer(size int) *auctionEventBuffer {
+	return &auctionEventBuffer{
+		epoch:       strings.ReplaceAll(uuid.NewString(), "-", "")[:12],
+		events:      make([]bufferedEvent, 0, size),
+		size:        size,
+		lastActive:  time.Now(),
+		subscribers: make(map[chan bufferedEvent]struct{}),
+	}
+}
+
+// append はイベントにIDを採番して保持し、購読者に配信する
+// 送信バッファがいっぱいの購読者はチャネルを閉じて切断する（再接続時にLast-Event-IDで再送される）
+func (b *auctionEventBuffer) append(eventType string, message []byte) bufferedEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	b.lastActive = time.Now()
+	event := bufferedEvent{ID: b.lastID, Type: eventType, Message: message}
+
+	if len(b.events) == b.size {
+		copy(b.events, b.events[1:])
+		b.events = b.events[:b.size-1]
+	}
+	b.events = append(b.events, event)
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return event
+}
+
+// subscribe は新しいイベントの購読を開始し、epochのlastEventID以降の保持済みイベントを返す
+// ok=falseの場合、要求されたイベントは既にバッファから消えているか別のエポックのもの
+// （クライアントは状態を再取得する必要がある）
+func (b *auctionEventBuffer) subscribe(epoch string, lastEventID uint64) (ch chan bufferedEvent, missed []bufferedEvent, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch = make(chan bufferedEvent, eventSubscriberBuffer)
+	b.subscribers[ch] = struct{}{}
+
+	if epoch == "" && lastEventID == 0 {
+		return ch, nil, true
+	}
+	if epoch != b.epoch {
+		return ch, nil, false
+	}
+
+	if lastEventID == 0 || lastEventID >= b.lastID {
+		return ch, nil, lastEventID <= b.lastID
+	}
+
+	// 要求されたIDの次のイベントが残っているか確認
+	if len(b.events) == 0 || b.events[0].ID > lastEventID+1 {
+		return ch, nil, false
+	}
+
+	for _, event := range b.events {
+		if event.ID > lastEventID {
+			missed = append(missed, event)
+		}
+	}
+	return ch, missed, true
+}
+
+// unsubscribe は購読を終了する
+func (b *auctionEventBuffer) unsubscribe(ch chan bufferedEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+	b.lastActive = time.Now()
+}
+
+// idle は購読者がおらず、ttlの間イベントもないかどうかを返す
+func (b *auctionEventBuffer) idle(now time.Time, ttl time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscribers) == 0 && now.Sub(b.lastActive) >= ttl
+}
@@ -3,22 +3,43 @@ package ws
 import (
 	"encoding/json"
 	"log"
+
+	"github.com/google/uuid"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
 )
 
+// spectatorAllowedEvents は観覧者が送信できるイベント
+var spectatorAllowedEvents = map[EventType]bool{
+	EventSubscribe:   true,
+	EventUnsubscribe: true,
+	EventPing:        true,
+}
+
 // EventHandler はクライアントからのイベントを処理する
 type EventHandler struct {
-	hub *Hub
+	hub            *Hub
+	bidService     *service.BidService
+	auctionService *service.AuctionService
+	chatService    *service.ChatService // nilの場合はアナウンス・チャットを受け付けない
 }
 
 // NewEventHandler は新しいEventHandlerを作成する
-func NewEventHandler(hub *Hub) *EventHandler {
+func NewEventHandler(hub *Hub, bidService *service.BidService, auctionService *service.AuctionService) *EventHandler {
 	return &EventHandler{
-		hub: hub,
+		hub:            hub,
+		bidService:     bidService,
+		auctionService: auctionService,
 	}
 }
 
 // Handle はイベントを処理する
 func (h *EventHandler) Handle(client *Client, event *Event) {
+	// 観覧者は読み取り専用（購読とPingのみ許可）
+	if client.isSpectator() && !spectatorAllowedEvents[event.Type] {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "FORBIDDEN", "Spectators are read-only"))
+		return
+	}
+
 	switch event.Type {
 	case EventSubscribe:
 		h.handleSubscribe(client, event)
@@ -26,6 +47,26 @@ func (h *EventHandler) Handle(client *Client, event *Event) {
 		h.handleUnsubscribe(client, event)
 	case EventPing:
 		h.handlePing(client, event)
+	case EventBidPlace:
+		h.handleBidPlace(client, event)
+	case EventItemStart:
+		h.handleItemStart(client, event)
+	case EventPriceOpen:
+		h.handlePriceOpen(client, event)
+	case EventItemEnd:
+		h.handleItemEnd(client, event)
+	case EventAuctionAnnounce:
+		h.handleAuctionAnnounce(client, event)
+	case EventChatSend:
+		h.handleChatSend(client, event)
+	case EventChatDelete:
+		h.handleChatDelete(client, event)
+	case EventChatMute:
+		h.handleChatMute(client, event)
+	case EventChatUnmute:
+		h.handleChatUnmute(client, event)
+	case EventLatencyStats:
+		h.handleLatencyStats(client, event)
 	default:
 		log.Printf("Unknown event type: %s", event.Type)
 		client.sendError("UNKNOWN_EVENT", "Unknown event type")
@@ -63,11 +104,16 @@ func (h *EventHandler) handleSubscribe(client *Client, event *Event) {
 		log.Printf("Failed to get active participants: %v", err)
 		participants = []ParticipantData{} // エラー時は空配列
 	}
+	participantCount := len(participants)
+
+	// 入札者には他の入札者の識別情報の代わりにパドル番号を、観覧者には人数のみ送信する
+	participants = participantsForClient(participants, client)
 
 	// 初期参加者リストを送信
 	participantsListEvent := NewEvent(EventParticipantsList, data.AuctionID, ParticipantsListData{
 		AuctionID:    data.AuctionID,
 		Participants: participants,
+		Count:        participantCount,
 	})
 	client.sendEvent(participantsListEvent)
 
@@ -77,6 +123,31 @@ func (h *EventHandler) handleSubscribe(client *Client, event *Event) {
 		"message":    "Successfully subscribed to auction",
 	})
 	client.sendEvent(response)
+
+	// 現在のライブ状態を送信（複数のREST APIを組み合わせずに画面を復元できるようにする）
+	h.sendAuctionState(client, data.AuctionID)
+}
+
+// sendAuctionState はオークションのライブ状態を閲覧者に応じて射影して送信する
+func (h *EventHandler) sendAuctionState(client *Client, auctionID string) {
+	if h.auctionService == nil {
+		return
+	}
+
+	state, err := h.auctionService.GetLiveState(auctionID)
+	if err != nil {
+		log.Printf("Failed to get live state: auctionID=%s, err=%v", auctionID, err)
+		return
+	}
+
+	var bidderID *uuid.UUID
+	if client.bidderID != nil {
+		if id, err := uuid.Parse(*client.bidderID); err == nil {
+			bidderID = &id
+		}
+	}
+
+	client.sendEvent(NewEvent(EventAuctionState, auctionID, state.ForViewer(bidderID, client.audience() == audienceAdmin)))
 }
 
 // handleUnsubscribe はオークションルームからの退出リクエストを処理する
@@ -103,14 +174,6 @@ func (h *EventHandler) handleUnsubscribe(client *Client, event *Event) {
 	client.sendEvent(response)
 }
 
-// handlePing はPingリクエストを処理する
-func (h *EventHandler) handlePing(client *Client, event *Event) {
-	response := NewEvent(EventPong, "", map[string]interface{}{
-		"message": "pong",
-	})
-	client.sendEvent(response)
-}
-
 // parseEventData はイベントデータをパースする
 func (h *EventHandler) parseEventData(event *Event, v interface{}) error {
 	data, err := json.Marshal(event.Data)
@@ -4,167 +4,317 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"github.com/tsutsumi389/real-time-auction/internal/events"
 	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
 )
 
 // Hub はWebSocket接続を管理する
+//
+// 登録・配信・イベント処理を単一のgoroutineに集約せず、次のように分散する:
+//   - クライアントからのイベントは各クライアントのreadPump goroutineで処理する
+//   - オークションルームはオークションIDのハッシュでシャードに分散し、シャードごとのgoroutineで配信する
+//   - イベントは受信者区分ごとに一度だけシリアライズし、全クライアントで共有する
 type Hub struct {
 	// クライアント管理
-	clients    map[*Client]bool       // 登録されているクライアント
-	rooms      map[string][]*Client   // オークションID -> クライアントリスト
-	roomsMutex sync.RWMutex           // ルームマップのロック
+	clients      map[*Client]bool // 登録されているクライアント
+	clientsMutex sync.RWMutex     // クライアントマップのロック
 
-	// チャネル
-	register     chan *Client        // クライアント登録
-	unregister   chan *Client        // クライアント登録解除
-	broadcast    chan *BroadcastMsg  // ブロードキャストメッセージ
-	handleEvent  chan *ClientEvent   // クライアントイベント
+	// オークションルーム（オークションIDでシャードに分散）
+	shards [numRoomShards]*roomShard
+
+	// 入札者ID -> 接続中クライアント（個別通知の配送先）
+	bidderClients map[string]map[*Client]bool
+	bidderMutex   sync.RWMutex
+
+	// 管理者ID -> 接続中クライアント（管理者への個別通知の配送先）
+	adminClients map[string]map[*Client]bool
+	adminMutex   sync.RWMutex
+
+	// オークションID -> 直近のイベント（SSEのLast-Event-ID再送用）
+	eventBuffers      map[string]*auctionEventBuffer
+	eventBuffersMutex sync.Mutex
+
+	// ユーザー・IPアドレスごとの接続数上限
+	connLimiter *connectionLimiter
+
+	// 観覧者（未認証接続）の接続数と上限
+	spectatorCount int64
+	maxSpectators  int64
 
 	// Redis
-	redisClient  *redis.Client
-	ctx          context.Context
+	redisClient *redis.Client
+	ctx         context.Context
 
 	// Repository
-	auctionRepo  *repository.AuctionRepository
+	auctionRepo *repository.AuctionRepository
 
 	// イベントハンドラー
 	eventHandler *EventHandler
 }
 
+// defaultMaxSpectators は観覧者接続数の上限のデフォルト値
+const defaultMaxSpectators = 1000
+
 // BroadcastMsg はブロードキャストメッセージを表す
 type BroadcastMsg struct {
-	auctionID string  // 空文字列の場合は全クライアントに送信
+	auctionID string // 空文字列の場合は全クライアントに送信
 	event     *Event
 }
 
-// ClientEvent はクライアントからのイベントを表す
-type ClientEvent struct {
-	client *Client
-	event  *Event
-}
-
-// NewHub は新しいHubを作成する
-func NewHub(redisClient *redis.Client, auctionRepo *repository.AuctionRepository) *Hub {
+// NewHub は新しいHubを作成し、シャードの配信goroutineを開始する
+func NewHub(redisClient *redis.Client, auctionRepo *repository.AuctionRepository, bidService *service.BidService, auctionService *service.AuctionService) *Hub {
 	hub := &Hub{
-		clients:      make(map[*Client]bool),
-		rooms:        make(map[string][]*Client),
-		register:     make(chan *Client),
-		unregister:   make(chan *Client),
-		broadcast:    make(chan *BroadcastMsg, 256),
-		handleEvent:  make(chan *ClientEvent, 256),
-		redisClient:  redisClient,
-		ctx:          context.Background(),
-		auctionRepo:  auctionRepo,
+		clients:       make(map[*Client]bool),
+		bidderClients: make(map[string]map[*Client]bool),
+		adminClients:  make(map[string]map[*Client]bool),
+		eventBuffers:  make(map[string]*auctionEventBuffer),
+		redisClient:   redisClient,
+		ctx:           context.Background(),
+		auctionRepo:   auctionRepo,
+		maxSpectators: defaultMaxSpectators,
+		connLimiter:   newConnectionLimiter(DefaultConnectionLimits()),
+	}
+
+	for i := range hub.shards {
+		hub.shards[i] = newRoomShard()
+		go hub.shards[i].run()
 	}
 
+	go hub.sweepEventBuffers()
+
 	// イベントハンドラーを初期化
-	hub.eventHandler = NewEventHandler(hub)
+	hub.eventHandler = NewEventHandler(hub, bidService, auctionService)
 
 	return hub
 }
 
-// Run はHubのメインループを開始する
+// Run はRedis Pub/Subからのイベント配信ループを開始する
 func (h *Hub) Run() {
-	// Redis Pub/Subリスナーを開始
-	go h.listenRedis()
+	h.listenRedis()
+}
 
-	for {
-		select {
-		case client := <-h.register:
-			h.registerClient(client)
+// shardFor はオークションルームを担当するシャードを返す
+func (h *Hub) shardFor(auctionID string) *roomShard {
+	return h.shards[shardIndex(auctionID)]
+}
 
-		case client := <-h.unregister:
-			h.unregisterClient(client)
+// registerClient はクライアントを登録する
+func (h *Hub) registerClient(client *Client) {
+	h.clientsMutex.Lock()
+	h.clients[client] = true
+	h.clientsMutex.Unlock()
 
-		case msg := <-h.broadcast:
-			h.broadcastMessage(msg)
+	// 入札者の場合は個別通知用のインデックスに追加
+	if client.bidderID != nil {
+		h.bidderMutex.Lock()
+		if _, ok := h.bidderClients[*client.bidderID]; !ok {
+			h.bidderClients[*client.bidderID] = make(map[*Client]bool)
+		}
+		h.bidderClients[*client.bidderID][client] = true
+		h.bidderMutex.Unlock()
+	}
 
-		case clientEvent := <-h.handleEvent:
-			h.eventHandler.Handle(clientEvent.client, clientEvent.event)
+	// 管理者の場合は管理者への個別通知用のインデックスに追加
+	if client.isAuctioneer() {
+		h.adminMutex.Lock()
+		if _, ok := h.adminClients[client.userID]; !ok {
+			h.adminClients[client.userID] = make(map[*Client]bool)
 		}
+		h.adminClients[client.userID][client] = true
+		h.adminMutex.Unlock()
 	}
-}
 
-// registerClient はクライアントを登録する
-func (h *Hub) registerClient(client *Client) {
-	h.clients[client] = true
 	log.Printf("Client registered: userID=%s, role=%s", client.userID, client.userRole)
 }
 
-// unregisterClient はクライアントの登録を解除する
+// unregisterClient はクライアントの登録を解除し、送信チャネルを閉じる
 func (h *Hub) unregisterClient(client *Client) {
-	if _, ok := h.clients[client]; ok {
-		delete(h.clients, client)
-		close(client.send)
-
-		// ルームから削除
-		h.roomsMutex.Lock()
-		for auctionID := range client.auctionIDs {
-			h.removeClientFromRoom(auctionID, client)
+	h.clientsMutex.Lock()
+	if _, ok := h.clients[client]; !ok {
+		h.clientsMutex.Unlock()
+		return
+	}
+	delete(h.clients, client)
+	h.clientsMutex.Unlock()
+
+	// 個別通知用のインデックスから削除
+	if client.bidderID != nil {
+		h.bidderMutex.Lock()
+		delete(h.bidderClients[*client.bidderID], client)
+		if len(h.bidderClients[*client.bidderID]) == 0 {
+			delete(h.bidderClients, *client.bidderID)
 		}
-		h.roomsMutex.Unlock()
+		h.bidderMutex.Unlock()
+	}
+	if client.isAuctioneer() {
+		h.adminMutex.Lock()
+		delete(h.adminClients[client.userID], client)
+		if len(h.adminClients[client.userID]) == 0 {
+			delete(h.adminClients, client.userID)
+		}
+		h.adminMutex.Unlock()
+	}
+
+	// 接続数上限の枠を解放
+	h.connLimiter.release(client)
+
+	// 観覧者の接続枠を解放
+	if client.isSpectator() {
+		h.releaseSpectatorSlot()
+	}
+
+	// ルームから削除
+	for _, auctionID := range client.subscribedAuctionIDs() {
+		h.RemoveClientFromRoom(auctionID, client)
+	}
+
+	client.closeSend()
 
-		log.Printf("Client unregistered: userID=%s, role=%s", client.userID, client.userRole)
+	if client.bidderID != nil {
+		latency := client.latency.snapshot()
+		log.Printf("Client unregistered: userID=%s, role=%s, rttSamples=%d, medianRTTMs=%s, clockOffsetMs=%s",
+			client.userID, client.userRole, latency.Samples, formatOptionalInt(latency.MedianRTTMs), formatOptionalInt(latency.ClockOffsetMs))
+		return
 	}
+	log.Printf("Client unregistered: userID=%s, role=%s", client.userID, client.userRole)
 }
 
-// broadcastMessage はメッセージをブロードキャストする
-func (h *Hub) broadcastMessage(msg *BroadcastMsg) {
-	message, err := json.Marshal(msg.event)
+// SetConnectionLimits はユーザー・IPアドレスごとの接続数上限を設定する（Run前に呼び出す）
+func (h *Hub) SetConnectionLimits(limits ConnectionLimits) {
+	h.connLimiter = newConnectionLimiter(limits)
+}
+
+// admit は接続数上限を確認し、受け付けた場合はクライアントを登録して送受信を開始する
+// 上限を超えた場合はクローズコード付きで切断してfalseを返す
+func (h *Hub) admit(client *Client, ip string) bool {
+	evicted, closeCode, reason := h.connLimiter.acquire(client, ip)
+	if closeCode != 0 {
+		log.Printf("Connection rejected: userID=%s, ip=%s, code=%d, reason=%s", client.userID, ip, closeCode, reason)
+		closeConn(client.conn, closeCode, reason)
+		return false
+	}
+
+	// 同じ入札者の古い接続を切断（切断後、readPumpの終了により登録解除される）
+	if evicted != nil {
+		log.Printf("Connection replaced: userID=%s", evicted.userID)
+		closeConn(evicted.conn, CloseSessionReplaced, "session replaced by a newer connection")
+	}
+
+	h.registerClient(client)
+
+	go client.writePump()
+	go client.readPump()
+	return true
+}
+
+// SetChatService はアナウンス・チャットを処理するサービスを設定する（Run前に呼び出す）
+func (h *Hub) SetChatService(chatService *service.ChatService) {
+	h.eventHandler.chatService = chatService
+}
+
+// SetMaxSpectators は観覧者接続数の上限を設定する（Run前に呼び出す）
+func (h *Hub) SetMaxSpectators(max int64) {
+	h.maxSpectators = max
+}
+
+// reserveSpectatorSlot は観覧者の接続枠を確保する（上限に達している場合はfalse）
+func (h *Hub) reserveSpectatorSlot() bool {
+	if atomic.AddInt64(&h.spectatorCount, 1) > h.maxSpectators {
+		atomic.AddInt64(&h.spectatorCount, -1)
+		return false
+	}
+	return true
+}
+
+// releaseSpectatorSlot は観覧者の接続枠を解放する
+func (h *Hub) releaseSpectatorSlot() {
+	atomic.AddInt64(&h.spectatorCount, -1)
+}
+
+// GetSpectatorCount は観覧者の接続数を返す
+func (h *Hub) GetSpectatorCount() int64 {
+	return atomic.LoadInt64(&h.spectatorCount)
+}
+
+// newRoomMessage はイベントを一度だけシリアライズして配信用メッセージを作成する
+// オークションの公開イベントはSSEクライアント向けにも保持する
+func (h *Hub) newRoomMessage(auctionID string, event *Event) (*roomMessage, bool) {
+	message, err := json.Marshal(event)
 	if err != nil {
 		log.Printf("Failed to marshal event: %v", err)
-		return
+		return nil, false
 	}
 
-	if msg.auctionID == "" {
-		// 全クライアントにブロードキャスト
-		for client := range h.clients {
-			select {
-			case client.send <- message:
-			default:
-				close(client.send)
-				delete(h.clients, client)
-			}
+	msg := &roomMessage{
+		auctionID: auctionID,
+		key:       coalesceKey(event),
+		raw:       newWireMessage(message),
+		public:    isPublicEvent(event.Type),
+	}
+
+	// 参加者の入退室イベントは入札者の識別情報を含むため、受信者区分ごとに射影する
+	if !msg.public {
+		var decoded struct {
+			Data map[string]interface{} `json:"data"`
 		}
-	} else {
-		// 特定のオークションルームにブロードキャスト
-		h.roomsMutex.RLock()
-		clients := h.rooms[msg.auctionID]
-		h.roomsMutex.RUnlock()
-
-		for _, client := range clients {
-			select {
-			case client.send <- message:
-			default:
-				close(client.send)
-				delete(h.clients, client)
-			}
+		if err := json.Unmarshal(message, &decoded); err != nil {
+			log.Printf("Failed to decode event for projection: %v", err)
+			return nil, false
 		}
+		msg.projected = newProjectedMessages(string(event.Type), decoded.Data)
 	}
+
+	if auctionID != "" && msg.public {
+		h.recordAuctionEvent(auctionID, string(event.Type), message)
+	}
+
+	return msg, true
 }
 
-// AddClientToRoom はクライアントをオークションルームに追加する
-func (h *Hub) AddClientToRoom(auctionID string, client *Client) {
-	h.roomsMutex.Lock()
-	defer h.roomsMutex.Unlock()
+// broadcastMessage はメッセージを呼び出し元のgoroutineで同期的にブロードキャストする
+func (h *Hub) broadcastMessage(msg *BroadcastMsg) {
+	roomMsg, ok := h.newRoomMessage(msg.auctionID, msg.event)
+	if !ok {
+		return
+	}
 
-	if _, ok := h.rooms[auctionID]; !ok {
-		h.rooms[auctionID] = make([]*Client, 0)
+	if msg.auctionID == "" {
+		h.sendToAll(roomMsg)
+		return
 	}
+	h.shardFor(msg.auctionID).deliver(roomMsg)
+}
 
-	// 既に存在する場合は追加しない
-	for _, c := range h.rooms[auctionID] {
-		if c == client {
-			return
+// sendToAll は全クライアントにメッセージを送信する
+func (h *Hub) sendToAll(msg *roomMessage) {
+	h.clientsMutex.RLock()
+	defer h.clientsMutex.RUnlock()
+
+	for client := range h.clients {
+		message := msg.forClient(client)
+		if message == nil {
+			continue
 		}
+		client.enqueue(msg.key, message)
+	}
+}
+
+// AddClientToRoom はクライアントをオークションルームに追加する
+func (h *Hub) AddClientToRoom(auctionID string, client *Client) {
+	// 既に参加している場合は追加しない
+	if !client.subscribe(auctionID) {
+		return
 	}
 
-	h.rooms[auctionID] = append(h.rooms[auctionID], client)
-	client.subscribe(auctionID)
+	h.shardFor(auctionID).join(auctionID, client)
 
 	log.Printf("Client added to room: userID=%s, auctionID=%s", client.userID, auctionID)
 
@@ -175,7 +325,12 @@ func (h *Hub) AddClientToRoom(auctionID string, client *Client) {
 }
 
 // broadcastParticipantJoined は参加者参加イベントをブロードキャストする
+// 参加したクライアントのgoroutineで呼び出されるため、DBアクセスは配信処理を妨げない
 func (h *Hub) broadcastParticipantJoined(auctionID string, client *Client) {
+	if h.auctionRepo == nil {
+		return
+	}
+
 	// データベースから入札者情報を取得
 	bidderUUID, err := uuid.Parse(*client.bidderID)
 	if err != nil {
@@ -191,11 +346,12 @@ func (h *Hub) broadcastParticipantJoined(auctionID string, client *Client) {
 
 	// イベントデータを作成
 	participantData := ParticipantData{
-		BidderID:    participantInfo.BidderID.String(),
-		DisplayName: participantInfo.DisplayName,
-		IsOnline:    true,
-		BidCount:    participantInfo.BidCount,
-		LastBidAt:   participantInfo.LastBidAt,
+		BidderID:     participantInfo.BidderID.String(),
+		DisplayName:  participantInfo.DisplayName,
+		PaddleNumber: h.paddleNumber(auctionID, *client.bidderID),
+		IsOnline:     true,
+		BidCount:     participantInfo.BidCount,
+		LastBidAt:    participantInfo.LastBidAt,
 	}
 
 	event := NewEvent(EventParticipantJoined, auctionID, ParticipantJoinedData{
@@ -209,44 +365,26 @@ func (h *Hub) broadcastParticipantJoined(auctionID string, client *Client) {
 
 // RemoveClientFromRoom はクライアントをオークションルームから削除する
 func (h *Hub) RemoveClientFromRoom(auctionID string, client *Client) {
-	h.roomsMutex.Lock()
-	defer h.roomsMutex.Unlock()
-
-	h.removeClientFromRoom(auctionID, client)
-}
-
-// removeClientFromRoom はクライアントをオークションルームから削除する（ロックなし）
-func (h *Hub) removeClientFromRoom(auctionID string, client *Client) {
-	clients, ok := h.rooms[auctionID]
-	if !ok {
+	if !client.unsubscribe(auctionID) {
 		return
 	}
 
-	for i, c := range clients {
-		if c == client {
-			h.rooms[auctionID] = append(clients[:i], clients[i+1:]...)
-			client.unsubscribe(auctionID)
-			log.Printf("Client removed from room: userID=%s, auctionID=%s", client.userID, auctionID)
+	h.shardFor(auctionID).leave(auctionID, client)
 
-			// bidderの場合のみ退出イベントを送信
-			if c.userRole == "bidder" && c.bidderID != nil {
-				h.broadcastParticipantLeft(auctionID, c)
-			}
-			break
-		}
-	}
+	log.Printf("Client removed from room: userID=%s, auctionID=%s", client.userID, auctionID)
 
-	// ルームが空になった場合は削除
-	if len(h.rooms[auctionID]) == 0 {
-		delete(h.rooms, auctionID)
+	// bidderの場合のみ退出イベントを送信
+	if client.userRole == "bidder" && client.bidderID != nil {
+		h.broadcastParticipantLeft(auctionID, client)
 	}
 }
 
 // broadcastParticipantLeft は参加者退出イベントをブロードキャストする
 func (h *Hub) broadcastParticipantLeft(auctionID string, client *Client) {
 	event := NewEvent(EventParticipantLeft, auctionID, ParticipantLeftData{
-		AuctionID: auctionID,
-		BidderID:  *client.bidderID,
+		AuctionID:    auctionID,
+		BidderID:     *client.bidderID,
+		PaddleNumber: h.paddleNumber(auctionID, *client.bidderID),
 	})
 
 	// オークションルームにブロードキャスト
@@ -254,41 +392,29 @@ func (h *Hub) broadcastParticipantLeft(auctionID string, client *Client) {
 }
 
 // BroadcastToAuction はオークションルームにイベントをブロードキャストする
+// 配信はルームを担当するシャードのgoroutineで非同期に行う
 func (h *Hub) BroadcastToAuction(auctionID string, event *Event) {
-	h.broadcast <- &BroadcastMsg{
-		auctionID: auctionID,
-		event:     event,
+	msg, ok := h.newRoomMessage(auctionID, event)
+	if !ok {
+		return
 	}
+	h.shardFor(auctionID).queue <- msg
 }
 
 // BroadcastToAll は全クライアントにイベントをブロードキャストする
 func (h *Hub) BroadcastToAll(event *Event) {
-	h.broadcast <- &BroadcastMsg{
+	h.broadcastMessage(&BroadcastMsg{
 		auctionID: "",
 		event:     event,
-	}
-}
-
-// PublishEvent はRedis Pub/Subにイベントを発行する
-func (h *Hub) PublishEvent(channel string, event *Event) error {
-	message, err := json.Marshal(event)
-	if err != nil {
-		return err
-	}
-
-	return h.redisClient.Publish(h.ctx, channel, message).Err()
+	})
 }
 
 // listenRedis はRedis Pub/Subからイベントを受信する
 func (h *Hub) listenRedis() {
 	pubsub := h.redisClient.Subscribe(h.ctx,
-		"auction:started",
-		"auction:price_open",
-		"auction:bid",
-		"auction:ended",
-		"auction:cancelled",
-		"auction:item_started",
-		"auction:item_ended",
+		events.ChannelAuctionEvents,
+		events.ChannelBidderNotifications,
+		events.ChannelAdminNotifications,
 	)
 	defer pubsub.Close()
 
@@ -297,59 +423,189 @@ func (h *Hub) listenRedis() {
 	log.Println("Redis Pub/Sub listener started")
 
 	for msg := range ch {
-		// Redisからのメッセージを汎用的なマップとして解析
-		var rawEvent map[string]interface{}
-		if err := json.Unmarshal([]byte(msg.Payload), &rawEvent); err != nil {
-			log.Printf("Failed to unmarshal Redis message: %v", err)
+		// 個別通知は宛先の入札者の接続にのみ配送する
+		if msg.Channel == events.ChannelBidderNotifications {
+			h.deliverToBidder([]byte(msg.Payload))
+			continue
+		}
+		if msg.Channel == events.ChannelAdminNotifications {
+			h.deliverToAdmin([]byte(msg.Payload))
 			continue
 		}
 
-		// typeフィールドを取得
-		eventType, _ := rawEvent["type"].(string)
+		h.deliverAuctionEvent([]byte(msg.Payload))
+	}
+}
 
-		// WebSocketクライアントが期待する形式に変換: { type, data }
-		// dataにはtype以外の全フィールドを含める
-		delete(rawEvent, "type")
+// deliverAuctionEvent はRedisから受信したイベントをオークションルームに配信する
+func (h *Hub) deliverAuctionEvent(payload []byte) {
+	var envelope events.Envelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		log.Printf("Failed to unmarshal Redis message: %v", err)
+		return
+	}
+	if envelope.Version != events.SchemaVersion {
+		log.Printf("Unsupported event schema version: type=%s, version=%d", envelope.Type, envelope.Version)
+		return
+	}
 
-		wsMessage := map[string]interface{}{
-			"type": eventType,
-			"data": rawEvent,
-		}
+	// 受信者のロールに応じて射影するため、データを汎用的なマップとして解析
+	var data map[string]interface{}
+	if err := json.Unmarshal(envelope.Data, &data); err != nil {
+		log.Printf("Failed to unmarshal event data: type=%s, err=%v", envelope.Type, err)
+		return
+	}
 
-		// メッセージをJSONに変換
-		messageBytes, err := json.Marshal(wsMessage)
-		if err != nil {
-			log.Printf("Failed to marshal WebSocket message: %v", err)
-			continue
-		}
+	eventType := string(envelope.Type)
+	messages := newProjectedMessages(eventType, data)
 
-		// 全クライアントにブロードキャスト
-		for client := range h.clients {
-			select {
-			case client.send <- messageBytes:
-			default:
-				close(client.send)
-				delete(h.clients, client)
-			}
+	// SSEクライアント向けに公開用の射影を保持し、オークションルームに配信する
+	if auctionID := eventAuctionID(data); auctionID != "" {
+		if message := messages.forAudience(audienceSpectator, false); message != nil {
+			h.recordAuctionEvent(auctionID, eventType, message.text)
+		}
+		h.shardFor(auctionID).queue <- &roomMessage{
+			auctionID: auctionID,
+			key:       coalesceKeyFromData(eventType, data),
+			projected: messages,
+			public:    true,
 		}
+	} else {
+		// オークションに紐づかないイベントは全クライアントに配信
+		h.sendToAll(&roomMessage{projected: messages, public: true})
+	}
+
+	log.Printf("Broadcasted event from Redis: type=%s", eventType)
+}
+
+// deliverToBidder は個別通知を宛先の入札者の全接続に送信する
+func (h *Hub) deliverToBidder(payload []byte) {
+	var notification events.BidderEnvelope
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		log.Printf("Failed to unmarshal bidder notification: %v", err)
+		return
+	}
+	if notification.BidderID == "" || notification.Type == "" {
+		return
+	}
+
+	message, err := json.Marshal(NewEvent(EventType(notification.Type), "", notification.Data))
+	if err != nil {
+		log.Printf("Failed to marshal bidder notification: %v", err)
+		return
+	}
+
+	wire := newWireMessage(message)
+
+	h.bidderMutex.RLock()
+	defer h.bidderMutex.RUnlock()
 
-		log.Printf("Broadcasted event from Redis: type=%s, channel=%s", eventType, msg.Channel)
+	for client := range h.bidderClients[notification.BidderID] {
+		client.enqueue("", wire)
 	}
 }
 
+// deliverToAdmin は個別通知を宛先の管理者の全接続に送信する
+func (h *Hub) deliverToAdmin(payload []byte) {
+	var notification events.AdminEnvelope
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		log.Printf("Failed to unmarshal admin notification: %v", err)
+		return
+	}
+	if notification.AdminID == 0 || notification.Type == "" {
+		return
+	}
+
+	message, err := json.Marshal(NewEvent(EventType(notification.Type), "", notification.Data))
+	if err != nil {
+		log.Printf("Failed to marshal admin notification: %v", err)
+		return
+	}
+
+	wire := newWireMessage(message)
+
+	h.adminMutex.RLock()
+	defer h.adminMutex.RUnlock()
+
+	for client := range h.adminClients[strconv.FormatInt(notification.AdminID, 10)] {
+		client.enqueue("", wire)
+	}
+}
+
+// GetBidderConnectionCount は入札者の接続数を返す
+func (h *Hub) GetBidderConnectionCount(bidderID string) int {
+	h.bidderMutex.RLock()
+	defer h.bidderMutex.RUnlock()
+
+	return len(h.bidderClients[bidderID])
+}
+
+// auctionEvents はオークションのイベントバッファを返す（存在しない場合は作成する）
+func (h *Hub) auctionEvents(auctionID string) *auctionEventBuffer {
+	h.eventBuffersMutex.Lock()
+	defer h.eventBuffersMutex.Unlock()
+
+	return h.auctionEventsLocked(auctionID)
+}
+
+// auctionEventsLocked はauctionEventsと同じ（eventBuffersMutexを保持して呼び出すこと）
+func (h *Hub) auctionEventsLocked(auctionID string) *auctionEventBuffer {
+	buffer, ok := h.eventBuffers[auctionID]
+	if !ok {
+		buffer = newAuctionEventBuffer(eventBufferSize)
+		h.eventBuffers[auctionID] = buffer
+	}
+	return buffer
+}
+
+// subscribeAuctionEvents はオークションのイベントバッファの購読を開始する
+// 購読はバッファの破棄と同じロックの下で行い、破棄済みのバッファを購読しないようにする
+func (h *Hub) subscribeAuctionEvents(auctionID, epoch string, lastEventID uint64) (buffer *auctionEventBuffer, ch chan bufferedEvent, missed []bufferedEvent, ok bool) {
+	h.eventBuffersMutex.Lock()
+	defer h.eventBuffersMutex.Unlock()
+
+	buffer = h.auctionEventsLocked(auctionID)
+	ch, missed, ok = buffer.subscribe(epoch, lastEventID)
+	return buffer, ch, missed, ok
+}
+
+// sweepEventBuffers はアイドルなイベントバッファを定期的に破棄する
+func (h *Hub) sweepEventBuffers() {
+	ticker := time.NewTicker(eventBufferSweepPeriod)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		h.evictIdleEventBuffers(now)
+	}
+}
+
+// evictIdleEventBuffers は購読者がおらず一定時間イベントのないバッファを破棄する
+// 破棄後に作成されるバッファは別のエポックになるため、古いLast-Event-IDで再接続したクライアントは状態を再取得する
+func (h *Hub) evictIdleEventBuffers(now time.Time) {
+	h.eventBuffersMutex.Lock()
+	defer h.eventBuffersMutex.Unlock()
+
+	for auctionID, buffer := range h.eventBuffers {
+		if buffer.idle(now, eventBufferIdleTTL) {
+			delete(h.eventBuffers, auctionID)
+		}
+	}
+}
+
+// recordAuctionEvent はオークションのイベントを保持し、SSEクライアントに配信する
+func (h *Hub) recordAuctionEvent(auctionID, eventType string, message []byte) {
+	h.auctionEvents(auctionID).append(eventType, message)
+}
+
 // GetRoomSize はオークションルームのクライアント数を返す
 func (h *Hub) GetRoomSize(auctionID string) int {
-	h.roomsMutex.RLock()
-	defer h.roomsMutex.RUnlock()
-
-	return len(h.rooms[auctionID])
+	return h.shardFor(auctionID).size(auctionID)
 }
 
 // GetActiveParticipants はオークションルームのアクティブ参加者一覧を返す
+// 入札者情報は1回のクエリでまとめて取得する
 func (h *Hub) GetActiveParticipants(auctionID string) ([]ParticipantData, error) {
-	h.roomsMutex.RLock()
-	clients := h.rooms[auctionID]
-	h.roomsMutex.RUnlock()
+	clients := h.shardFor(auctionID).members(auctionID)
 
 	// bidder_idで重複排除するためのマップ
 	uniqueBidders := make(map[string]bool)
@@ -370,23 +626,57 @@ func (h *Hub) GetActiveParticipants(auctionID string) ([]ParticipantData, error)
 		}
 	}
 
-	// データベースから各入札者の情報を取得
-	var participants []ParticipantData
-	for _, bidderID := range bidderIDs {
-		participantInfo, err := h.auctionRepo.GetBidderInfo(bidderID, auctionID)
-		if err != nil {
-			log.Printf("Failed to get bidder info: %v", err)
-			continue
-		}
+	participants := make([]ParticipantData, 0, len(bidderIDs))
+	if len(bidderIDs) == 0 || h.auctionRepo == nil {
+		return participants, nil
+	}
+
+	// データベースから入札者の情報をまとめて取得
+	infos, err := h.auctionRepo.GetBiddersInfo(bidderIDs, auctionID)
+	if err != nil {
+		return nil, err
+	}
 
+	for _, participantInfo := range infos {
 		participants = append(participants, ParticipantData{
-			BidderID:    participantInfo.BidderID.String(),
-			DisplayName: participantInfo.DisplayName,
-			IsOnline:    true,
-			BidCount:    participantInfo.BidCount,
-			LastBidAt:   participantInfo.LastBidAt,
+			BidderID:     participantInfo.BidderID.String(),
+			DisplayName:  participantInfo.DisplayName,
+			PaddleNumber: h.paddleNumber(auctionID, participantInfo.BidderID.String()),
+			IsOnline:     true,
+			BidCount:     participantInfo.BidCount,
+			LastBidAt:    participantInfo.LastBidAt,
 		})
 	}
 
 	return participants, nil
 }
+
+// paddleNumber は入札者のオークション内のパドル番号を返す（取得できない場合は0）
+// 入札イベントと同じ番号を使い、入札者同士は識別情報の代わりにパドル番号で区別する
+func (h *Hub) paddleNumber(auctionID, bidderID string) int64 {
+	paddle, err := service.AssignPaddleNumber(h.ctx, h.redisClient, auctionID, bidderID)
+	if err != nil {
+		log.Printf("Failed to get paddle number: %v", err)
+		return 0
+	}
+	return paddle
+}
+
+// GetLatencyStats はオークションルームに接続中の入札者ごとの遅延統計を返す
+// このサーバーに接続しているクライアントのみが対象となる
+func (h *Hub) GetLatencyStats(auctionID string) []ConnectionLatency {
+	clients := h.shardFor(auctionID).members(auctionID)
+
+	stats := make([]ConnectionLatency, 0, len(clients))
+	for _, client := range clients {
+		if client.bidderID == nil {
+			continue
+		}
+		stats = append(stats, ConnectionLatency{
+			BidderID:        *client.bidderID,
+			DisplayName:     client.displayName,
+			LatencySnapshot: client.latency.snapshot(),
+		})
+	}
+	return stats
+}
@@ -0,0 +1,164 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/events"
+)
+
+func TestHub_DeliverToBidder(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+
+	bidderA := "6f1c2d3e-0000-0000-0000-00000000000a"
+	bidderB := "6f1c2d3e-0000-0000-0000-00000000000b"
+	clientA1 := NewClient(hub, nil, bidderA, "bidder", &bidderA, "bidder")
+	clientA2 := NewClient(hub, nil, bidderA, "bidder", &bidderA, "bidder")
+	clientB := NewClient(hub, nil, bidderB, "bidder", &bidderB, "bidder")
+	admin := NewClient(hub, nil, "1", "system_admin", nil, "system_admin")
+	for _, client := range []*Client{clientA1, clientA2, clientB, admin} {
+		hub.registerClient(client)
+	}
+
+	payload, err := events.MarshalForBidder(bidderA, events.BidOutbid{
+		AuctionID:    "auction-1",
+		ItemID:       "item-1",
+		OutbidBidID:  1,
+		OutbidPrice:  1000,
+		CurrentPrice: 1500,
+	})
+	require.NoError(t, err)
+
+	hub.deliverToBidder(payload)
+
+	for _, client := range []*Client{clientA1, clientA2} {
+		event := readEvent(t, client)
+		assert.Equal(t, string(EventBidOutbid), event["type"])
+		assert.Equal(t, float64(events.SchemaVersion), event["version"])
+		assert.Equal(t, "item-1", event["data"].(map[string]interface{})["item_id"])
+	}
+	assert.Equal(t, 0, clientB.send.len())
+	assert.Equal(t, 0, admin.send.len())
+}
+
+func TestHub_DeliverToAdmin(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+
+	requester := NewClient(hub, nil, "1", "system_admin", nil, "system_admin")
+	otherAdmin := NewClient(hub, nil, "2", "system_admin", nil, "system_admin")
+	bidderID := "1"
+	bidder := NewClient(hub, nil, bidderID, "bidder", &bidderID, "bidder")
+	for _, client := range []*Client{requester, otherAdmin, bidder} {
+		hub.registerClient(client)
+	}
+
+	payload, err := events.MarshalForAdmin(1, events.PointGrantDecided{
+		RequestID: 3,
+		BidderID:  "6f1c2d3e-0000-0000-0000-00000000000a",
+		Points:    500000,
+		Status:    "approved",
+		DecidedBy: 2,
+	})
+	require.NoError(t, err)
+
+	hub.deliverToAdmin(payload)
+
+	event := readEvent(t, requester)
+	assert.Equal(t, string(EventPointGrantDecided), event["type"])
+	assert.Equal(t, float64(3), event["data"].(map[string]interface{})["request_id"])
+	assert.Equal(t, 0, otherAdmin.send.len())
+	assert.Equal(t, 0, bidder.send.len())
+
+	hub.unregisterClient(requester)
+	assert.Empty(t, hub.adminClients["1"])
+}
+
+func TestHub_UnregisterRemovesBidderIndex(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+
+	bidderID := "6f1c2d3e-0000-0000-0000-00000000000a"
+	client := NewClient(hub, nil, bidderID, "bidder", &bidderID, "bidder")
+	hub.registerClient(client)
+	assert.Equal(t, 1, hub.GetBidderConnectionCount(bidderID))
+
+	hub.unregisterClient(client)
+	assert.Equal(t, 0, hub.GetBidderConnectionCount(bidderID))
+
+	// 切断済みクライアントへの配送でパニックしないこと
+	payload, err := events.MarshalForBidder(bidderID, events.PointsUpdated{})
+	require.NoError(t, err)
+	assert.NotPanics(t, func() { hub.deliverToBidder(payload) })
+}
+
+func TestHub_DeliverAuctionEvent_ForwardsSchemaValidMessages(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+
+	bidderA := "6f1c2d3e-0000-0000-0000-00000000000a"
+	bidderB := "6f1c2d3e-0000-0000-0000-00000000000b"
+	clients := []*Client{
+		NewClient(hub, nil, bidderA, "bidder", &bidderA, "bidder"),
+		NewClient(hub, nil, bidderB, "bidder", &bidderB, "bidder"),
+		NewClient(hub, nil, "1", "system_admin", nil, "system_admin"),
+		NewClient(hub, nil, "", roleSpectator, nil, ""),
+	}
+	for _, client := range clients {
+		hub.shardFor("auction-1").join("auction-1", client)
+	}
+
+	payloads := []events.Payload{
+		events.BidPlaced{
+			AuctionID: "auction-1",
+			ItemID:    "item-1",
+			Bid:       events.BidInfo{ID: 1, BidderID: bidderA, BidderName: "Alice", PaddleNumber: 12, Price: 1500, IsWinning: true, BidAt: time.Now()},
+		},
+		events.ItemEnded{
+			AuctionID: "auction-1",
+			ItemID:    "item-1",
+			Item:      events.ItemResult{ID: "item-1", AuctionID: "auction-1", Name: "Vase", FinalPrice: 1500, WinnerID: bidderA, WinnerPaddleNumber: 12, EndedAt: time.Now(), Status: "ended"},
+		},
+		events.PriceOpened{
+			AuctionID:    "auction-1",
+			ItemID:       "item-1",
+			Price:        1500,
+			PriceHistory: events.PriceDisclosure{ID: 1, ItemID: "item-1", Price: 1500, DisclosedBy: 1, DisclosedAt: time.Now()},
+		},
+	}
+
+	for _, payload := range payloads {
+		message, err := events.Marshal(payload)
+		require.NoError(t, err)
+
+		hub.deliverAuctionEvent(message)
+
+		// 受信者ごとに射影されたメッセージもスキーマに適合すること
+		for _, client := range clients {
+			require.Eventually(t, func() bool { return client.send.len() > 0 }, time.Second, time.Millisecond)
+			messages, _, _ := client.send.take()
+			require.Len(t, messages, 1)
+			assert.NoError(t, events.Validate(messages[0].text), "type=%s, role=%s", payload.EventType(), client.userRole)
+		}
+	}
+}
+
+func TestHub_DeliverAuctionEvent_IgnoresUnknownVersion(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+	client := NewClient(hub, nil, "1", "system_admin", nil, "system_admin")
+	hub.shardFor("auction-1").join("auction-1", client)
+
+	hub.deliverAuctionEvent([]byte(`{"type":"bid:placed","version":99,"data":{"auction_id":"auction-1"}}`))
+	hub.deliverAuctionEvent(mustMarshal(t, events.AuctionEnded{AuctionID: "auction-1", Status: "ended", EndedAt: time.Now()}))
+
+	// 未対応バージョンのイベントは配信せず、後続のイベントのみ届くこと
+	require.Eventually(t, func() bool { return client.send.len() > 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, string(EventAuctionEnded), readEvent(t, client)["type"])
+	assert.Equal(t, 0, client.send.len())
+}
+
+func mustMarshal(t *testing.T, payload events.Payload) []byte {
+	t.Helper()
+	message, err := events.Marshal(payload)
+	require.NoError(t, err)
+	return message
+}
@@ -0,0 +1,233 @@
+package ws
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	latencySampleSize = 32 // 接続ごとに保持する往復遅延のサンプル数
+
+	// クライアントが報告する計測値の許容範囲（範囲外の値は記録しない）
+	maxReportedRTT         = 60 * time.Second
+	maxReportedClockOffset = 24 * time.Hour
+)
+
+// TimeSyncData は時刻同期のためにクライアントがpingに含めるデータ
+// クライアントは前回のpongから計算した往復遅延と時計のずれを報告できる
+type TimeSyncData struct {
+	ClientTime int64  `json:"client_time"`         // ping送信時のクライアント時刻（Unixミリ秒）
+	RTTMs      *int64 `json:"rtt_ms,omitempty"`    // 前回の往復遅延（ミリ秒）
+	OffsetMs   *int64 `json:"offset_ms,omitempty"` // 前回計算した時計のずれ（クライアント時刻 - サーバー時刻、ミリ秒）
+}
+
+// PongData はpongイベントのデータ
+// クライアントはpong受信時刻をt3として次のように計算する（NTPと同じ方式）
+//
+//	rtt    = (t3 - client_time) - (server_send_time - server_receive_time)
+//	offset = ((client_time - server_receive_time) + (t3 - server_send_time)) / 2
+type PongData struct {
+	Message           string `json:"message"`
+	ClientTime        int64  `json:"client_time,omitempty"` // pingのclient_timeをそのまま返す
+	ServerReceiveTime int64  `json:"server_receive_time"`   // pingを受信したサーバー時刻（Unixミリ秒）
+	ServerSendTime    int64  `json:"server_send_time"`      // pongを送信キューに追加したサーバー時刻（Unixミリ秒）
+}
+
+// LatencySnapshot は接続の遅延統計
+type LatencySnapshot struct {
+	Samples       int    `json:"samples"` // サーバーで計測した往復遅延のサンプル数
+	LastRTTMs     *int64 `json:"last_rtt_ms,omitempty"`
+	MedianRTTMs   *int64 `json:"median_rtt_ms,omitempty"`
+	MinRTTMs      *int64 `json:"min_rtt_ms,omitempty"`
+	MaxRTTMs      *int64 `json:"max_rtt_ms,omitempty"`
+	ReportedRTTMs *int64 `json:"reported_rtt_ms,omitempty"` // クライアントが報告した往復遅延
+	ClockOffsetMs *int64 `json:"clock_offset_ms,omitempty"` // クライアントが報告した時計のずれ
+}
+
+// ConnectionLatency は入札者の接続ごとの遅延統計
+type ConnectionLatency struct {
+	BidderID    string `json:"bidder_id"`
+	DisplayName string `json:"display_name"`
+	LatencySnapshot
+}
+
+// LatencyStatsCommandData は遅延統計取得コマンドのデータ
+type LatencyStatsCommandData struct {
+	AuctionID string `json:"auction_id"`
+}
+
+// latencyStats は接続ごとの遅延統計を記録する
+// 往復遅延はWebSocketのPing/Pongフレームでサーバーが計測し、時計のずれはクライアントの報告値を記録する
+type latencyStats struct {
+	mu            sync.Mutex
+	samples       [latencySampleSize]time.Duration // 直近のサンプル（リングバッファ）
+	count         int                              // 記録したサンプルの総数
+	min, max      time.Duration
+	reportedRTT   *int64
+	clockOffsetMs *int64
+}
+
+// pingPayload はPingフレームに含める送信時刻を返す
+func pingPayload(now time.Time) []byte {
+	return []byte(strconv.FormatInt(now.UnixNano(), 10))
+}
+
+// recordPong はPongフレームに含まれる送信時刻から往復遅延を記録する
+func (s *latencyStats) recordPong(payload string, now time.Time) {
+	sentAt, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return
+	}
+
+	rtt := now.Sub(time.Unix(0, sentAt))
+	if rtt < 0 || rtt > pongWait {
+		return
+	}
+	s.record(rtt)
+}
+
+// record は往復遅延のサンプルを記録する
+func (s *latencyStats) record(rtt time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.count == 0 || rtt < s.min {
+		s.min = rtt
+	}
+	if rtt > s.max {
+		s.max = rtt
+	}
+	s.samples[s.count%latencySampleSize] = rtt
+	s.count++
+}
+
+// recordReport はクライアントが時刻同期で計算した値を記録する
+func (s *latencyStats) recordReport(data *TimeSyncData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if data.RTTMs != nil && *data.RTTMs >= 0 && *data.RTTMs <= maxReportedRTT.Milliseconds() {
+		rtt := *data.RTTMs
+		s.reportedRTT = &rtt
+	}
+	if data.OffsetMs != nil && abs64(*data.OffsetMs) <= maxReportedClockOffset.Milliseconds() {
+		offset := *data.OffsetMs
+		s.clockOffsetMs = &offset
+	}
+}
+
+// bidTiming は入札に記録する往復遅延と時計のずれを返す
+// 往復遅延はサーバーの計測値（中央値）を優先し、未計測の場合はクライアントの報告値を使う
+func (s *latencyStats) bidTiming() (rttMs *int64, clockOffsetMs *int64) {
+	snapshot := s.snapshot()
+	rttMs = snapshot.MedianRTTMs
+	if rttMs == nil {
+		rttMs = snapshot.ReportedRTTMs
+	}
+	return rttMs, snapshot.ClockOffsetMs
+}
+
+// snapshot は現在の遅延統計を返す
+func (s *latencyStats) snapshot() LatencySnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := LatencySnapshot{
+		Samples:       s.count,
+		ReportedRTTMs: copyInt64(s.reportedRTT),
+		ClockOffsetMs: copyInt64(s.clockOffsetMs),
+	}
+	if s.count == 0 {
+		return snapshot
+	}
+
+	n := s.count
+	if n > latencySampleSize {
+		n = latencySampleSize
+	}
+	recent := make([]time.Duration, n)
+	copy(recent, s.samples[:n])
+	sort.Slice(recent, func(i, j int) bool { return recent[i] < recent[j] })
+
+	snapshot.LastRTTMs = millis(s.samples[(s.count-1)%latencySampleSize])
+	snapshot.MedianRTTMs = millis(recent[n/2])
+	snapshot.MinRTTMs = millis(s.min)
+	snapshot.MaxRTTMs = millis(s.max)
+	return snapshot
+}
+
+// handlePing はPingリクエストを処理する
+// dataにclient_timeが含まれる場合は時刻同期のためにサーバー時刻を返す
+func (h *EventHandler) handlePing(client *Client, event *Event) {
+	receivedAt := event.receivedAt
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+
+	var data TimeSyncData
+	if event.Data != nil {
+		if err := h.parseEventData(event, &data); err == nil {
+			client.latency.recordReport(&data)
+		}
+	}
+
+	client.sendEvent(NewEvent(EventPong, "", PongData{
+		Message:           "pong",
+		ClientTime:        data.ClientTime,
+		ServerReceiveTime: receivedAt.UnixMilli(),
+		ServerSendTime:    time.Now().UnixMilli(),
+	}))
+}
+
+// handleLatencyStats は遅延統計取得コマンドを処理する（主催者のみ）
+// 先着紛争の検証用に、ルームに接続中の入札者ごとの遅延統計を返す
+func (h *EventHandler) handleLatencyStats(client *Client, event *Event) {
+	if !h.authorizeAuctioneer(client, event) {
+		return
+	}
+
+	var data LatencyStatsCommandData
+	if err := h.parseEventData(event, &data); err != nil || !isValidUUID(data.AuctionID) {
+		client.sendEvent(NewRequestErrorEvent(event.RequestID, "INVALID_DATA", "Invalid auction data"))
+		return
+	}
+
+	client.sendEvent(NewAckEvent(event.RequestID, map[string]interface{}{
+		"auction_id":  data.AuctionID,
+		"measured_at": time.Now(),
+		"connections": h.hub.GetLatencyStats(data.AuctionID),
+	}))
+}
+
+// formatOptionalInt はログ出力用に値を文字列に変換する（nilの場合は"-"）
+func formatOptionalInt(v *int64) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+// millis は時間をミリ秒のポインタに変換する
+func millis(d time.Duration) *int64 {
+	ms := d.Milliseconds()
+	return &ms
+}
+
+// copyInt64 はポインタの値をコピーする
+func copyInt64(v *int64) *int64 {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+// abs64 は絶対値を返す
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
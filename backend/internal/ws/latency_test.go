@@ -0,0 +1,96 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyStats_Snapshot(t *testing.T) {
+	var stats latencyStats
+
+	snapshot := stats.snapshot()
+	assert.Equal(t, 0, snapshot.Samples)
+	assert.Nil(t, snapshot.MedianRTTMs)
+
+	for _, ms := range []int{40, 10, 30, 20, 500} {
+		stats.record(time.Duration(ms) * time.Millisecond)
+	}
+
+	snapshot = stats.snapshot()
+	assert.Equal(t, 5, snapshot.Samples)
+	assert.Equal(t, int64(500), *snapshot.LastRTTMs)
+	assert.Equal(t, int64(30), *snapshot.MedianRTTMs)
+	assert.Equal(t, int64(10), *snapshot.MinRTTMs)
+	assert.Equal(t, int64(500), *snapshot.MaxRTTMs)
+}
+
+func TestLatencyStats_RecordPong(t *testing.T) {
+	var stats latencyStats
+	now := time.Now()
+
+	stats.recordPong(string(pingPayload(now.Add(-25*time.Millisecond))), now)
+	stats.recordPong("not-a-timestamp", now)
+	stats.recordPong(string(pingPayload(now.Add(time.Second))), now)
+
+	snapshot := stats.snapshot()
+	require.Equal(t, 1, snapshot.Samples)
+	assert.Equal(t, int64(25), *snapshot.LastRTTMs)
+}
+
+func TestLatencyStats_BidTiming(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	t.Run("Falls back to the reported RTT before measuring", func(t *testing.T) {
+		var stats latencyStats
+		stats.recordReport(&TimeSyncData{RTTMs: int64Ptr(80), OffsetMs: int64Ptr(-120)})
+
+		rtt, offset := stats.bidTiming()
+		assert.Equal(t, int64(80), *rtt)
+		assert.Equal(t, int64(-120), *offset)
+	})
+
+	t.Run("Prefers the measured RTT", func(t *testing.T) {
+		var stats latencyStats
+		stats.recordReport(&TimeSyncData{RTTMs: int64Ptr(80)})
+		stats.record(30 * time.Millisecond)
+
+		rtt, offset := stats.bidTiming()
+		assert.Equal(t, int64(30), *rtt)
+		assert.Nil(t, offset)
+	})
+
+	t.Run("Ignores implausible reports", func(t *testing.T) {
+		var stats latencyStats
+		stats.recordReport(&TimeSyncData{RTTMs: int64Ptr(-1), OffsetMs: int64Ptr(48 * time.Hour.Milliseconds())})
+
+		rtt, offset := stats.bidTiming()
+		assert.Nil(t, rtt)
+		assert.Nil(t, offset)
+	})
+}
+
+func TestEventHandler_HandlePing_ReturnsServerTimes(t *testing.T) {
+	handler := NewEventHandler(nil, nil, nil)
+	bidderID := "6f1c2d3e-0000-0000-0000-000000000001"
+	client := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+
+	receivedAt := time.Now().Add(-5 * time.Millisecond)
+	handler.Handle(client, &Event{
+		Type:       EventPing,
+		Data:       map[string]interface{}{"client_time": 1700000000000, "offset_ms": 42},
+		receivedAt: receivedAt,
+	})
+
+	reply := readEvent(t, client)
+	assert.Equal(t, string(EventPong), reply["type"])
+
+	data := reply["data"].(map[string]interface{})
+	assert.Equal(t, "pong", data["message"])
+	assert.Equal(t, float64(1700000000000), data["client_time"])
+	assert.Equal(t, float64(receivedAt.UnixMilli()), data["server_receive_time"])
+	assert.GreaterOrEqual(t, data["server_send_time"].(float64), data["server_receive_time"].(float64))
+	assert.Equal(t, int64(42), *client.latency.snapshot().ClockOffsetMs)
+}
@@ -0,0 +1,176 @@
+package ws
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tsutsumi389/real-time-auction/internal/middleware"
+)
+
+// アプリケーション定義のクローズコード（4000 + 対応するHTTPステータス）
+// ブラウザはWebSocketアップグレード失敗時のHTTPステータスを参照できないため、
+// 接続を拒否する場合もアップグレード後にクローズコード付きで切断する
+const (
+	CloseUnauthorized       = 4401 // 認証情報が無効または期限切れ（再接続前に再ログインが必要）
+	CloseResyncRequired     = 4408 // 送信が追いつかず切断した（再接続してREST APIで状態を再取得する）
+	CloseSessionReplaced    = 4409 // 同じ入札者の別の接続により置き換えられた / 既に接続中（自動再接続しない）
+	CloseTooManyConnections = 4429 // ユーザーまたはIPアドレスあたりの接続数上限を超えた
+	CloseServerFull         = 4503 // 観覧者の接続数上限に達した（時間をおいて再接続可能）
+)
+
+// BidderSessionPolicy は入札者が2つ目の接続（別タブなど）を開いた場合の動作
+type BidderSessionPolicy string
+
+const (
+	BidderSessionKickOldest BidderSessionPolicy = "kick_oldest" // 最も古い接続を切断して新しい接続を受け付ける
+	BidderSessionReject     BidderSessionPolicy = "reject"      // 新しい接続を拒否する
+)
+
+// ConnectionLimits は接続数の上限設定（0は無制限）
+type ConnectionLimits struct {
+	MaxPerUser    int                 // 管理者1人あたりの最大接続数
+	MaxPerBidder  int                 // 入札者1人あたりの最大接続数
+	MaxPerIP      int                 // IPアドレスあたりの最大接続数（観覧者を含む）
+	BidderSession BidderSessionPolicy // 入札者の接続数が上限に達した場合の動作
+}
+
+// DefaultConnectionLimits はデフォルトの接続数上限を返す
+// 会場では多数の端末が同じグローバルIPを共有するため、IP上限は大きめに設定する
+func DefaultConnectionLimits() ConnectionLimits {
+	return ConnectionLimits{
+		MaxPerUser:    5,
+		MaxPerBidder:  1,
+		MaxPerIP:      200,
+		BidderSession: BidderSessionKickOldest,
+	}
+}
+
+// connectionLimiter はユーザーごと・IPアドレスごとの接続数を管理する
+type connectionLimiter struct {
+	mu     sync.Mutex
+	limits ConnectionLimits
+	byUser map[string][]*Client // ユーザーキー -> 接続（古い順）
+	byIP   map[string]int       // IPアドレス -> 接続数
+	ipOf   map[*Client]string   // 接続 -> IPアドレス
+}
+
+// newConnectionLimiter は新しいconnectionLimiterを作成する
+func newConnectionLimiter(limits ConnectionLimits) *connectionLimiter {
+	return &connectionLimiter{
+		limits: limits,
+		byUser: make(map[string][]*Client),
+		byIP:   make(map[string]int),
+		ipOf:   make(map[*Client]string),
+	}
+}
+
+// userKey はクライアントのユーザーキーを返す（観覧者は空文字列）
+func userKey(client *Client) string {
+	if client.isSpectator() {
+		return ""
+	}
+	if client.bidderID != nil {
+		return "bidder:" + *client.bidderID
+	}
+	return "admin:" + client.userID
+}
+
+// acquire は接続枠を確保する
+// 拒否する場合はクローズコードと理由を返す。入札者の古い接続を切断する場合はevictedに設定される
+func (l *connectionLimiter) acquire(client *Client, ip string) (evicted *Client, closeCode int, reason string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limits.MaxPerIP > 0 && l.byIP[ip] >= l.limits.MaxPerIP {
+		return nil, CloseTooManyConnections, "too many connections from this address"
+	}
+
+	key := userKey(client)
+	if key != "" {
+		conns := l.byUser[key]
+
+		if client.bidderID != nil {
+			if l.limits.MaxPerBidder > 0 && len(conns) >= l.limits.MaxPerBidder {
+				if l.limits.BidderSession != BidderSessionKickOldest {
+					return nil, CloseSessionReplaced, "already connected in another session"
+				}
+				// 最も古い接続を管理対象から外す（IPの接続数は実際に切断されるまで保持）
+				evicted = conns[0]
+				conns = conns[1:]
+			}
+		} else if l.limits.MaxPerUser > 0 && len(conns) >= l.limits.MaxPerUser {
+			return nil, CloseTooManyConnections, "too many connections for this user"
+		}
+
+		l.byUser[key] = append(conns, client)
+	}
+
+	l.byIP[ip]++
+	l.ipOf[client] = ip
+
+	return evicted, 0, ""
+}
+
+// release は接続枠を解放する
+func (l *connectionLimiter) release(client *Client) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ip, ok := l.ipOf[client]
+	if !ok {
+		return
+	}
+	delete(l.ipOf, client)
+
+	if l.byIP[ip]--; l.byIP[ip] <= 0 {
+		delete(l.byIP, ip)
+	}
+
+	key := userKey(client)
+	conns := l.byUser[key]
+	for i, c := range conns {
+		if c == client {
+			conns = append(conns[:i], conns[i+1:]...)
+			break
+		}
+	}
+	if len(conns) == 0 {
+		delete(l.byUser, key)
+	} else {
+		l.byUser[key] = conns
+	}
+}
+
+// closeConn はクローズコードを送信してWebSocket接続を閉じる
+func closeConn(conn *websocket.Conn, code int, reason string) {
+	if conn == nil {
+		return
+	}
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+	conn.Close()
+}
+
+// checkOrigin はCORS_ORIGINSの許可リストまたは同一オリジンからの接続のみ受け付ける
+// CORS_ORIGINSが未設定の場合は（REST APIのような全許可にはせず）同一オリジンのみ許可する
+// Originヘッダーを送らないクライアント（ブラウザ以外）は許可する
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	if os.Getenv("CORS_ORIGINS") != "" && middleware.IsOriginAllowed(origin, middleware.AllowedOrigins()) {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
@@ -0,0 +1,118 @@
+package ws
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionLimiter(t *testing.T) {
+	bidderID := "6f1c2d3e-0000-0000-0000-00000000000a"
+
+	t.Run("Kicks oldest bidder connection", func(t *testing.T) {
+		limiter := newConnectionLimiter(DefaultConnectionLimits())
+		first := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+		second := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+
+		evicted, code, _ := limiter.acquire(first, "10.0.0.1")
+		assert.Nil(t, evicted)
+		assert.Zero(t, code)
+
+		evicted, code, _ = limiter.acquire(second, "10.0.0.2")
+		assert.Same(t, first, evicted)
+		assert.Zero(t, code)
+
+		// 切断された古い接続の解放で新しい接続の枠は失われない
+		limiter.release(first)
+		assert.Equal(t, []*Client{second}, limiter.byUser["bidder:"+bidderID])
+		assert.NotContains(t, limiter.byIP, "10.0.0.1")
+	})
+
+	t.Run("Rejects second bidder connection", func(t *testing.T) {
+		limits := DefaultConnectionLimits()
+		limits.BidderSession = BidderSessionReject
+		limiter := newConnectionLimiter(limits)
+
+		first := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+		second := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+		limiter.acquire(first, "10.0.0.1")
+
+		evicted, code, _ := limiter.acquire(second, "10.0.0.1")
+		assert.Nil(t, evicted)
+		assert.Equal(t, CloseSessionReplaced, code)
+		assert.Equal(t, 1, limiter.byIP["10.0.0.1"])
+	})
+
+	t.Run("Caps admin connections per user", func(t *testing.T) {
+		limits := DefaultConnectionLimits()
+		limits.MaxPerUser = 2
+		limiter := newConnectionLimiter(limits)
+
+		for i := 0; i < 2; i++ {
+			_, code, _ := limiter.acquire(NewClient(nil, nil, "1", "auctioneer", nil, "auctioneer"), "10.0.0.1")
+			assert.Zero(t, code)
+		}
+		_, code, _ := limiter.acquire(NewClient(nil, nil, "1", "auctioneer", nil, "auctioneer"), "10.0.0.1")
+		assert.Equal(t, CloseTooManyConnections, code)
+
+		_, code, _ = limiter.acquire(NewClient(nil, nil, "2", "auctioneer", nil, "auctioneer"), "10.0.0.1")
+		assert.Zero(t, code)
+	})
+
+	t.Run("Caps connections per IP including spectators", func(t *testing.T) {
+		limits := DefaultConnectionLimits()
+		limits.MaxPerIP = 1
+		limiter := newConnectionLimiter(limits)
+
+		spectator := NewClient(nil, nil, "spectator:1", roleSpectator, nil, "Spectator")
+		_, code, _ := limiter.acquire(spectator, "10.0.0.1")
+		assert.Zero(t, code)
+
+		_, code, _ = limiter.acquire(NewClient(nil, nil, "spectator:2", roleSpectator, nil, "Spectator"), "10.0.0.1")
+		assert.Equal(t, CloseTooManyConnections, code)
+
+		limiter.release(spectator)
+		_, code, _ = limiter.acquire(NewClient(nil, nil, "spectator:3", roleSpectator, nil, "Spectator"), "10.0.0.1")
+		assert.Zero(t, code)
+	})
+}
+
+func TestCheckOrigin(t *testing.T) {
+	t.Setenv("CORS_ORIGINS", "https://auction.example.com, https://admin.example.com")
+
+	cases := []struct {
+		name   string
+		origin string
+		host   string
+		want   bool
+	}{
+		{"Allowed origin", "https://auction.example.com", "ws.example.com", true},
+		{"Same origin", "http://localhost:8081", "localhost:8081", true},
+		{"No origin header", "", "ws.example.com", true},
+		{"Disallowed origin", "https://evil.example.com", "ws.example.com", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/ws", nil)
+			req.Host = tc.host
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			assert.Equal(t, tc.want, checkOrigin(req))
+		})
+	}
+}
+
+func TestCheckOrigin_WithoutConfiguredOrigins(t *testing.T) {
+	t.Setenv("CORS_ORIGINS", "")
+
+	// 未設定の場合は同一オリジンのみ許可する
+	req := httptest.NewRequest("GET", "/ws", nil)
+	req.Host = "localhost"
+	req.Header.Set("Origin", "https://evil.example.com")
+	assert.False(t, checkOrigin(req))
+
+	req.Header.Set("Origin", "http://localhost")
+	assert.True(t, checkOrigin(req))
+}
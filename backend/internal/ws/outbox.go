@@ -0,0 +1,186 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// 送信のバックプレッシャー方針
+//
+// クライアントへの送信はクライアントごとの送信キュー（outbox）を経由し、writePumpが順に書き込む。
+// 配信側（シャードのgoroutineなど）は送信キューへの追加だけを行い、遅いクライアントを待たない。
+//   - 古くなった更新の統合: 同じ商品のprice:openedや同じ入札者の入退室イベントが未送信のまま残っている場合、
+//     古いメッセージを取り除いて最新のものだけを送る
+//   - 上限超過: 未送信メッセージが上限を超えた場合は未送信分を破棄してconnection:resync_requiredを送り、
+//     CloseResyncRequiredで切断する（クライアントは再接続してREST APIで状態を再取得する）
+//   - 送信キューのクローズは何度呼び出しても安全で、クローズ後の追加は無視される
+const (
+	sendQueueSize          = 256 // 認証済みクライアントの未送信メッセージ上限
+	spectatorSendQueueSize = 64  // 観覧者の未送信メッセージ上限
+)
+
+// EventResyncRequired は送信が追いつかず切断する直前に送信するイベント
+const EventResyncRequired EventType = "connection:resync_required"
+
+// ResyncRequiredData は再同期要求イベントのデータ
+type ResyncRequiredData struct {
+	Reason string `json:"reason"`
+}
+
+// outboundMessage は送信待ちのメッセージ
+type outboundMessage struct {
+	key  string // 統合キー（同じキーの未送信メッセージは新しいもので置き換える。空の場合は統合しない）
+	data *wireMessage
+}
+
+// pushResult は送信キューへの追加結果
+type pushResult int
+
+const (
+	pushQueued     pushResult = iota // 追加した
+	pushCoalesced                    // 同じキーの未送信メッセージを置き換えた
+	pushOverflowed                   // 上限を超えたため再同期要求を送って切断する
+	pushClosed                       // 既に切断されている
+)
+
+// outbox はクライアントごとの送信キュー
+type outbox struct {
+	mu        sync.Mutex
+	queue     []outboundMessage
+	limit     int
+	closed    bool
+	closeCode int           // 切断時に送信するクローズコード
+	notify    chan struct{} // writePumpへの通知（容量1）
+}
+
+// newOutbox は新しい送信キューを作成する
+func newOutbox(limit int) *outbox {
+	return &outbox{
+		queue:  make([]outboundMessage, 0, 16),
+		limit:  limit,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// push はメッセージを送信キューに追加する
+func (o *outbox) push(key string, data *wireMessage) pushResult {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		return pushClosed
+	}
+
+	result := pushQueued
+	if key != "" {
+		for i, msg := range o.queue {
+			if msg.key == key {
+				// 古いメッセージを取り除き、他のイベントとの順序を保つため末尾に追加する
+				o.queue = append(o.queue[:i], o.queue[i+1:]...)
+				result = pushCoalesced
+				break
+			}
+		}
+	}
+
+	if result == pushQueued && len(o.queue) >= o.limit {
+		o.queue = append(o.queue[:0], outboundMessage{data: newResyncRequiredMessage()})
+		o.closed = true
+		o.closeCode = CloseResyncRequired
+		o.signal()
+		return pushOverflowed
+	}
+
+	o.queue = append(o.queue, outboundMessage{key: key, data: data})
+	o.signal()
+	return result
+}
+
+// close は送信キューを閉じる（未送信のメッセージは送信してから切断する）
+func (o *outbox) close() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		return
+	}
+	o.closed = true
+	o.closeCode = websocket.CloseNormalClosure
+	o.signal()
+}
+
+// take は未送信のメッセージをすべて取り出す
+// 送信キューが閉じられている場合はclosed=trueと送信すべきクローズコードを返す
+func (o *outbox) take() (messages []*wireMessage, closed bool, closeCode int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	messages = make([]*wireMessage, len(o.queue))
+	for i, msg := range o.queue {
+		messages[i] = msg.data
+	}
+	o.queue = o.queue[:0]
+	return messages, o.closed, o.closeCode
+}
+
+// len は未送信のメッセージ数を返す
+func (o *outbox) len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return len(o.queue)
+}
+
+// isClosed は送信キューが閉じられているかを返す
+func (o *outbox) isClosed() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.closed
+}
+
+// signal はwritePumpに未送信のメッセージがあることを通知する（ロック中に呼び出す）
+func (o *outbox) signal() {
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+}
+
+// coalesceKey はイベントの統合キーを返す（統合しないイベントは空文字列）
+func coalesceKey(event *Event) string {
+	switch data := event.Data.(type) {
+	case ParticipantJoinedData:
+		return participantCoalesceKey(data.AuctionID, data.Participant.BidderID)
+	case ParticipantLeftData:
+		return participantCoalesceKey(data.AuctionID, data.BidderID)
+	case map[string]interface{}:
+		return coalesceKeyFromData(string(event.Type), data)
+	}
+	return ""
+}
+
+// coalesceKeyFromData はRedis Pub/Subから受信したイベントの統合キーを返す
+// 価格開示は同じ商品の新しい価格で置き換えてよい
+func coalesceKeyFromData(eventType string, data map[string]interface{}) string {
+	if EventType(eventType) == EventPriceOpened {
+		if itemID, ok := data["item_id"].(string); ok && itemID != "" {
+			return string(EventPriceOpened) + ":" + itemID
+		}
+	}
+	return ""
+}
+
+// participantCoalesceKey は入退室イベントの統合キーを返す
+// 同じ入札者の入室と退室は最後の状態だけを送ればよい
+func participantCoalesceKey(auctionID, bidderID string) string {
+	return "participant:" + auctionID + ":" + bidderID
+}
+
+// newResyncRequiredMessage は再同期要求イベントのメッセージを作成する
+func newResyncRequiredMessage() *wireMessage {
+	message, _ := json.Marshal(NewEvent(EventResyncRequired, "", ResyncRequiredData{Reason: "slow_consumer"}))
+	return newWireMessage(message)
+}
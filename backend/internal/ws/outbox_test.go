@@ -0,0 +1,168 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// messageTexts はメッセージのJSONを文字列の一覧で返す
+func messageTexts(messages []*wireMessage) []string {
+	texts := make([]string, len(messages))
+	for i, message := range messages {
+		texts[i] = string(message.text)
+	}
+	return texts
+}
+
+func TestOutbox_CoalescesSupersededMessages(t *testing.T) {
+	o := newOutbox(8)
+
+	assert.Equal(t, pushQueued, o.push("price:opened:item-1", newWireMessage([]byte("1000"))))
+	assert.Equal(t, pushQueued, o.push("", newWireMessage([]byte("bid"))))
+	assert.Equal(t, pushQueued, o.push("price:opened:item-2", newWireMessage([]byte("500"))))
+	assert.Equal(t, pushCoalesced, o.push("price:opened:item-1", newWireMessage([]byte("1100"))))
+
+	messages, closed, _ := o.take()
+	assert.False(t, closed)
+	// 古い価格は取り除かれ、新しい価格は後続のイベントより後に送られる
+	assert.Equal(t, []string{"bid", "500", "1100"}, messageTexts(messages))
+	assert.Equal(t, 0, o.len())
+}
+
+func TestOutbox_OverflowSendsResyncAndCloses(t *testing.T) {
+	o := newOutbox(3)
+	for i := 0; i < 3; i++ {
+		require.Equal(t, pushQueued, o.push("", newWireMessage([]byte("event"))))
+	}
+
+	// 統合できるメッセージは上限に達していても置き換えられる
+	full := newOutbox(1)
+	require.Equal(t, pushQueued, full.push("k", newWireMessage([]byte("a"))))
+	require.Equal(t, pushCoalesced, full.push("k", newWireMessage([]byte("b"))))
+
+	assert.Equal(t, pushOverflowed, o.push("", newWireMessage([]byte("event"))))
+	assert.Equal(t, pushClosed, o.push("", newWireMessage([]byte("event"))))
+
+	messages, closed, closeCode := o.take()
+	assert.True(t, closed)
+	assert.Equal(t, CloseResyncRequired, closeCode)
+	require.Len(t, messages, 1)
+
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal(messages[0].text, &event))
+	assert.Equal(t, string(EventResyncRequired), event["type"])
+	assert.Equal(t, "slow_consumer", event["data"].(map[string]interface{})["reason"])
+}
+
+func TestOutbox_CloseIsIdempotent(t *testing.T) {
+	o := newOutbox(4)
+	o.push("", newWireMessage([]byte("last")))
+
+	assert.NotPanics(t, func() {
+		o.close()
+		o.close()
+	})
+	assert.Equal(t, pushClosed, o.push("", newWireMessage([]byte("after close"))))
+
+	// 閉じる前に追加したメッセージは送信される
+	messages, closed, closeCode := o.take()
+	assert.True(t, closed)
+	assert.Equal(t, websocket.CloseNormalClosure, closeCode)
+	assert.Equal(t, []string{"last"}, messageTexts(messages))
+}
+
+func TestOutbox_ConcurrentPushAndClose(t *testing.T) {
+	o := newOutbox(sendQueueSize)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range o.notify {
+			if _, closed, _ := o.take(); closed {
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				key := ""
+				if j%2 == 0 {
+					key = "price:opened:item-1"
+				}
+				o.push(key, newWireMessage([]byte("event")))
+				if i == 0 && j == 250 {
+					o.close()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	o.close()
+	<-done
+
+	assert.True(t, o.isClosed())
+}
+
+func TestHub_SlowClientDoesNotBlockRoom(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+	auctionID := "a1b2c3d4-0000-0000-0000-000000000001"
+
+	slow := NewClient(hub, nil, "", roleSpectator, nil, "")
+	fast := NewClient(hub, nil, "1", "auctioneer", nil, "auctioneer")
+	for _, client := range []*Client{slow, fast} {
+		hub.registerClient(client)
+		hub.AddClientToRoom(auctionID, client)
+	}
+
+	for i := 0; i <= spectatorSendQueueSize; i++ {
+		hub.broadcastMessage(&BroadcastMsg{
+			auctionID: auctionID,
+			event:     NewEvent(EventAuctionStarted, auctionID, map[string]interface{}{"seq": i}),
+		})
+		// 送信が追いついているクライアントは上限に達しない
+		fast.send.take()
+	}
+
+	// 読み出しの遅い観覧者は再同期要求を受け取って切断される
+	messages, closed, closeCode := slow.send.take()
+	assert.True(t, closed)
+	assert.Equal(t, CloseResyncRequired, closeCode)
+	require.Len(t, messages, 1)
+	assert.False(t, fast.send.isClosed())
+}
+
+func TestHub_CoalescesParticipantEvents(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+	auctionID := "a1b2c3d4-0000-0000-0000-000000000001"
+	bidderID := "6f1c2d3e-0000-0000-0000-00000000000a"
+
+	admin := NewClient(hub, nil, "1", "auctioneer", nil, "auctioneer")
+	hub.registerClient(admin)
+	hub.AddClientToRoom(auctionID, admin)
+
+	hub.broadcastMessage(&BroadcastMsg{
+		auctionID: auctionID,
+		event: NewEvent(EventParticipantJoined, auctionID, ParticipantJoinedData{
+			AuctionID:   auctionID,
+			Participant: ParticipantData{BidderID: bidderID, IsOnline: true},
+		}),
+	})
+	hub.broadcastMessage(&BroadcastMsg{
+		auctionID: auctionID,
+		event:     NewEvent(EventParticipantLeft, auctionID, ParticipantLeftData{AuctionID: auctionID, BidderID: bidderID}),
+	})
+
+	require.Equal(t, 1, admin.send.len())
+	event := readEvent(t, admin)
+	assert.Equal(t, string(EventParticipantLeft), event["type"])
+}
@@ -0,0 +1,258 @@
+package ws
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/tsutsumi389/real-time-auction/internal/events"
+)
+
+// audience はイベント受信者の区分を表す
+type audience int
+
+const (
+	audienceSpectator audience = iota // 観覧者：入札者の識別情報も本人フラグも受け取らない
+	audienceBidder                    // 入札者：パドル番号と本人フラグ（is_mine）を受け取る
+	audienceAdmin                     // 管理者：入札者の完全な識別情報を受け取る
+)
+
+// audience はクライアントの受信者区分を返す
+func (c *Client) audience() audience {
+	switch c.userRole {
+	case "system_admin", "auctioneer", "admin":
+		return audienceAdmin
+	case "bidder":
+		if c.bidderID != nil {
+			return audienceBidder
+		}
+	}
+	return audienceSpectator
+}
+
+// 入札者の識別情報を含むフィールド（管理者以外には送らない）
+var bidderIdentityFields = []string{"bidder_id", "bidder_name"}
+
+// 参加者の識別情報を含むフィールド（管理者以外には送らない）
+var participantIdentityFields = []string{"bidder_id", "display_name"}
+
+// isPublicEvent は観覧者に配信してよいイベントかどうかを返す
+// 参加者の入退室イベントは入札者の識別情報を含むため観覧者には配信しない
+func isPublicEvent(eventType EventType) bool {
+	switch eventType {
+	case EventParticipantJoined, EventParticipantLeft:
+		return false
+	}
+	return true
+}
+
+// isOwnerOnlyEvent は管理者と当事者の入札者にのみ配信するイベントかどうかを返す
+// 入札者から主催者への質問は他の入札者・観覧者には配信しない
+func isOwnerOnlyEvent(eventType string) bool {
+	return EventType(eventType) == EventChatMessage
+}
+
+// eventOwner はイベントの当事者となる入札者IDを返す（該当しない場合は空文字列）
+func eventOwner(eventType string, data map[string]interface{}) string {
+	switch EventType(eventType) {
+	case EventBidPlaced:
+		if bid, ok := data["bid"].(map[string]interface{}); ok {
+			bidderID, _ := bid["bidder_id"].(string)
+			return bidderID
+		}
+	case EventItemEnded:
+		if item, ok := data["item"].(map[string]interface{}); ok {
+			winnerID, _ := item["winner_id"].(string)
+			return winnerID
+		}
+	case EventChatMessage, EventParticipantLeft:
+		bidderID, _ := data["bidder_id"].(string)
+		return bidderID
+	case EventParticipantJoined:
+		if participant, ok := data["participant"].(map[string]interface{}); ok {
+			bidderID, _ := participant["bidder_id"].(string)
+			return bidderID
+		}
+	}
+	return ""
+}
+
+// projectEventData はイベントデータを受信者区分に応じて変換する
+// 元のデータは変更せず、変更が必要な階層のみコピーする
+func projectEventData(eventType string, data map[string]interface{}, aud audience, isMine bool) map[string]interface{} {
+	if aud == audienceAdmin {
+		return data
+	}
+
+	projected := copyMap(data)
+
+	switch EventType(eventType) {
+	case EventBidPlaced:
+		if bid, ok := data["bid"].(map[string]interface{}); ok {
+			bid = copyMap(bid)
+			deleteKeys(bid, bidderIdentityFields...)
+			if aud == audienceBidder {
+				bid["is_mine"] = isMine
+			} else {
+				delete(bid, "paddle_number")
+			}
+			projected["bid"] = bid
+		}
+
+	case EventItemEnded:
+		if item, ok := data["item"].(map[string]interface{}); ok {
+			item = copyMap(item)
+			delete(item, "winner_id")
+			if aud == audienceBidder {
+				item["is_mine"] = isMine
+			} else {
+				delete(item, "winner_paddle_number")
+			}
+			projected["item"] = item
+		}
+
+	case EventPriceOpened:
+		// 価格開示を行った管理者は管理者以外に公開しない
+		if history, ok := data["price_history"].(map[string]interface{}); ok {
+			history = copyMap(history)
+			delete(history, "disclosed_by")
+			projected["price_history"] = history
+		}
+
+	case EventChatMessage:
+		// 当事者の入札者にのみ配信されるため、本人フラグのみ付与する
+		deleteKeys(projected, bidderIdentityFields...)
+		projected["is_mine"] = isMine
+
+	case EventParticipantJoined:
+		if participant, ok := data["participant"].(map[string]interface{}); ok {
+			projected["participant"] = projectParticipant(participant, aud, isMine)
+		}
+
+	case EventParticipantLeft:
+		projected = projectParticipant(data, aud, isMine)
+	}
+
+	return projected
+}
+
+// projectParticipant は参加者情報から入札者の識別情報を除き、入札者には本人フラグを付与する
+func projectParticipant(participant map[string]interface{}, aud audience, isMine bool) map[string]interface{} {
+	participant = copyMap(participant)
+	deleteKeys(participant, participantIdentityFields...)
+	if aud == audienceBidder {
+		participant["is_mine"] = isMine
+	} else {
+		delete(participant, "paddle_number")
+	}
+	return participant
+}
+
+// participantsForClient は参加者一覧をクライアントの受信者区分に応じて変換する
+// 入札者には他の入札者の識別情報の代わりにパドル番号と本人フラグを、観覧者には空の一覧を返す
+func participantsForClient(participants []ParticipantData, client *Client) []ParticipantData {
+	switch client.audience() {
+	case audienceAdmin:
+		return participants
+	case audienceBidder:
+		projected := make([]ParticipantData, len(participants))
+		for i, participant := range participants {
+			projected[i] = participant
+			projected[i].BidderID = ""
+			projected[i].DisplayName = ""
+			projected[i].IsMine = participant.BidderID == *client.bidderID
+		}
+		return projected
+	default:
+		return []ParticipantData{}
+	}
+}
+
+// projectedMessages は受信者区分ごとのシリアライズ済みメッセージ
+// 生成時に全ての射影を一度ずつシリアライズするため、複数のシャードから同時に参照できる
+type projectedMessages struct {
+	owner    string
+	messages map[projectionKey]*wireMessage
+}
+
+type projectionKey struct {
+	aud    audience
+	isMine bool
+}
+
+// newProjectedMessages はRedisから受信したイベントの射影を作成する
+func newProjectedMessages(eventType string, data map[string]interface{}) *projectedMessages {
+	p := &projectedMessages{
+		owner:    eventOwner(eventType, data),
+		messages: make(map[projectionKey]*wireMessage, 4),
+	}
+
+	keys := []projectionKey{
+		{aud: audienceAdmin},
+		{aud: audienceBidder},
+		{aud: audienceSpectator},
+	}
+	if isOwnerOnlyEvent(eventType) {
+		keys = keys[:1]
+	}
+	if p.owner != "" {
+		keys = append(keys, projectionKey{aud: audienceBidder, isMine: true})
+	}
+
+	for _, key := range keys {
+		// WebSocketクライアントが期待する形式に変換: { type, version, data }
+		message, err := json.Marshal(map[string]interface{}{
+			"type":    eventType,
+			"version": events.SchemaVersion,
+			"data":    projectEventData(eventType, data, key.aud, key.isMine),
+		})
+		if err != nil {
+			log.Printf("Failed to marshal WebSocket message: %v", err)
+			continue
+		}
+		p.messages[key] = newWireMessage(message)
+	}
+
+	return p
+}
+
+// forClient はクライアント向けのメッセージを返す（生成に失敗した場合はnil）
+func (p *projectedMessages) forClient(client *Client) *wireMessage {
+	aud := client.audience()
+	isMine := aud == audienceBidder && p.owner != "" && *client.bidderID == p.owner
+
+	return p.forAudience(aud, isMine)
+}
+
+// forAudience は受信者区分向けのメッセージを返す（生成に失敗した場合はnil）
+func (p *projectedMessages) forAudience(aud audience, isMine bool) *wireMessage {
+	return p.messages[projectionKey{aud: aud, isMine: isMine}]
+}
+
+// eventAuctionID はRedisから受信したイベントのオークションIDを返す（該当しない場合は空文字列）
+func eventAuctionID(data map[string]interface{}) string {
+	if auctionID, ok := data["auction_id"].(string); ok {
+		return auctionID
+	}
+	if item, ok := data["item"].(map[string]interface{}); ok {
+		if auctionID, ok := item["auction_id"].(string); ok {
+			return auctionID
+		}
+	}
+	return ""
+}
+
+// copyMap はマップの浅いコピーを返す
+func copyMap(src map[string]interface{}) map[string]interface{} {
+	dst := make(map[string]interface{}, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// deleteKeys はマップから指定したキーを削除する
+func deleteKeys(m map[string]interface{}, keys ...string) {
+	for _, key := range keys {
+		delete(m, key)
+	}
+}
@@ -0,0 +1,230 @@
+package ws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bidPlacedData(bidderID string) map[string]interface{} {
+	return map[string]interface{}{
+		"auction_id": "auction-1",
+		"item_id":    "item-1",
+		"bid": map[string]interface{}{
+			"id":            float64(10),
+			"bidder_id":     bidderID,
+			"bidder_name":   "Taro",
+			"paddle_number": float64(7),
+			"price":         float64(1500),
+			"is_winning":    true,
+		},
+	}
+}
+
+func decodeProjected(t *testing.T, message *wireMessage) map[string]interface{} {
+	t.Helper()
+	require.NotNil(t, message)
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal(message.text, &event))
+	return event["data"].(map[string]interface{})
+}
+
+func TestProjectedMessages_BidPlaced(t *testing.T) {
+	owner := "6f1c2d3e-0000-0000-0000-00000000000a"
+	other := "6f1c2d3e-0000-0000-0000-00000000000b"
+
+	data := bidPlacedData(owner)
+	messages := newProjectedMessages("bid:placed", data)
+
+	t.Run("Admin receives full identity", func(t *testing.T) {
+		admin := NewClient(nil, nil, "1", "auctioneer", nil, "auctioneer")
+		bid := decodeProjected(t, messages.forClient(admin))["bid"].(map[string]interface{})
+
+		assert.Equal(t, owner, bid["bidder_id"])
+		assert.Equal(t, "Taro", bid["bidder_name"])
+		assert.NotContains(t, bid, "is_mine")
+	})
+
+	t.Run("Bidder receives paddle number and is_mine", func(t *testing.T) {
+		ownerClient := NewClient(nil, nil, owner, "bidder", &owner, "bidder")
+		otherClient := NewClient(nil, nil, other, "bidder", &other, "bidder")
+
+		mine := decodeProjected(t, messages.forClient(ownerClient))["bid"].(map[string]interface{})
+		assert.Equal(t, true, mine["is_mine"])
+		assert.Equal(t, float64(7), mine["paddle_number"])
+		assert.NotContains(t, mine, "bidder_id")
+		assert.NotContains(t, mine, "bidder_name")
+
+		theirs := decodeProjected(t, messages.forClient(otherClient))["bid"].(map[string]interface{})
+		assert.Equal(t, false, theirs["is_mine"])
+		assert.Equal(t, float64(7), theirs["paddle_number"])
+	})
+
+	t.Run("Spectator receives neither identity nor is_mine", func(t *testing.T) {
+		spectator := NewClient(nil, nil, "", "spectator", nil, "")
+		bid := decodeProjected(t, messages.forClient(spectator))["bid"].(map[string]interface{})
+
+		assert.NotContains(t, bid, "bidder_id")
+		assert.NotContains(t, bid, "bidder_name")
+		assert.NotContains(t, bid, "paddle_number")
+		assert.NotContains(t, bid, "is_mine")
+		assert.Equal(t, float64(1500), bid["price"])
+	})
+
+	t.Run("Source data is not modified", func(t *testing.T) {
+		assert.Equal(t, bidPlacedData(owner), data)
+	})
+}
+
+func TestProjectEventData_ItemEnded(t *testing.T) {
+	winner := "6f1c2d3e-0000-0000-0000-00000000000a"
+	data := map[string]interface{}{
+		"item_id": "item-1",
+		"item": map[string]interface{}{
+			"id":                   "item-1",
+			"winner_id":            winner,
+			"winner_paddle_number": float64(3),
+		},
+	}
+
+	assert.Equal(t, winner, eventOwner("item:ended", data))
+
+	bidderView := projectEventData("item:ended", data, audienceBidder, true)["item"].(map[string]interface{})
+	assert.NotContains(t, bidderView, "winner_id")
+	assert.Equal(t, true, bidderView["is_mine"])
+	assert.Equal(t, float64(3), bidderView["winner_paddle_number"])
+
+	spectatorView := projectEventData("item:ended", data, audienceSpectator, false)["item"].(map[string]interface{})
+	assert.NotContains(t, spectatorView, "winner_id")
+	assert.NotContains(t, spectatorView, "winner_paddle_number")
+	assert.NotContains(t, spectatorView, "is_mine")
+}
+
+func TestProjectedMessages_ChatMessage(t *testing.T) {
+	owner := "6f1c2d3e-0000-0000-0000-00000000000a"
+	other := "6f1c2d3e-0000-0000-0000-00000000000b"
+
+	messages := newProjectedMessages("chat:message", map[string]interface{}{
+		"auction_id":  "auction-1",
+		"message_id":  float64(3),
+		"bidder_id":   owner,
+		"bidder_name": "Taro",
+		"message":     "Is lot 12 still available?",
+	})
+
+	t.Run("Admin receives sender identity", func(t *testing.T) {
+		admin := NewClient(nil, nil, "1", "auctioneer", nil, "auctioneer")
+		data := decodeProjected(t, messages.forClient(admin))
+
+		assert.Equal(t, owner, data["bidder_id"])
+		assert.Equal(t, "Taro", data["bidder_name"])
+	})
+
+	t.Run("Sender receives own message without identity", func(t *testing.T) {
+		ownerClient := NewClient(nil, nil, owner, "bidder", &owner, "bidder")
+		data := decodeProjected(t, messages.forClient(ownerClient))
+
+		assert.Equal(t, true, data["is_mine"])
+		assert.NotContains(t, data, "bidder_id")
+		assert.NotContains(t, data, "bidder_name")
+	})
+
+	t.Run("Other bidders and spectators receive nothing", func(t *testing.T) {
+		otherClient := NewClient(nil, nil, other, "bidder", &other, "bidder")
+		spectator := NewClient(nil, nil, "", "spectator", nil, "")
+
+		assert.Nil(t, messages.forClient(otherClient))
+		assert.Nil(t, messages.forClient(spectator))
+	})
+}
+
+func TestProjectedMessages_ParticipantJoined(t *testing.T) {
+	owner := "6f1c2d3e-0000-0000-0000-00000000000a"
+	other := "6f1c2d3e-0000-0000-0000-00000000000b"
+
+	messages := newProjectedMessages("participant:joined", map[string]interface{}{
+		"auction_id": "auction-1",
+		"participant": map[string]interface{}{
+			"bidder_id":     owner,
+			"display_name":  "Taro",
+			"paddle_number": float64(4),
+			"is_online":     true,
+			"bid_count":     float64(2),
+		},
+	})
+
+	t.Run("Admin receives participant identity", func(t *testing.T) {
+		admin := NewClient(nil, nil, "1", "auctioneer", nil, "auctioneer")
+		participant := decodeProjected(t, messages.forClient(admin))["participant"].(map[string]interface{})
+
+		assert.Equal(t, owner, participant["bidder_id"])
+		assert.Equal(t, "Taro", participant["display_name"])
+	})
+
+	t.Run("Bidders receive paddle number instead of identity", func(t *testing.T) {
+		ownerClient := NewClient(nil, nil, owner, "bidder", &owner, "bidder")
+		otherClient := NewClient(nil, nil, other, "bidder", &other, "bidder")
+
+		theirs := decodeProjected(t, messages.forClient(otherClient))["participant"].(map[string]interface{})
+		assert.Equal(t, float64(4), theirs["paddle_number"])
+		assert.Equal(t, false, theirs["is_mine"])
+		assert.NotContains(t, theirs, "bidder_id")
+		assert.NotContains(t, theirs, "display_name")
+
+		mine := decodeProjected(t, messages.forClient(ownerClient))["participant"].(map[string]interface{})
+		assert.Equal(t, true, mine["is_mine"])
+		assert.NotContains(t, mine, "bidder_id")
+	})
+}
+
+func TestProjectedMessages_ParticipantLeft(t *testing.T) {
+	owner := "6f1c2d3e-0000-0000-0000-00000000000a"
+	other := "6f1c2d3e-0000-0000-0000-00000000000b"
+
+	messages := newProjectedMessages("participant:left", map[string]interface{}{
+		"auction_id":    "auction-1",
+		"bidder_id":     owner,
+		"paddle_number": float64(4),
+	})
+
+	otherClient := NewClient(nil, nil, other, "bidder", &other, "bidder")
+	data := decodeProjected(t, messages.forClient(otherClient))
+
+	assert.Equal(t, "auction-1", data["auction_id"])
+	assert.Equal(t, float64(4), data["paddle_number"])
+	assert.Equal(t, false, data["is_mine"])
+	assert.NotContains(t, data, "bidder_id")
+}
+
+func TestParticipantsForClient(t *testing.T) {
+	owner := "6f1c2d3e-0000-0000-0000-00000000000a"
+	other := "6f1c2d3e-0000-0000-0000-00000000000b"
+
+	participants := []ParticipantData{
+		{BidderID: owner, DisplayName: "Taro", PaddleNumber: 1, IsOnline: true},
+		{BidderID: other, DisplayName: "Hanako", PaddleNumber: 2, IsOnline: true},
+	}
+
+	t.Run("Admin receives identities", func(t *testing.T) {
+		admin := NewClient(nil, nil, "1", "auctioneer", nil, "auctioneer")
+		assert.Equal(t, participants, participantsForClient(participants, admin))
+	})
+
+	t.Run("Bidder receives paddle numbers and own flag", func(t *testing.T) {
+		ownerClient := NewClient(nil, nil, owner, "bidder", &owner, "bidder")
+		projected := participantsForClient(participants, ownerClient)
+
+		assert.Equal(t, []ParticipantData{
+			{PaddleNumber: 1, IsMine: true, IsOnline: true},
+			{PaddleNumber: 2, IsOnline: true},
+		}, projected)
+		assert.Equal(t, owner, participants[0].BidderID, "source list must not be modified")
+	})
+
+	t.Run("Spectator receives an empty list", func(t *testing.T) {
+		spectator := NewClient(nil, nil, "", "spectator", nil, "")
+		assert.Empty(t, participantsForClient(participants, spectator))
+	})
+}
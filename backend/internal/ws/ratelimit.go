@@ -0,0 +1,52 @@
+package ws
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter は接続単位のトークンバケット方式レートリミッター
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64   // 1秒あたりの補充トークン数
+	burst    float64   // バケットの最大容量
+	tokens   float64   // 現在のトークン数
+	lastTime time.Time // 最後に補充した時刻
+}
+
+// newRateLimiter は新しいレートリミッターを作成する
+func newRateLimiter(ratePerSecond float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:     ratePerSecond,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastTime: time.Now(),
+	}
+}
+
+// Allow はトークンを1つ消費できればtrueを返す
+func (l *rateLimiter) Allow() bool {
+	return l.allowAt(time.Now())
+}
+
+// allowAt は指定時刻を基準にトークンを補充・消費する
+func (l *rateLimiter) allowAt(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elapsed := now.Sub(l.lastTime).Seconds()
+	if elapsed > 0 {
+		l.tokens += elapsed * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.lastTime = now
+	}
+
+	if l.tokens < 1 {
+		return false
+	}
+
+	l.tokens--
+	return true
+}
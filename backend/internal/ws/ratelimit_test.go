@@ -0,0 +1,38 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	t.Run("Allows up to burst then rejects", func(t *testing.T) {
+		limiter := newRateLimiter(1, 3)
+		now := limiter.lastTime
+
+		assert.True(t, limiter.allowAt(now))
+		assert.True(t, limiter.allowAt(now))
+		assert.True(t, limiter.allowAt(now))
+		assert.False(t, limiter.allowAt(now))
+	})
+
+	t.Run("Refills tokens over time", func(t *testing.T) {
+		limiter := newRateLimiter(2, 1)
+		now := limiter.lastTime
+
+		assert.True(t, limiter.allowAt(now))
+		assert.False(t, limiter.allowAt(now.Add(100*time.Millisecond)))
+		assert.True(t, limiter.allowAt(now.Add(600*time.Millisecond)))
+	})
+
+	t.Run("Does not exceed burst after long idle", func(t *testing.T) {
+		limiter := newRateLimiter(10, 2)
+		now := limiter.lastTime.Add(time.Hour)
+
+		assert.True(t, limiter.allowAt(now))
+		assert.True(t, limiter.allowAt(now))
+		assert.False(t, limiter.allowAt(now))
+	})
+}
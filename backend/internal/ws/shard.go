@@ -0,0 +1,127 @@
+package ws
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+const (
+	numRoomShards  = 16   // ルームを分散するシャード数
+	shardQueueSize = 1024 // シャードごとの配信キューの長さ
+)
+
+// roomMessage はオークションルームに配信するメッセージ
+// raw（全員に同じ内容）またはprojected（受信者区分ごとに射影済み）のどちらかを設定する
+type roomMessage struct {
+	auctionID string
+	key       string // 送信キューでの統合キー（coalesceKeyを参照）
+	raw       *wireMessage
+	projected *projectedMessages
+	public    bool // falseの場合は観覧者に配信しない
+}
+
+// forClient はクライアントに送信するメッセージを返す（送信しない場合はnil）
+func (m *roomMessage) forClient(client *Client) *wireMessage {
+	if !m.public && client.isSpectator() {
+		return nil
+	}
+	if m.projected != nil {
+		return m.projected.forClient(client)
+	}
+	return m.raw
+}
+
+// roomShard はオークションルームの一部を担当する
+// ルームへの配信はシャードごとの専用goroutineで行い、ルーム間で配信が互いに待たされないようにする
+type roomShard struct {
+	mu    sync.RWMutex
+	rooms map[string]map[*Client]struct{} // オークションID -> 参加クライアント
+	queue chan *roomMessage
+}
+
+// newRoomShard は新しいシャードを作成する
+func newRoomShard() *roomShard {
+	return &roomShard{
+		rooms: make(map[string]map[*Client]struct{}),
+		queue: make(chan *roomMessage, shardQueueSize),
+	}
+}
+
+// run はシャードの配信ループを実行する
+func (s *roomShard) run() {
+	for msg := range s.queue {
+		s.deliver(msg)
+	}
+}
+
+// deliver はルームの全クライアントの送信キューにメッセージを追加する
+// 送信が追いつかないクライアントの扱いは送信キュー側の方針に従う（outbox.goを参照）
+func (s *roomShard) deliver(msg *roomMessage) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for client := range s.rooms[msg.auctionID] {
+		message := msg.forClient(client)
+		if message == nil {
+			continue
+		}
+		client.enqueue(msg.key, message)
+	}
+}
+
+// join はクライアントをルームに追加する
+func (s *roomShard) join(auctionID string, client *Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, ok := s.rooms[auctionID]
+	if !ok {
+		room = make(map[*Client]struct{})
+		s.rooms[auctionID] = room
+	}
+	room[client] = struct{}{}
+}
+
+// leave はクライアントをルームから削除する
+func (s *roomShard) leave(auctionID string, client *Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, ok := s.rooms[auctionID]
+	if !ok {
+		return
+	}
+	delete(room, client)
+
+	// ルームが空になった場合は削除
+	if len(room) == 0 {
+		delete(s.rooms, auctionID)
+	}
+}
+
+// members はルームのクライアント一覧を返す
+func (s *roomShard) members(auctionID string) []*Client {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clients := make([]*Client, 0, len(s.rooms[auctionID]))
+	for client := range s.rooms[auctionID] {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// size はルームのクライアント数を返す
+func (s *roomShard) size(auctionID string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.rooms[auctionID])
+}
+
+// shardIndex はオークションIDに対応するシャードの番号を返す
+func shardIndex(auctionID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(auctionID))
+	return int(h.Sum32() % numRoomShards)
+}
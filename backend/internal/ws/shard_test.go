@@ -0,0 +1,140 @@
+package ws
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newBenchClients はルームに参加したクライアントを作成する
+// 送信キューを読み捨てるgoroutineを起動し、停止用の関数を返す
+func newBenchClients(tb testing.TB, hub *Hub, auctionID string, n int) func() {
+	tb.Helper()
+
+	// 5,000件の接続ログを抑制する
+	log.SetOutput(io.Discard)
+	tb.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	var wg sync.WaitGroup
+	clients := make([]*Client, 0, n)
+	for i := 0; i < n; i++ {
+		var client *Client
+		switch i % 10 {
+		case 0:
+			client = NewClient(hub, nil, fmt.Sprintf("admin-%d", i), "auctioneer", nil, "auctioneer")
+		case 1, 2, 3:
+			client = NewClient(hub, nil, "", roleSpectator, nil, "")
+		default:
+			bidderID := fmt.Sprintf("bidder-%d", i)
+			client = NewClient(hub, nil, bidderID, "bidder", &bidderID, "bidder")
+		}
+		hub.registerClient(client)
+		// 入札者の参加イベント（DBアクセス）を避けるためシャードに直接追加する
+		client.subscribe(auctionID)
+		hub.shardFor(auctionID).join(auctionID, client)
+		clients = append(clients, client)
+
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			for range c.send.notify {
+				if _, closed, _ := c.send.take(); closed {
+					return
+				}
+			}
+		}(client)
+	}
+
+	return func() {
+		for _, client := range clients {
+			client.closeSend()
+		}
+		wg.Wait()
+	}
+}
+
+func TestShardIndex_Stable(t *testing.T) {
+	auctionID := "a1b2c3d4-0000-0000-0000-000000000001"
+	assert.Equal(t, shardIndex(auctionID), shardIndex(auctionID))
+	assert.GreaterOrEqual(t, shardIndex(auctionID), 0)
+	assert.Less(t, shardIndex(auctionID), numRoomShards)
+}
+
+func TestHub_BroadcastToAuction_DeliversPerRoom(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+
+	auctionA := "a1b2c3d4-0000-0000-0000-00000000000a"
+	auctionB := "a1b2c3d4-0000-0000-0000-00000000000b"
+	clientA := NewClient(hub, nil, "1", "auctioneer", nil, "auctioneer")
+	clientB := NewClient(hub, nil, "2", "auctioneer", nil, "auctioneer")
+	hub.registerClient(clientA)
+	hub.registerClient(clientB)
+	hub.AddClientToRoom(auctionA, clientA)
+	hub.AddClientToRoom(auctionB, clientB)
+	assert.Equal(t, 1, hub.GetRoomSize(auctionA))
+
+	hub.broadcastMessage(&BroadcastMsg{
+		auctionID: auctionA,
+		event:     NewEvent(EventAuctionStarted, auctionA, map[string]interface{}{"item_id": "item-1"}),
+	})
+
+	event := readEvent(t, clientA)
+	assert.Equal(t, string(EventAuctionStarted), event["type"])
+	assert.Equal(t, 0, clientB.send.len())
+
+	// 切断後はルームから削除されること
+	hub.unregisterClient(clientA)
+	assert.Equal(t, 0, hub.GetRoomSize(auctionA))
+	assert.NotPanics(t, func() { hub.unregisterClient(clientA) })
+}
+
+func BenchmarkHub_BroadcastToRoom5000(b *testing.B) {
+	hub := NewHub(nil, nil, nil, nil)
+	auctionID := "a1b2c3d4-0000-0000-0000-000000000001"
+	stop := newBenchClients(b, hub, auctionID, 5000)
+	defer stop()
+
+	event := NewEvent(EventAuctionStarted, auctionID, map[string]interface{}{
+		"auction_id":  auctionID,
+		"item_id":     "item-1",
+		"start_price": 1000,
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hub.broadcastMessage(&BroadcastMsg{auctionID: auctionID, event: event})
+	}
+}
+
+func BenchmarkHub_BroadcastProjectedToRoom5000(b *testing.B) {
+	hub := NewHub(nil, nil, nil, nil)
+	auctionID := "a1b2c3d4-0000-0000-0000-000000000001"
+	stop := newBenchClients(b, hub, auctionID, 5000)
+	defer stop()
+
+	data := map[string]interface{}{
+		"auction_id":    auctionID,
+		"item_id":       "item-1",
+		"bidder_id":     "bidder-5",
+		"bidder_name":   "bidder",
+		"paddle_number": 5,
+		"price":         1500,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Redisから受信したイベントと同様に、射影ごとに1回だけシリアライズして配信する
+		hub.shardFor(auctionID).deliver(&roomMessage{
+			auctionID: auctionID,
+			projected: newProjectedMessages("bid:placed", data),
+			public:    true,
+		})
+	}
+}
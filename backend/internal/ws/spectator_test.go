@@ -0,0 +1,72 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_SpectatorSlots(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+	hub.SetMaxSpectators(2)
+
+	assert.True(t, hub.reserveSpectatorSlot())
+	assert.True(t, hub.reserveSpectatorSlot())
+	assert.False(t, hub.reserveSpectatorSlot())
+	assert.Equal(t, int64(2), hub.GetSpectatorCount())
+
+	// 切断時に接続枠が解放される
+	spectator := NewClient(hub, nil, "spectator:1", roleSpectator, nil, "Spectator")
+	hub.registerClient(spectator)
+	hub.unregisterClient(spectator)
+
+	assert.Equal(t, int64(1), hub.GetSpectatorCount())
+	assert.True(t, hub.reserveSpectatorSlot())
+}
+
+func TestEventHandler_SpectatorIsReadOnly(t *testing.T) {
+	handler := NewEventHandler(nil, nil, nil)
+	spectator := NewClient(nil, nil, "spectator:1", roleSpectator, nil, "Spectator")
+
+	for _, eventType := range []EventType{EventBidPlace, EventItemStart, EventAuctionAnnounce} {
+		handler.Handle(spectator, &Event{Type: eventType, RequestID: "req-1"})
+
+		reply := readEvent(t, spectator)
+		assert.Equal(t, string(EventError), reply["type"])
+		assert.Equal(t, "FORBIDDEN", reply["data"].(map[string]interface{})["code"], eventType)
+	}
+}
+
+func TestHub_BroadcastSkipsPrivateEventsForSpectators(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+
+	bidderID := "6f1c2d3e-0000-0000-0000-00000000000a"
+	bidder := NewClient(hub, nil, bidderID, "bidder", &bidderID, "bidder")
+	spectator := NewClient(hub, nil, "spectator:1", roleSpectator, nil, "Spectator")
+	hub.registerClient(bidder)
+	hub.registerClient(spectator)
+
+	hub.broadcastMessage(&BroadcastMsg{event: NewEvent(EventParticipantLeft, "auction-1", ParticipantLeftData{
+		AuctionID: "auction-1",
+		BidderID:  bidderID,
+	})})
+	assert.Equal(t, 1, bidder.send.len())
+	assert.Equal(t, 0, spectator.send.len())
+
+	hub.broadcastMessage(&BroadcastMsg{event: NewEvent(EventAuctionEnded, "auction-1", nil)})
+	assert.Equal(t, 1, spectator.send.len())
+}
+
+func TestNewClient_SpectatorRateLimit(t *testing.T) {
+	spectator := NewClient(nil, nil, "spectator:1", roleSpectator, nil, "Spectator")
+	for i := 0; i < spectatorMessageRateBurst; i++ {
+		assert.True(t, spectator.msgLimiter.Allow())
+	}
+	assert.False(t, spectator.msgLimiter.Allow())
+
+	bidderID := "6f1c2d3e-0000-0000-0000-00000000000a"
+	bidder := NewClient(nil, nil, bidderID, "bidder", &bidderID, "bidder")
+	for i := 0; i < spectatorMessageRateBurst+1; i++ {
+		assert.True(t, bidder.msgLimiter.Allow())
+	}
+}
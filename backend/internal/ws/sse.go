@@ -0,0 +1,156 @@
+package ws
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	sseHeartbeatPeriod = 15 * time.Second // プロキシによる切断を防ぐコメント送信間隔
+	sseRetryMillis     = 3000             // クライアントの再接続待機時間
+)
+
+// EventStreamReset はLast-Event-IDのイベントが既にバッファにない、または別のエポックのものである場合に送信するイベント
+// 受信したクライアントはREST APIで現在の状態を再取得する必要がある
+const EventStreamReset EventType = "stream:reset"
+
+// ServeSSE はオークションルームのイベントをServer-Sent Eventsで配信する
+// WebSocketが利用できないネットワーク向けの読み取り専用フォールバックで、観覧者と同じ公開用イベントを配信する
+func ServeSSE(hub *Hub, c *gin.Context) {
+	auctionID := c.Param("id")
+	if _, err := uuid.Parse(auctionID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid auction ID"})
+		return
+	}
+
+	// Last-Event-IDヘッダー（EventSourceの自動再接続）またはクエリパラメータから再開位置を取得
+	epoch, lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+		return
+	}
+
+	// 未認証のエンドポイントのため、存在しないオークションのイベントバッファは作成しない
+	if hub.auctionRepo != nil {
+		auction, err := hub.auctionRepo.FindByID(auctionID)
+		if err != nil {
+			log.Printf("Failed to find auction for SSE: auctionID=%s, err=%v", auctionID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+		if auction == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+	}
+
+	// SSE接続は観覧者と同じ接続数上限で管理する
+	if !hub.reserveSpectatorSlot() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Too many spectator connections"})
+		return
+	}
+	defer hub.releaseSpectatorSlot()
+
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Streaming not supported"})
+		return
+	}
+
+	buffer, events, missed, resumable := hub.subscribeAuctionEvents(auctionID, epoch, lastEventID)
+	defer buffer.unsubscribe(events)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetryMillis)
+
+	// 取りこぼしたイベントを再送
+	sentID := lastEventID
+	if !resumable {
+		fmt.Fprintf(c.Writer, "event: %s\ndata: {\"type\":%q,\"data\":{\"auction_id\":%q}}\n\n", EventStreamReset, EventStreamReset, auctionID)
+		sentID = 0
+	}
+	for _, event := range missed {
+		if err := writeSSEEvent(c.Writer, buffer.epoch, event); err != nil {
+			return
+		}
+		sentID = event.ID
+	}
+	flusher.Flush()
+
+	log.Printf("SSE client connected: auctionID=%s, lastEventID=%d", auctionID, lastEventID)
+
+	ticker := time.NewTicker(sseHeartbeatPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// 送信が追いつかず購読が解除された（クライアントはLast-Event-IDで再接続する）
+				log.Printf("SSE client dropped as slow consumer: auctionID=%s", auctionID)
+				return
+			}
+			// 再送済みのイベントは送らない
+			if event.ID <= sentID {
+				continue
+			}
+			if err := writeSSEEvent(c.Writer, buffer.epoch, event); err != nil {
+				return
+			}
+			sentID = event.ID
+			flusher.Flush()
+
+		case <-ticker.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-c.Request.Context().Done():
+			log.Printf("SSE client disconnected: auctionID=%s", auctionID)
+			return
+		}
+	}
+}
+
+// parseLastEventID はLast-Event-ID（<エポック>-<連番>）を取得する（指定がない場合は空文字列と0）
+// エポックのない連番のみのIDはエポック不一致として扱われ、クライアントは状態を再取得する
+func parseLastEventID(c *gin.Context) (string, uint64, error) {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return "", 0, nil
+	}
+
+	epoch, seq, found := strings.Cut(value, "-")
+	if !found {
+		epoch, seq = "", value
+	} else if epoch == "" {
+		return "", 0, fmt.Errorf("missing epoch in event ID %q", value)
+	}
+	id, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return "", 0, err
+	}
+	return epoch, id, nil
+}
+
+// writeSSEEvent はイベントをSSE形式で書き込む（IDにはバッファのエポックを付与する）
+func writeSSEEvent(w gin.ResponseWriter, epoch string, event bufferedEvent) error {
+	_, err := fmt.Fprintf(w, "id: %s-%d\nevent: %s\ndata: %s\n\n", epoch, event.ID, event.Type, event.Message)
+	return err
+}
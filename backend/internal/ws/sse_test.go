@@ -0,0 +1,231 @@
+package ws
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestAuctionEventBuffer(t *testing.T) {
+	t.Run("Replays events after Last-Event-ID", func(t *testing.T) {
+		buffer := newAuctionEventBuffer(4)
+		for i := 0; i < 3; i++ {
+			buffer.append("bid:placed", []byte(`{}`))
+		}
+
+		_, missed, ok := buffer.subscribe(buffer.epoch, 1)
+		require.True(t, ok)
+		require.Len(t, missed, 2)
+		assert.Equal(t, uint64(2), missed[0].ID)
+		assert.Equal(t, uint64(3), missed[1].ID)
+	})
+
+	t.Run("Reports gap when events were evicted", func(t *testing.T) {
+		buffer := newAuctionEventBuffer(2)
+		for i := 0; i < 5; i++ {
+			buffer.append("bid:placed", []byte(`{}`))
+		}
+
+		_, missed, ok := buffer.subscribe(buffer.epoch, 1)
+		assert.False(t, ok)
+		assert.Empty(t, missed)
+
+		_, missed, ok = buffer.subscribe(buffer.epoch, 3)
+		assert.True(t, ok)
+		assert.Len(t, missed, 2)
+	})
+
+	t.Run("Reports gap when ID is ahead of buffer", func(t *testing.T) {
+		buffer := newAuctionEventBuffer(2)
+		buffer.append("bid:placed", []byte(`{}`))
+
+		_, _, ok := buffer.subscribe(buffer.epoch, 10)
+		assert.False(t, ok)
+	})
+
+	t.Run("Reports gap when epoch differs", func(t *testing.T) {
+		buffer := newAuctionEventBuffer(4)
+		buffer.append("bid:placed", []byte(`{}`))
+		buffer.append("bid:placed", []byte(`{}`))
+
+		// 再起動前や別インスタンスのバッファのID
+		_, missed, ok := buffer.subscribe("otherepoch", 1)
+		assert.False(t, ok)
+		assert.Empty(t, missed)
+
+		_, _, ok = buffer.subscribe("", 1)
+		assert.False(t, ok)
+
+		// 新規接続
+		_, _, ok = buffer.subscribe("", 0)
+		assert.True(t, ok)
+	})
+
+	t.Run("Closes slow subscribers", func(t *testing.T) {
+		buffer := newAuctionEventBuffer(eventSubscriberBuffer * 2)
+		ch, _, _ := buffer.subscribe("", 0)
+
+		for i := 0; i < eventSubscriberBuffer+1; i++ {
+			buffer.append("bid:placed", []byte(`{}`))
+		}
+
+		for range ch {
+		}
+		assert.Empty(t, buffer.subscribers)
+	})
+}
+
+func TestHub_EvictIdleEventBuffers(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+	idleID := "6f1c2d3e-0000-0000-0000-0000000000a1"
+	subscribedID := "6f1c2d3e-0000-0000-0000-0000000000a2"
+
+	hub.recordAuctionEvent(idleID, "bid:placed", []byte(`{}`))
+	idleEpoch := hub.auctionEvents(idleID).epoch
+	buffer, ch, _, _ := hub.subscribeAuctionEvents(subscribedID, "", 0)
+
+	// 購読者のいるバッファは残し、アイドルなバッファのみ破棄する
+	hub.evictIdleEventBuffers(time.Now().Add(eventBufferIdleTTL))
+	assert.NotContains(t, hub.eventBuffers, idleID)
+	assert.Contains(t, hub.eventBuffers, subscribedID)
+
+	// 破棄後のバッファは別のエポックになり、古いIDでは再開できない
+	_, _, _, ok := hub.subscribeAuctionEvents(idleID, idleEpoch, 1)
+	assert.False(t, ok)
+
+	buffer.unsubscribe(ch)
+	hub.evictIdleEventBuffers(time.Now().Add(eventBufferIdleTTL))
+	assert.NotContains(t, hub.eventBuffers, subscribedID)
+}
+
+func TestServeSSE_ResumesFromLastEventID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hub := NewHub(nil, nil, nil, nil)
+	auctionID := "6f1c2d3e-0000-0000-0000-0000000000aa"
+
+	hub.recordAuctionEvent(auctionID, "bid:placed", []byte(`{"type":"bid:placed","data":{"price":100}}`))
+	hub.recordAuctionEvent(auctionID, "bid:placed", []byte(`{"type":"bid:placed","data":{"price":200}}`))
+
+	router := gin.New()
+	router.GET("/sse/auctions/:id", func(c *gin.Context) { ServeSSE(hub, c) })
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/sse/auctions/"+auctionID, nil)
+	require.NoError(t, err)
+	epoch := hub.auctionEvents(auctionID).epoch
+	req.Header.Set("Last-Event-ID", epoch+"-1")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readUntil := func(prefix string) string {
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			if strings.HasPrefix(line, prefix) {
+				return strings.TrimSpace(line)
+			}
+		}
+	}
+
+	// 再送されたイベント
+	assert.Equal(t, "id: "+epoch+"-2", readUntil("id:"))
+	assert.Contains(t, readUntil("data:"), `"price":200`)
+
+	// 接続後に発生したイベント
+	hub.recordAuctionEvent(auctionID, "bid:placed", []byte(`{"type":"bid:placed","data":{"price":300}}`))
+	assert.Equal(t, "id: "+epoch+"-3", readUntil("id:"))
+	assert.Contains(t, readUntil("data:"), `"price":300`)
+}
+
+func TestServeSSE_InvalidRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hub := NewHub(nil, nil, nil, nil)
+	router := gin.New()
+	router.GET("/sse/auctions/:id", func(c *gin.Context) { ServeSSE(hub, c) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sse/auctions/not-a-uuid", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/sse/auctions/6f1c2d3e-0000-0000-0000-0000000000aa", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServeSSE_ResetsOnEpochMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hub := NewHub(nil, nil, nil, nil)
+	auctionID := "6f1c2d3e-0000-0000-0000-0000000000ab"
+
+	hub.recordAuctionEvent(auctionID, "bid:placed", []byte(`{"type":"bid:placed","data":{"price":100}}`))
+
+	router := gin.New()
+	router.GET("/sse/auctions/:id", func(c *gin.Context) { ServeSSE(hub, c) })
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/sse/auctions/"+auctionID, nil)
+	require.NoError(t, err)
+	// 再起動前のサーバーが発行したID
+	req.Header.Set("Last-Event-ID", "oldepoch-1")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if strings.HasPrefix(line, "event:") {
+			assert.Equal(t, "event: "+string(EventStreamReset), strings.TrimSpace(line))
+			return
+		}
+	}
+}
+
+func TestServeSSE_UnknownAuction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+
+	hub := NewHub(nil, repository.NewAuctionRepository(db), nil, nil)
+	router := gin.New()
+	router.GET("/sse/auctions/:id", func(c *gin.Context) { ServeSSE(hub, c) })
+
+	mock.ExpectQuery(`SELECT \* FROM "auctions"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sse/auctions/6f1c2d3e-0000-0000-0000-0000000000ac", nil))
+
+	// 存在しないオークションのイベントバッファは作成しない
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, hub.eventBuffers)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,51 +2,36 @@ package ws
 
 import (
 	"log"
-	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/tsutsumi389/real-time-auction/internal/domain"
-	"github.com/tsutsumi389/real-time-auction/internal/service"
 )
 
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// TODO: 本番環境では適切なオリジンチェックを実装
-		return true
-	},
+	CheckOrigin:     checkOrigin, // CORS_ORIGINSの許可リストと同一オリジンのみ許可
+	// クライアントが要求したサブプロトコルのうち、この順で最初に一致したものを選択する
+	Subprotocols: []string{SubprotocolMsgpack, SubprotocolJSON},
+	// permessage-deflate（JSONクライアントのみ圧縮して送信する。writePumpを参照）
+	EnableCompression: true,
 }
 
 // ServeWs はWebSocket接続をアップグレードし、クライアントを登録する
-func ServeWs(hub *Hub, c *gin.Context) {
-	// JWTトークンをクエリパラメータから取得
-	tokenString := c.Query("token")
-	if tokenString == "" {
-		// Authorization ヘッダーからも確認
-		authHeader := c.GetHeader("Authorization")
-		if authHeader != "" {
-			parts := strings.Split(authHeader, " ")
-			if len(parts) == 2 && parts[0] == "Bearer" {
-				tokenString = parts[1]
-			}
-		}
-	}
-
-	if tokenString == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing authentication token"})
+func ServeWs(hub *Hub, auth *Authenticator, c *gin.Context) {
+	claims, err := auth.authenticate(c)
+	if err != nil {
+		log.Printf("Failed to authenticate WebSocket connection: %v", err)
+		rejectUpgrade(c, CloseUnauthorized, "invalid or expired credentials")
 		return
 	}
 
-	// JWTトークンを検証
-	jwtService := service.NewJWTService("")
-	claims, err := jwtService.ValidateToken(tokenString)
-	if err != nil {
-		log.Printf("Failed to validate token: %v", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+	// 認証情報がない場合は読み取り専用の観覧者として接続する
+	if claims == nil {
+		serveSpectator(hub, c)
 		return
 	}
 
@@ -62,7 +47,7 @@ func ServeWs(hub *Hub, c *gin.Context) {
 			userID = strconv.FormatInt(id, 10)
 		} else {
 			log.Printf("Failed to get admin user ID")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID"})
+			rejectUpgrade(c, CloseUnauthorized, "invalid user ID")
 			return
 		}
 		// ロールを文字列に変換
@@ -74,9 +59,9 @@ func ServeWs(hub *Hub, c *gin.Context) {
 		default:
 			userRole = "admin"
 		}
-		// 管理者の表示名を取得（現時点ではemailを使用、後で改善可能）
-		if claims.Email != "" {
-			displayName = claims.Email
+		// 管理者の表示名（メールアドレスは他の参加者に公開しない）
+		if claims.DisplayName != "" {
+			displayName = claims.DisplayName
 		} else {
 			displayName = "Admin"
 		}
@@ -88,15 +73,14 @@ func ServeWs(hub *Hub, c *gin.Context) {
 			bidderID = &id // bidderの場合はUUIDを設定
 		} else {
 			log.Printf("Failed to get bidder user ID")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID"})
+			rejectUpgrade(c, CloseUnauthorized, "invalid user ID")
 			return
 		}
 		userRole = "bidder"
 
-		// 入札者の表示名を取得（データベースから取得する必要がある）
-		// ここではひとまずemailを使用、後でRepositoryから取得するように改善
-		if claims.Email != "" {
-			displayName = claims.Email
+		// 入札者の表示名（メールアドレスは他の参加者に公開しない）
+		if claims.DisplayName != "" {
+			displayName = claims.DisplayName
 		} else {
 			displayName = "Bidder"
 		}
@@ -112,10 +96,40 @@ func ServeWs(hub *Hub, c *gin.Context) {
 	// クライアントを作成
 	client := NewClient(hub, conn, userID, userRole, bidderID, displayName)
 
-	// Hubに登録
-	hub.register <- client
+	// 接続数上限を確認してHubに登録
+	hub.admit(client, c.ClientIP())
+}
+
+// serveSpectator は未認証の観覧者接続を受け付ける
+// 観覧者は認証済みクライアントとは別の接続数上限で管理し、公開用に射影されたイベントのみ受信する
+func serveSpectator(hub *Hub, c *gin.Context) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade spectator connection: %v", err)
+		return
+	}
+
+	if !hub.reserveSpectatorSlot() {
+		closeConn(conn, CloseServerFull, "too many spectator connections")
+		return
+	}
+
+	// 観覧者は接続ごとに一意のIDを持つ（ログ用）
+	userID := "spectator:" + uuid.NewString()
+	client := NewClient(hub, conn, userID, roleSpectator, nil, "Spectator")
 
-	// readPumpとwritePumpをgoroutineで開始
-	go client.writePump()
-	go client.readPump()
+	if !hub.admit(client, c.ClientIP()) {
+		hub.releaseSpectatorSlot()
+	}
+}
+
+// rejectUpgrade は接続をアップグレードした上でクローズコード付きで切断する
+// ブラウザはアップグレード失敗時のHTTPステータスを参照できないため、クローズコードで理由を伝える
+func rejectUpgrade(c *gin.Context, code int, reason string) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// WebSocketのリクエストでない場合はUpgraderがHTTPエラーを返している
+		return
+	}
+	closeConn(conn, code, reason)
 }
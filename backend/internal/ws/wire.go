@@ -0,0 +1,101 @@
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// WebSocketのサブプロトコル（Sec-WebSocket-Protocol）で選択できる送受信形式
+// サブプロトコルを指定しないクライアントにはJSONで送信する
+const (
+	SubprotocolJSON    = "auction.json"
+	SubprotocolMsgpack = "auction.msgpack"
+)
+
+// wireFormat はクライアントとの送受信形式
+type wireFormat int
+
+const (
+	wireFormatJSON    wireFormat = iota // テキストフレーム（JSON）。キュー内の複数メッセージは改行区切りで1フレームにまとめる
+	wireFormatMsgpack                   // バイナリフレーム（MessagePack）。1メッセージ1フレーム
+)
+
+// wireFormatFor はネゴシエートされたサブプロトコルに対応する送受信形式を返す
+func wireFormatFor(subprotocol string) wireFormat {
+	if subprotocol == SubprotocolMsgpack {
+		return wireFormatMsgpack
+	}
+	return wireFormatJSON
+}
+
+// wireMessage はクライアントに送信するメッセージ
+// イベントのスキーマはJSONで一度だけ定義し、MessagePackはJSONと同じ構造に変換する
+// 変換は最初に必要になった時点で一度だけ行い、同じメッセージを受け取る全クライアントで共有する
+type wireMessage struct {
+	text []byte
+
+	msgpackOnce sync.Once
+	msgpack     []byte
+}
+
+// newWireMessage はシリアライズ済みのJSONからメッセージを作成する
+func newWireMessage(text []byte) *wireMessage {
+	return &wireMessage{text: text}
+}
+
+// encode は送受信形式に応じたメッセージを返す（変換に失敗した場合はnil）
+func (m *wireMessage) encode(format wireFormat) []byte {
+	if format != wireFormatMsgpack {
+		return m.text
+	}
+
+	m.msgpackOnce.Do(func() {
+		message, err := jsonToMsgpack(m.text)
+		if err != nil {
+			log.Printf("Failed to encode MessagePack message: %v", err)
+			return
+		}
+		m.msgpack = message
+	})
+	return m.msgpack
+}
+
+// jsonToMsgpack はJSONを同じ構造のMessagePackに変換する
+// 数値はjson.Numberとして読み込み、整数はMessagePackの整数としてエンコードする
+func jsonToMsgpack(text []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(text))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return msgp.AppendIntf(nil, value)
+}
+
+// msgpackToJSON はクライアントから受信したMessagePackをJSONに変換する
+func msgpackToJSON(message []byte) ([]byte, error) {
+	value, rest, err := msgp.ReadIntfBytes(message)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after MessagePack value")
+	}
+	return json.Marshal(value)
+}
+
+// decodeIncoming は受信したフレームをJSONに変換する
+// バイナリフレームはMessagePackとして扱い、テキストフレームはそのまま返す
+func decodeIncoming(messageType int, message []byte) ([]byte, error) {
+	if messageType == websocket.BinaryMessage {
+		return msgpackToJSON(message)
+	}
+	return message, nil
+}
@@ -0,0 +1,155 @@
+package ws
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// sampleBidMessage は入札イベント（管理者向けの射影）のJSONを返す
+func sampleBidMessage(tb testing.TB) []byte {
+	tb.Helper()
+	messages := newProjectedMessages("bid:placed", map[string]interface{}{
+		"auction_id": "a1b2c3d4-0000-0000-0000-000000000001",
+		"item_id":    "b1b2c3d4-0000-0000-0000-000000000002",
+		"bid": map[string]interface{}{
+			"id":            float64(12345),
+			"bidder_id":     "6f1c2d3e-0000-0000-0000-00000000000a",
+			"bidder_name":   "Bidder A",
+			"paddle_number": float64(17),
+			"price":         float64(150000),
+			"is_winning":    true,
+			"bid_at":        time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		},
+	})
+	message := messages.forAudience(audienceAdmin, false)
+	require.NotNil(tb, message)
+	return message.text
+}
+
+func TestWireMessage_MsgpackHasSameSchemaAsJSON(t *testing.T) {
+	text := sampleBidMessage(t)
+	message := newWireMessage(text)
+
+	assert.Equal(t, text, message.encode(wireFormatJSON))
+
+	encoded := message.encode(wireFormatMsgpack)
+	require.NotNil(t, encoded)
+	assert.Less(t, len(encoded), len(text))
+
+	value, rest, err := msgp.ReadIntfBytes(encoded)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+
+	// 整数は浮動小数点ではなく整数としてエンコードされる
+	bid := value.(map[string]interface{})["data"].(map[string]interface{})["bid"].(map[string]interface{})
+	assert.Equal(t, int64(150000), bid["price"])
+
+	roundTrip, err := json.Marshal(value)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(text), string(roundTrip))
+
+	// 変換結果は共有される
+	assert.Same(t, &encoded[0], &message.encode(wireFormatMsgpack)[0])
+}
+
+func TestDecodeIncoming(t *testing.T) {
+	text := []byte(`{"type":"ping"}`)
+	decoded, err := decodeIncoming(websocket.TextMessage, text)
+	require.NoError(t, err)
+	assert.Equal(t, text, decoded)
+
+	binary, err := msgp.AppendIntf(nil, map[string]interface{}{"type": "ping", "request_id": "r1"})
+	require.NoError(t, err)
+	decoded, err = decodeIncoming(websocket.BinaryMessage, binary)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"ping","request_id":"r1"}`, string(decoded))
+
+	_, err = decodeIncoming(websocket.BinaryMessage, []byte{0xc1})
+	assert.Error(t, err)
+}
+
+func TestServeWs_NegotiatesMsgpack(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hub := NewHub(nil, nil, nil, nil)
+
+	router := gin.New()
+	router.GET("/ws", func(c *gin.Context) {
+		ServeWs(hub, NewAuthenticator(nil, nil), c)
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{SubprotocolMsgpack, SubprotocolJSON}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, SubprotocolMsgpack, conn.Subprotocol())
+
+	request, err := msgp.AppendIntf(nil, map[string]interface{}{
+		"type": "subscribe",
+		"data": map[string]interface{}{"auction_id": "a1b2c3d4-0000-0000-0000-000000000001"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, request))
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var types []string
+	for len(types) < 2 {
+		messageType, message, err := conn.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, websocket.BinaryMessage, messageType)
+
+		value, _, err := msgp.ReadIntfBytes(message)
+		require.NoError(t, err)
+		types = append(types, value.(map[string]interface{})["type"].(string))
+	}
+	assert.Equal(t, []string{string(EventParticipantsList), "subscribed"}, types)
+}
+
+// BenchmarkWireEncoding は1イベントあたりの送信バイト数を形式ごとに比較する
+func BenchmarkWireEncoding(b *testing.B) {
+	text := sampleBidMessage(b)
+
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = newWireMessage(text).encode(wireFormatJSON)
+		}
+		b.ReportMetric(float64(len(text)), "bytes/event")
+	})
+
+	b.Run("json_deflate", func(b *testing.B) {
+		// permessage-deflate（コンテキスト引き継ぎなし）と同等の圧縮
+		var buf bytes.Buffer
+		w, err := flate.NewWriter(&buf, flate.BestSpeed)
+		require.NoError(b, err)
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			w.Reset(&buf)
+			w.Write(text)
+			w.Flush()
+		}
+		// 末尾の同期マーカー（4バイト）は送信時に取り除かれる
+		b.ReportMetric(float64(buf.Len()-4), "bytes/event")
+	})
+
+	b.Run("msgpack", func(b *testing.B) {
+		var encoded []byte
+		for i := 0; i < b.N; i++ {
+			encoded = newWireMessage(text).encode(wireFormatMsgpack)
+		}
+		b.ReportMetric(float64(len(encoded)), "bytes/event")
+	})
+}
@@ -0,0 +1,16 @@
+-- Migration: 015_create_auction_messages (rollback)
+-- Description: オークションのメッセージ・チャットのミュートを削除する
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- Step 1: チャットのミュートテーブルを削除
+DROP TABLE IF EXISTS auction_chat_mutes;
+
+-- Step 2: メッセージテーブルを削除
+DROP TABLE IF EXISTS auction_messages;
+
+-- Step 3: メッセージ受付の切り替えカラムを削除
+ALTER TABLE auctions DROP COLUMN IF EXISTS chat_enabled;
+
+COMMIT;
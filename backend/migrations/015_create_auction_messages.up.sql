@@ -0,0 +1,44 @@
+-- Migration: 015_create_auction_messages
+-- Description: オークションのアナウンス・入札者からの質問を記録するテーブルとチャットのミュートを追加する
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- Step 1: オークション単位で入札者からのメッセージ受付を切り替えるカラムを追加
+ALTER TABLE auctions ADD COLUMN chat_enabled BOOLEAN NOT NULL DEFAULT false;
+
+-- Step 2: メッセージテーブルを作成
+-- announcement: 主催者からのアナウンス（全員に配信）
+-- question: 入札者から主催者への質問（主催者と送信者本人にのみ配信）
+CREATE TABLE auction_messages (
+    id BIGSERIAL PRIMARY KEY,
+    auction_id UUID NOT NULL REFERENCES auctions(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    admin_id BIGINT REFERENCES admins(id),
+    bidder_id UUID REFERENCES bidders(id),
+    sender_name VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    deleted_at TIMESTAMPTZ,
+    deleted_by BIGINT REFERENCES admins(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT chk_auction_messages_kind CHECK (kind IN ('announcement', 'question')),
+    CONSTRAINT chk_auction_messages_sender CHECK (
+        (kind = 'announcement' AND admin_id IS NOT NULL AND bidder_id IS NULL) OR
+        (kind = 'question' AND bidder_id IS NOT NULL AND admin_id IS NULL)
+    )
+);
+
+CREATE INDEX idx_auction_messages_auction ON auction_messages(auction_id, created_at);
+CREATE INDEX idx_auction_messages_bidder ON auction_messages(bidder_id) WHERE bidder_id IS NOT NULL;
+
+-- Step 3: チャットのミュートテーブルを作成（ミュート解除で行を削除する）
+CREATE TABLE auction_chat_mutes (
+    auction_id UUID NOT NULL REFERENCES auctions(id) ON DELETE CASCADE,
+    bidder_id UUID NOT NULL REFERENCES bidders(id),
+    muted_by BIGINT NOT NULL REFERENCES admins(id),
+    reason VARCHAR(200),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (auction_id, bidder_id)
+);
+
+COMMIT;
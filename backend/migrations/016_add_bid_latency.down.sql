@@ -0,0 +1,13 @@
+-- Migration: 016_add_bid_latency (rollback)
+-- Description: 入札の受信時刻と遅延計測値のカラムを削除する
+-- Date: 2026-10-19
+
+BEGIN;
+
+ALTER TABLE bids
+    DROP CONSTRAINT IF EXISTS chk_bids_client_rtt_non_negative,
+    DROP COLUMN IF EXISTS clock_offset_ms,
+    DROP COLUMN IF EXISTS client_rtt_ms,
+    DROP COLUMN IF EXISTS received_at;
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Migration: 016_add_bid_latency
+-- Description: 入札の先着紛争を検証できるよう、サーバー受信時刻と接続の遅延計測値を入札に記録する
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- received_at: サーバーが入札リクエストを受信した時刻（bid_atは入札確定時刻）
+-- client_rtt_ms: 入札時点の接続の往復遅延（ミリ秒、WebSocket経由の入札のみ）
+-- clock_offset_ms: クライアント時計のサーバー時計に対するずれ（ミリ秒、クライアントが時刻同期を行った場合のみ）
+ALTER TABLE bids
+    ADD COLUMN received_at TIMESTAMPTZ,
+    ADD COLUMN client_rtt_ms INTEGER,
+    ADD COLUMN clock_offset_ms INTEGER,
+    ADD CONSTRAINT chk_bids_client_rtt_non_negative CHECK (client_rtt_ms IS NULL OR client_rtt_ms >= 0);
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Migration: 017_create_bid_queue_entries (rollback)
+-- Description: 入札キューの監査テーブルを削除する
+-- Date: 2026-10-19
+
+BEGIN;
+
+DROP TABLE IF EXISTS bid_queue_entries;
+
+COMMIT;
@@ -0,0 +1,28 @@
+-- Migration: 017_create_bid_queue_entries
+-- Description: 入札キューへの到着順と処理結果を監査用に記録するテーブルを追加する
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- 入札リクエスト1件ごとに1行を記録する（受理されなかった入札も含む）
+-- position: 商品ごとの入札キューへの到着順（Redisが利用できない場合はNULL）
+-- outcome: accepted（受理）、outbid_at_price（同じ価格で先着の入札が受理済み）、rejected（検証エラー）
+-- bid_id: 受理された入札、またはoutbid_at_priceの場合は先に受理された入札
+CREATE TABLE bid_queue_entries (
+    id BIGSERIAL PRIMARY KEY,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    bidder_id UUID NOT NULL REFERENCES bidders(id),
+    price BIGINT NOT NULL,
+    position BIGINT,
+    outcome VARCHAR(20) NOT NULL,
+    bid_id BIGINT REFERENCES bids(id) ON DELETE SET NULL,
+    reason VARCHAR(50),
+    received_at TIMESTAMPTZ NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT chk_bid_queue_entries_outcome CHECK (outcome IN ('accepted', 'outbid_at_price', 'rejected'))
+);
+
+CREATE INDEX idx_bid_queue_entries_item ON bid_queue_entries(item_id, position);
+CREATE INDEX idx_bid_queue_entries_bidder ON bid_queue_entries(bidder_id);
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Migration: 018_add_item_fence_token (rollback)
+-- Description: 商品のフェンシングトークンのカラムを削除する
+-- Date: 2026-10-19
+
+BEGIN;
+
+ALTER TABLE items DROP COLUMN IF EXISTS fence_token;
+
+COMMIT;
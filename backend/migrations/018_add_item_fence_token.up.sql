@@ -0,0 +1,11 @@
+-- Migration: 018_add_item_fence_token
+-- Description: 商品操作（入札・価格開示・商品終了）のフェンシングトークンを商品に記録する
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- fence_token: 商品に対して最後に書き込みを行った操作のトークン
+-- 操作は行ロック下で読んだ fence_token と一致する場合のみ + 1 して書き込み、一致しなければ古い書き込みとして拒否される
+ALTER TABLE items ADD COLUMN fence_token BIGINT NOT NULL DEFAULT 0;
+
+COMMIT;
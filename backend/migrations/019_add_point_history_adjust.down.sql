@@ -0,0 +1,11 @@
+-- Migration: 019_add_point_history_adjust (rollback)
+-- Description: ポイント履歴のadjust種別を削除する（adjustの履歴が存在する場合は失敗する）
+-- Date: 2026-10-19
+
+BEGIN;
+
+ALTER TABLE point_history DROP CONSTRAINT IF EXISTS chk_point_history_type;
+ALTER TABLE point_history ADD CONSTRAINT chk_point_history_type
+    CHECK (type IN ('grant', 'reserve', 'release', 'consume', 'refund'));
+
+COMMIT;
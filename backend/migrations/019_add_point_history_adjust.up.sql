@@ -0,0 +1,11 @@
+-- Migration: 019_add_point_history_adjust
+-- Description: ポイント台帳の照合で検出した不整合を補正するadjust種別をポイント履歴に追加する
+-- Date: 2026-10-19
+
+BEGIN;
+
+ALTER TABLE point_history DROP CONSTRAINT IF EXISTS chk_point_history_type;
+ALTER TABLE point_history ADD CONSTRAINT chk_point_history_type
+    CHECK (type IN ('grant', 'reserve', 'release', 'consume', 'refund', 'adjust'));
+
+COMMIT;
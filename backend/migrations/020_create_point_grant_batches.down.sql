@@ -0,0 +1,11 @@
+-- Migration: 020_create_point_grant_batches (rollback)
+-- Description: ポイント履歴のバッチIDとポイント一括付与のバッチテーブルを削除する
+-- Date: 2026-10-19
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_point_history_batch_id;
+ALTER TABLE point_history DROP COLUMN IF EXISTS batch_id;
+DROP TABLE IF EXISTS point_grant_batches;
+
+COMMIT;
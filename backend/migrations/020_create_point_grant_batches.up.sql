@@ -0,0 +1,26 @@
+-- Migration: 020_create_point_grant_batches
+-- Description: ポイント一括付与のバッチを記録するテーブルを追加し、ポイント履歴にバッチIDを追加する
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- 一括付与1回ごとに1行を記録する
+-- status: applied（適用済み）、reversed（取り消し済み）
+CREATE TABLE point_grant_batches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_id BIGINT REFERENCES admins(id) ON DELETE SET NULL,
+    row_count INTEGER NOT NULL,
+    total_points BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'applied',
+    reversed_by BIGINT REFERENCES admins(id) ON DELETE SET NULL,
+    reversed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT chk_point_grant_batches_status CHECK (status IN ('applied', 'reversed'))
+);
+
+-- 一括付与とその取り消しで作成したポイント履歴にバッチIDを記録する
+ALTER TABLE point_history ADD COLUMN batch_id UUID REFERENCES point_grant_batches(id);
+
+CREATE INDEX idx_point_history_batch_id ON point_history(batch_id) WHERE batch_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Migration: 021_create_point_lots (rollback)
+-- Description: ポイントロットテーブルを削除し、ポイント履歴のexpire種別を削除する（expireの履歴が存在する場合は失敗する）
+-- Date: 2026-10-19
+
+BEGIN;
+
+DROP TABLE IF EXISTS point_lots;
+
+ALTER TABLE point_history DROP CONSTRAINT IF EXISTS chk_point_history_type;
+ALTER TABLE point_history ADD CONSTRAINT chk_point_history_type
+    CHECK (type IN ('grant', 'reserve', 'release', 'consume', 'refund', 'adjust'));
+
+COMMIT;
@@ -0,0 +1,32 @@
+-- Migration: 021_create_point_lots
+-- Description: ポイント付与ごとの有効期限を管理するロットテーブルを追加し、ポイント履歴にexpire種別を追加する
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- ポイント付与1件ごとに1行を記録する（有効期限のない付与も含む）
+-- 使用済みのポイントは古いロットから順に消費されたものとして扱う（FIFO）
+-- expires_at: 有効期限（NULLは無期限）
+-- expired_amount: 有効期限切れで失効したポイント数
+-- closed_at: 失効処理または付与の取り消しによりロットを閉じた日時
+CREATE TABLE point_lots (
+    id BIGSERIAL PRIMARY KEY,
+    bidder_id UUID NOT NULL REFERENCES bidders(id) ON DELETE CASCADE,
+    history_id BIGINT REFERENCES point_history(id) ON DELETE SET NULL,
+    amount BIGINT NOT NULL,
+    expires_at TIMESTAMPTZ,
+    expired_amount BIGINT NOT NULL DEFAULT 0,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT chk_point_lots_amount CHECK (amount > 0)
+);
+
+CREATE INDEX idx_point_lots_bidder_open ON point_lots(bidder_id, created_at) WHERE closed_at IS NULL;
+CREATE INDEX idx_point_lots_expires_at ON point_lots(expires_at) WHERE closed_at IS NULL AND expires_at IS NOT NULL;
+CREATE INDEX idx_point_lots_history_id ON point_lots(history_id);
+
+ALTER TABLE point_history DROP CONSTRAINT IF EXISTS chk_point_history_type;
+ALTER TABLE point_history ADD CONSTRAINT chk_point_history_type
+    CHECK (type IN ('grant', 'reserve', 'release', 'consume', 'refund', 'adjust', 'expire'));
+
+COMMIT;
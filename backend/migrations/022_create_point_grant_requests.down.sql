@@ -0,0 +1,9 @@
+-- Migration: 022_create_point_grant_requests (rollback)
+-- Description: ポイント付与の承認待ちテーブルを削除する
+-- Date: 2026-10-19
+
+BEGIN;
+
+DROP TABLE IF EXISTS point_grant_requests;
+
+COMMIT;
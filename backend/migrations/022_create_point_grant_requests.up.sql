@@ -0,0 +1,29 @@
+-- Migration: 022_create_point_grant_requests
+-- Description: 閾値を超えるポイント付与を別のシステム管理者の承認待ちとして記録するテーブルを追加する
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- status: pending（承認待ち）、approved（承認済み・付与済み）、rejected（却下）
+-- requested_by: 付与を申請した管理者、decided_by: 承認または却下した管理者（申請者とは別の管理者）
+-- history_id: 承認により作成したポイント履歴
+CREATE TABLE point_grant_requests (
+    id BIGSERIAL PRIMARY KEY,
+    bidder_id UUID NOT NULL REFERENCES bidders(id) ON DELETE CASCADE,
+    points BIGINT NOT NULL,
+    expires_at TIMESTAMPTZ,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    requested_by BIGINT NOT NULL REFERENCES admins(id),
+    decided_by BIGINT REFERENCES admins(id),
+    decision_note TEXT,
+    history_id BIGINT REFERENCES point_history(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    decided_at TIMESTAMPTZ,
+    CONSTRAINT chk_point_grant_requests_points CHECK (points > 0),
+    CONSTRAINT chk_point_grant_requests_status CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT chk_point_grant_requests_decider CHECK (decided_by IS NULL OR decided_by <> requested_by)
+);
+
+CREATE INDEX idx_point_grant_requests_status ON point_grant_requests(status, created_at);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Migration: 023_add_point_history_item (rollback)
+-- Description: ポイント履歴の関連商品を削除する（補完したrelated_auction_idはそのまま残す）
+-- Date: 2026-10-19
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_point_history_related_item_id;
+ALTER TABLE point_history DROP COLUMN IF EXISTS related_item_id;
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- Migration: 023_add_point_history_item
+-- Description: ポイント履歴に関連商品（UUID）を追加し、入札に紐づく既存履歴のオークション・商品を補完する
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- related_auction_idは009でUUIDに変換済み（アプリケーション側のみBIGINTのままだった）
+ALTER TABLE point_history ADD COLUMN related_item_id UUID REFERENCES items(id) ON DELETE SET NULL;
+
+-- 入札に紐づく履歴（reserve/release/consume）は入札から商品とオークションを補完する
+UPDATE point_history ph
+SET related_item_id = b.item_id,
+    related_auction_id = COALESCE(ph.related_auction_id, i.auction_id)
+FROM bids b
+JOIN items i ON b.item_id = i.id
+WHERE ph.related_bid_id = b.id;
+
+CREATE INDEX idx_point_history_related_item_id ON point_history(related_item_id);
+
+COMMIT;
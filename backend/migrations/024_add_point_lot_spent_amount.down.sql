@@ -0,0 +1,10 @@
+-- Migration: 024_add_point_lot_spent_amount (rollback)
+-- Description: ポイントロットの使用済みポイント数を削除する
+-- Date: 2026-10-19
+
+BEGIN;
+
+ALTER TABLE point_lots DROP CONSTRAINT IF EXISTS chk_point_lots_spent_amount;
+ALTER TABLE point_lots DROP COLUMN IF EXISTS spent_amount;
+
+COMMIT;
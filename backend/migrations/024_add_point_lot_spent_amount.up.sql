@@ -0,0 +1,28 @@
+-- Migration: 024_add_point_lot_spent_amount
+-- Description: ポイントロットに使用済みポイント数を追加し、実際の消費（落札・減算調整）のみでロットを消費するようにする
+-- Date: 2026-10-19
+
+BEGIN;
+
+-- spent_amount: 落札による消費と減算の手動調整で使用されたポイント数（古いロットから順に割り当てる）
+-- 返金や加算調整などロットを伴わない付与はロットの未使用分に含めない
+ALTER TABLE point_lots ADD COLUMN spent_amount BIGINT NOT NULL DEFAULT 0;
+
+-- 既存の未クローズのロットは、従来どおり残高を新しいロットから順に割り当てた残りを使用済みとする
+UPDATE point_lots pl
+SET spent_amount = pl.amount - GREATEST(0, LEAST(pl.amount, bp.total_points - newer.amount))
+FROM bidder_points bp,
+LATERAL (
+    SELECT COALESCE(SUM(n.amount), 0) AS amount
+    FROM point_lots n
+    WHERE n.bidder_id = pl.bidder_id
+      AND n.closed_at IS NULL
+      AND (n.created_at, n.id) > (pl.created_at, pl.id)
+) newer
+WHERE bp.bidder_id = pl.bidder_id
+  AND pl.closed_at IS NULL;
+
+ALTER TABLE point_lots ADD CONSTRAINT chk_point_lots_spent_amount
+    CHECK (spent_amount >= 0 AND spent_amount + expired_amount <= amount);
+
+COMMIT;
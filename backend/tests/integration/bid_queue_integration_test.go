@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// setupTestRedis connects to the test Redis or skips the test
+func setupTestRedis(t *testing.T) *redis.Client {
+	client, err := repository.NewRedisClient()
+	if err != nil {
+		t.Skipf("Failed to connect to test Redis: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestBidQueueOrderIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	redisClient := setupTestRedis(t)
+	adminID := getSeedAdminID(t, db)
+
+	bidRepo := repository.NewBidRepository(db)
+	bidService := service.NewBidService(db, redisClient, bidRepo, repository.NewPointRepository(db), repository.NewAuctionRepository(db))
+
+	item := createStartedTestItem(t, db, 1000)
+	const bidders = 10
+
+	bidderIDs := make([]string, bidders)
+	for i := range bidderIDs {
+		bidderIDs[i] = createTestBidder(t, db, adminID, 5000)
+	}
+
+	var wg sync.WaitGroup
+	for _, bidderID := range bidderIDs {
+		wg.Add(1)
+		go func(bidderID string) {
+			defer wg.Done()
+			_, err := bidService.PlaceBid(&service.PlaceBidRequest{
+				ItemID:   item.ID.String(),
+				BidderID: bidderID,
+				Price:    1000,
+			})
+			if err != nil {
+				assert.True(t, errors.Is(err, service.ErrOutbidAtPrice), err.Error())
+			}
+		}(bidderID)
+	}
+	wg.Wait()
+
+	entries, err := bidRepo.FindQueueEntriesByItemID(item.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, bidders)
+
+	// The request first in the queue took the price; every later one was outbid by it
+	var accepted *domain.BidQueueEntry
+	for i := range entries {
+		if entries[i].Outcome == domain.BidQueueOutcomeAccepted {
+			require.Nil(t, accepted, "more than one bid accepted at the same price")
+			accepted = &entries[i]
+		}
+	}
+	require.NotNil(t, accepted)
+	require.NotNil(t, accepted.Position)
+
+	for _, entry := range entries {
+		require.NotNil(t, entry.Position)
+		if entry.ID == accepted.ID {
+			continue
+		}
+		assert.Equal(t, domain.BidQueueOutcomeOutbidAtPrice, entry.Outcome)
+		assert.Equal(t, accepted.BidID, entry.BidID)
+		assert.Greater(t, *entry.Position, *accepted.Position)
+	}
+}
@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"gorm.io/gorm"
+)
+
+// createStartedTestItem creates an active auction with one item and starts the item
+func createStartedTestItem(t *testing.T, db *gorm.DB, startingPrice int64) *domain.Item {
+	auctionRepo := repository.NewAuctionRepository(db)
+	auction := &domain.Auction{
+		Title:  fmt.Sprintf("Integration %s", uuid.NewString()),
+		Status: domain.AuctionStatusActive,
+	}
+	items := []domain.Item{
+		{Name: "Integration item", StartingPrice: &startingPrice, LotNumber: 1},
+	}
+	require.NoError(t, auctionRepo.CreateAuctionWithItems(auction, items))
+
+	item, err := auctionRepo.StartItem(items[0].ID.String())
+	require.NoError(t, err)
+	return item
+}
+
+func TestAdvanceFenceTokenIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	auctionRepo := repository.NewAuctionRepository(db)
+
+	t.Run("Concurrent operations holding the item lock all advance the token", func(t *testing.T) {
+		item := createStartedTestItem(t, db, 1000)
+		const operations = 20
+
+		var wg sync.WaitGroup
+		for i := 0; i < operations; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := db.Transaction(func(tx *gorm.DB) error {
+					locked, err := auctionRepo.FindItemForUpdate(item.ID, tx)
+					if err != nil {
+						return err
+					}
+					advanced, err := auctionRepo.AdvanceFenceToken(item.ID, locked.FenceToken, tx)
+					if err != nil {
+						return err
+					}
+					assert.True(t, advanced)
+					return nil
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		locked, err := auctionRepo.FindItemForUpdate(item.ID, db)
+		require.NoError(t, err)
+		assert.Equal(t, item.FenceToken+operations, locked.FenceToken)
+	})
+
+	t.Run("Writer holding a stale read is rejected", func(t *testing.T) {
+		item := createStartedTestItem(t, db, 1000)
+
+		stale := db.Begin()
+		defer stale.Rollback()
+
+		// The stale writer reads the item before another operation writes to it
+		var staleItem domain.Item
+		require.NoError(t, stale.First(&staleItem, "id = ?", item.ID).Error)
+
+		require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+			advanced, err := auctionRepo.AdvanceFenceToken(item.ID, staleItem.FenceToken, tx)
+			assert.True(t, advanced)
+			return err
+		}))
+
+		advanced, err := auctionRepo.AdvanceFenceToken(item.ID, staleItem.FenceToken, stale)
+		require.NoError(t, err)
+		assert.False(t, advanced)
+
+		locked, err := auctionRepo.FindItemForUpdate(item.ID, db)
+		require.NoError(t, err)
+		assert.Equal(t, staleItem.FenceToken+1, locked.FenceToken)
+	})
+}
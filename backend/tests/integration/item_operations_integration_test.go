@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+)
+
+// expectedBidErrors are the outcomes a bid may legitimately get while other operations race it
+var expectedBidErrors = []error{
+	service.ErrPriceMismatch,
+	service.ErrOutbidAtPrice,
+	service.ErrAlreadyWinningBidder,
+	service.ErrItemAlreadyEnded,
+	service.ErrBidLockFailed,
+}
+
+func isExpectedError(err error, expected ...error) bool {
+	for _, target := range expected {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestItemOperationsConcurrencyIntegration races bids against price disclosures and item end
+// on two items sharing the same bidders
+func TestItemOperationsConcurrencyIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	adminID := getSeedAdminID(t, db)
+
+	auctionRepo := repository.NewAuctionRepository(db)
+	bidRepo := repository.NewBidRepository(db)
+	pointRepo := repository.NewPointRepository(db)
+	bidService := service.NewBidService(db, nil, bidRepo, pointRepo, auctionRepo)
+	auctionService := service.NewAuctionService(db, auctionRepo, bidRepo, pointRepo, nil)
+
+	const (
+		bidders       = 8
+		initialPoints = int64(20000)
+		priceSteps    = 5
+		adminRacers   = 3
+	)
+
+	items := []*domain.Item{createStartedTestItem(t, db, 1000), createStartedTestItem(t, db, 1000)}
+	bidderIDs := make([]string, bidders)
+	for i := range bidderIDs {
+		bidderIDs[i] = createTestBidder(t, db, adminID, initialPoints)
+	}
+
+	endResults := make([]*domain.EndItemResponse, len(items))
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		itemID := item.ID.String()
+
+		// Bidders keep bidding at the current price until the item ends
+		for _, bidderID := range bidderIDs {
+			wg.Add(1)
+			go func(bidderID string) {
+				defer wg.Done()
+				for attempt := 0; attempt < 500; attempt++ {
+					current, err := auctionRepo.FindItemByID(itemID)
+					if !assert.NoError(t, err) || current.EndedAt != nil {
+						return
+					}
+					_, err = bidService.PlaceBid(&service.PlaceBidRequest{
+						ItemID:   itemID,
+						BidderID: bidderID,
+						Price:    *current.CurrentPrice,
+					})
+					if err != nil && !isExpectedError(err, expectedBidErrors...) {
+						t.Errorf("unexpected bid error: %v", err)
+						return
+					}
+					time.Sleep(2 * time.Millisecond)
+				}
+			}(bidderID)
+		}
+
+		// Several admins disclose the same prices, then end the item, at the same time
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var adminWG sync.WaitGroup
+
+			for step := int64(1); step <= priceSteps; step++ {
+				time.Sleep(20 * time.Millisecond)
+				price := 1000 + step*1000
+				for r := 0; r < adminRacers; r++ {
+					adminWG.Add(1)
+					go func() {
+						defer adminWG.Done()
+						_, err := auctionService.OpenPrice(itemID, price, adminID)
+						if err != nil && !isExpectedError(err, service.ErrPriceTooLow) {
+							t.Errorf("unexpected open price error: %v", err)
+						}
+					}()
+				}
+				adminWG.Wait()
+			}
+
+			time.Sleep(20 * time.Millisecond)
+			var mu sync.Mutex
+			for r := 0; r < adminRacers; r++ {
+				adminWG.Add(1)
+				go func() {
+					defer adminWG.Done()
+					result, err := auctionService.EndItem(itemID)
+					if err != nil {
+						if !isExpectedError(err, service.ErrItemAlreadyEnded) {
+							t.Errorf("unexpected end item error: %v", err)
+						}
+						return
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					assert.Nil(t, endResults[i], "item ended twice")
+					endResults[i] = result
+				}()
+			}
+			adminWG.Wait()
+		}(i)
+	}
+	wg.Wait()
+
+	spent := map[string]int64{}
+	for i, item := range items {
+		require.NotNil(t, endResults[i], "item %s was not ended", item.ID)
+
+		ended, err := auctionRepo.FindItemByID(item.ID.String())
+		require.NoError(t, err)
+		require.NotNil(t, ended.EndedAt)
+
+		// One winning bid per item, and it is the one the item ended with
+		var winning []domain.Bid
+		require.NoError(t, db.Where("item_id = ? AND is_winning = ?", item.ID, true).Find(&winning).Error)
+		require.LessOrEqual(t, len(winning), 1)
+		if len(winning) == 1 {
+			require.NotNil(t, ended.WinnerID)
+			assert.Equal(t, winning[0].BidderID, *ended.WinnerID)
+			assert.Equal(t, winning[0].Price, endResults[i].FinalPrice)
+			spent[winning[0].BidderID.String()] += winning[0].Price
+		} else {
+			assert.Nil(t, ended.WinnerID)
+		}
+
+		// No bid lands after the item ended
+		var lateBids int64
+		require.NoError(t, db.Model(&domain.Bid{}).
+			Where("item_id = ? AND bid_at > ?", item.ID, *ended.EndedAt).
+			Count(&lateBids).Error)
+		assert.Zero(t, lateBids)
+	}
+
+	// Every reservation was released or consumed, and the ledger balances
+	for _, bidderID := range bidderIDs {
+		points, err := pointRepo.GetCurrentPoints(bidderID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, points.TotalPoints, points.AvailablePoints+points.ReservedPoints, "bidder %s", bidderID)
+		assert.Zero(t, points.ReservedPoints, "bidder %s", bidderID)
+		assert.Equal(t, initialPoints-spent[bidderID], points.TotalPoints, "bidder %s", bidderID)
+	}
+}
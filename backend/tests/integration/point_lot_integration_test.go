@@ -0,0 +1,79 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsutsumi389/real-time-auction/internal/domain"
+	"github.com/tsutsumi389/real-time-auction/internal/repository"
+	"github.com/tsutsumi389/real-time-auction/internal/service"
+	"gorm.io/gorm"
+)
+
+// getSeedAdminID returns the ID of the seeded system admin
+func getSeedAdminID(t *testing.T, db *gorm.DB) int64 {
+	var adminID int64
+	if err := db.Raw("SELECT id FROM admins WHERE email = ?", "admin@example.com").Scan(&adminID).Error; err != nil || adminID == 0 {
+		t.Skipf("Seed admin not found: %v", err)
+	}
+	return adminID
+}
+
+// createTestBidder creates an active bidder with the given initial points and returns its ID
+func createTestBidder(t *testing.T, db *gorm.DB, adminID int64, initialPoints int64) string {
+	bidderRepo := repository.NewBidderRepository(db)
+	bidder := &domain.Bidder{
+		Email:        fmt.Sprintf("bidder-%s@example.com", uuid.NewString()),
+		PasswordHash: "not-used",
+		Status:       domain.BidderStatusActive,
+	}
+
+	response, err := bidderRepo.CreateBidderWithPoints(bidder, initialPoints, adminID)
+	require.NoError(t, err)
+	return response.ID
+}
+
+func TestPointGrantBatchReverseAfterExpiryIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	adminID := getSeedAdminID(t, db)
+
+	bidderRepo := repository.NewBidderRepository(db)
+	pointRepo := repository.NewPointRepository(db)
+	batchService := service.NewPointGrantBatchService(db, repository.NewPointGrantBatchRepository(db), pointRepo)
+	expiryService := service.NewPointExpiryService(db, pointRepo)
+
+	bidderID := createTestBidder(t, db, adminID, 0)
+
+	expiresAt := time.Now().Add(time.Hour)
+	result, err := batchService.Apply([]domain.PointGrantRow{
+		{Bidder: bidderID, Points: 1000, Reason: "Campaign", ExpiresAt: &expiresAt},
+	}, adminID)
+	require.NoError(t, err)
+
+	// 300 of the lot are spent, then 500 are credited without a lot
+	_, _, err = bidderRepo.AdjustPoints(bidderID, -300, "Penalty", adminID)
+	require.NoError(t, err)
+	_, _, err = bidderRepo.AdjustPoints(bidderID, 500, "Goodwill", adminID)
+	require.NoError(t, err)
+
+	// Only the 700 unused points of the lot expire
+	_, err = expiryService.ExpirePoints(expiresAt.Add(time.Minute))
+	require.NoError(t, err)
+
+	points, err := pointRepo.GetCurrentPoints(bidderID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), points.AvailablePoints)
+
+	// The reversal deducts the granted points that did not expire
+	_, err = batchService.Reverse(result.Batch.ID, adminID)
+	require.NoError(t, err)
+
+	points, err = pointRepo.GetCurrentPoints(bidderID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), points.AvailablePoints)
+	assert.Equal(t, int64(200), points.TotalPoints)
+}
@@ -28,3 +28,13 @@ export async function getAuctionDetail(id) {
   const response = await apiClient.get(`/auctions/${id}`)
   return response.data
 }
+
+/**
+ * オークションのライブ状態を取得（公開エンドポイント、認証不要）
+ * @param {string} id - オークションID（UUID）
+ * @returns {Promise<object>} ライブ状態（current_item, winning_bid, progress）
+ */
+export async function getAuctionLiveState(id) {
+  const response = await apiClient.get(`/auctions/${id}/live`)
+  return response.data
+}
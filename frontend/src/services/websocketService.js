@@ -2,7 +2,11 @@
  * WebSocket Service
  * オークションライブ画面用のWebSocket接続管理
  */
-import { getWsUrl } from '../config/api'
+import axios from 'axios'
+import { getApiBaseUrl, getWsUrl } from '../config/api'
+
+// 自動再接続しないクローズコード（サーバー側の定義と対応）
+const NON_RETRYABLE_CLOSE_CODES = [4401, 4409]
 
 class WebSocketService {
   constructor() {
@@ -36,19 +40,47 @@ class WebSocketService {
     }
   }
 
+  /**
+   * WebSocket接続用の使い捨てチケットを取得
+   * JWTをURLに含めるとプロキシのログに残るため、接続ごとに短命なチケットを発行して使用する
+   * @param {string} token - JWT認証トークン
+   * @returns {Promise<string>} チケット
+   */
+  async fetchTicket(token) {
+    const response = await axios.post(`${getApiBaseUrl()}/ws/ticket`, null, {
+      headers: { Authorization: `Bearer ${token}` },
+    })
+    return response.data.ticket
+  }
+
   /**
    * WebSocket接続を確立
    * @param {string} token - JWT認証トークン
    * @param {string} auctionId - オークションID
    */
-  connect(token, auctionId) {
+  async connect(token, auctionId) {
     this.token = token
     this.auctionId = auctionId
     this.isIntentionalClose = false
 
+    // 接続ごとにチケットを取得（チケットは1回のみ使用可能）
+    let ticket
+    try {
+      ticket = await this.fetchTicket(token)
+    } catch (error) {
+      console.error('[WebSocket] Failed to obtain ticket:', error)
+      this.emit('error', { message: 'WebSocket接続に失敗しました' })
+      return
+    }
+
+    // チケット取得中に切断された場合は接続しない
+    if (this.isIntentionalClose) {
+      return
+    }
+
     // 動的にWebSocket URLを取得（ローカルネットワーク対応）
     this.url = getWsUrl()
-    const wsUrl = `${this.url}?token=${encodeURIComponent(token)}&auction_id=${encodeURIComponent(auctionId)}`
+    const wsUrl = `${this.url}?ticket=${encodeURIComponent(ticket)}&auction_id=${encodeURIComponent(auctionId)}`
 
     try {
       this.ws = new WebSocket(wsUrl)
@@ -87,6 +119,16 @@ class WebSocketService {
         this.stopPingTimer()
         this.emit('disconnected', { code: event.code, reason: event.reason })
 
+        // サーバーが再接続すべきでないと通知した場合は再接続しない
+        // 4401: 認証情報が無効, 4409: 別のタブ・端末の接続に置き換えられた
+        if (NON_RETRYABLE_CLOSE_CODES.includes(event.code)) {
+          const message = event.code === 4409
+            ? '別の画面で接続されたため、この画面の接続を終了しました。'
+            : '認証の有効期限が切れました。再度ログインしてください。'
+          this.emit('error', { message, code: event.code })
+          return
+        }
+
         // 意図的なクローズでない場合は自動再接続を試みる
         if (!this.isIntentionalClose && this.reconnectAttempts < this.maxReconnectAttempts) {
           this.reconnectAttempts++
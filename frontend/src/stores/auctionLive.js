@@ -511,6 +511,12 @@ export const useAuctionLiveStore = defineStore('auctionLive', () => {
     websocketService.on('auction:ended', onAuctionEnded)
     websocketService.on('auction:cancelled', onAuctionCancelled)
 
+    // 送信が追いつかずサーバーから切断される場合は、再接続後に状態を取得し直す
+    let resyncRequired = false
+    websocketService.on('connection:resync_required', () => {
+      resyncRequired = true
+    })
+
     websocketService.on('connected', () => {
       wsConnected.value = true
       wsReconnecting.value = false
@@ -523,6 +529,11 @@ export const useAuctionLiveStore = defineStore('auctionLive', () => {
         }
       })
       console.log('Sent subscribe event for auction:', auctionId)
+
+      if (resyncRequired) {
+        resyncRequired = false
+        initialize(auctionId)
+      }
     })
 
     websocketService.on('disconnected', () => {
@@ -92,9 +92,9 @@ export const useBidderStore = defineStore('bidder', () => {
     try {
       const response = await grantPoints(bidderId, points)
 
-      // 一覧内の該当入札者を更新
+      // 一覧内の該当入札者を更新（承認待ちの場合はまだ付与されていない）
       const index = bidders.value.findIndex((bidder) => bidder.id === bidderId)
-      if (index !== -1) {
+      if (index !== -1 && response.bidder) {
         bidders.value[index] = {
           ...bidders.value[index],
           total_points: response.bidder.total_points,
@@ -41,6 +41,7 @@ export const useBidderAuctionLiveStore = defineStore('bidderAuctionLive', () =>
   const reconnectAttempt = ref(0)
   const maxReconnectAttempts = ref(5)
   const hasBidAtCurrentPrice = ref(false) // 現在価格で既に入札があるか
+  let resyncRequired = false // 再接続後に状態の再取得が必要か
 
   // Computed
 
@@ -282,6 +283,7 @@ export const useBidderAuctionLiveStore = defineStore('bidderAuctionLive', () =>
 
     // イベントハンドラーを登録
     websocketService.on('connected', onWebSocketConnected)
+    websocketService.on('connection:resync_required', onResyncRequired)
     websocketService.on('disconnected', onWebSocketDisconnected)
     websocketService.on('reconnecting', onWebSocketReconnecting)
     websocketService.on('error', onWebSocketError)
@@ -304,6 +306,7 @@ export const useBidderAuctionLiveStore = defineStore('bidderAuctionLive', () =>
   function disconnectWebSocket() {
     // イベントハンドラーを解除
     websocketService.off('connected', onWebSocketConnected)
+    websocketService.off('connection:resync_required', onResyncRequired)
     websocketService.off('disconnected', onWebSocketDisconnected)
     websocketService.off('reconnecting', onWebSocketReconnecting)
     websocketService.off('error', onWebSocketError)
@@ -325,6 +328,14 @@ export const useBidderAuctionLiveStore = defineStore('bidderAuctionLive', () =>
 
   // WebSocketイベントハンドラー
 
+  /**
+   * 再同期要求ハンドラ（送信が追いつかずサーバーから切断される直前に受信する）
+   */
+  function onResyncRequired() {
+    console.warn('[bidderAuctionLive] Resync required')
+    resyncRequired = true
+  }
+
   function onWebSocketConnected() {
     console.log('[bidderAuctionLive] WebSocket connected')
     wsConnected.value = true
@@ -340,12 +351,18 @@ export const useBidderAuctionLiveStore = defineStore('bidderAuctionLive', () =>
         }
       })
       console.log('[bidderAuctionLive] Sent subscribe event for auction:', auction.value.id)
+
+      // 取りこぼしたイベントがあるため状態を取得し直す
+      if (resyncRequired) {
+        resyncRequired = false
+        initialize(auction.value.id)
+      }
     }
   }
 
   /**
    * 参加者リスト受信イベント
-   * @param {object} payload - { participants: [{ id, display_name }], count }
+   * @param {object} payload - { participants: [{ paddle_number, is_mine }], count }
    */
   function onParticipantsList(payload) {
     console.log('[bidderAuctionLive] Participants list:', payload)
@@ -354,14 +371,13 @@ export const useBidderAuctionLiveStore = defineStore('bidderAuctionLive', () =>
 
   /**
    * 参加者参加イベント
-   * @param {object} payload - { participant: { id, display_name }, count }
+   * @param {object} payload - { participant: { paddle_number, is_mine }, count }
    */
   function onParticipantJoined(payload) {
     console.log('[bidderAuctionLive] Participant joined:', payload)
 
     // 自分自身の参加イベントは無視（participants:listで正確な人数を受信するため）
-    const user = getUserFromToken('bidder')
-    if (user && payload.participant && payload.participant.bidder_id === user.bidderId) {
+    if (payload.participant?.is_mine) {
       console.log('[bidderAuctionLive] Ignoring own participant:joined event')
       return
     }